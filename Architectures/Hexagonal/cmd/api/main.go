@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	httphandler "kiosco/internal/adapter/input/http"
 	"kiosco/internal/adapter/output/api"
+	"kiosco/internal/adapter/output/idgen"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/adapter/output/postgres"
+	"kiosco/internal/adapter/output/rates"
+	"kiosco/internal/adapter/output/sqlite"
 	"kiosco/internal/application"
 	"kiosco/internal/config"
+	"kiosco/internal/domain"
 	"log"
 	"net/http"
 )
@@ -17,23 +24,100 @@ func main() {
 		log.Fatalf("Error al cargar configuración: %v", err)
 	}
 
-	// Inicializar adaptador de salida (repositorio HTTP)
-	itemRepository := api.NewHTTPItemRepository(cfg.ExternalAPIURL)
+	// Inicializar adaptador de salida según el backend configurado
+	repository, closeRepository, err := newRepository(context.Background(), cfg)
+	if err != nil {
+		log.Fatalf("Error al inicializar el repositorio: %v", err)
+	}
+	defer closeRepository()
 
 	// Inicializar casos de uso (servicio de aplicación)
-	itemService := application.NewItemService(itemRepository)
+	itemService := application.NewItemService(repository, idgen.NewUUIDv7Generator())
+	stockService := application.NewStockService(repository)
+	orderService := application.NewOrderService(repository, idgen.NewUUIDv7Generator(), cfg.TaxRate)
+	categoryService := application.NewCategoryService(repository, idgen.NewUUIDv7Generator())
+
+	// Moneda en la que se informan los precios, con sus cotizaciones
+	display, err := newDisplayCurrency(cfg)
+	if err != nil {
+		log.Fatalf("Error al cargar la moneda de visualización: %v", err)
+	}
 
 	// Inicializar adaptador de entrada (router HTTP)
-	router := httphandler.NewRouter(itemService)
+	router := httphandler.NewRouter(itemService, stockService, orderService, categoryService, display)
 	muxRouter := router.SetupRoutes()
 
 	// Iniciar servidor
 	serverAddr := fmt.Sprintf(":%s", cfg.ServerPort)
 	log.Printf("Servidor iniciado en http://localhost%s", serverAddr)
 	log.Printf("API disponible en http://localhost%s/api", serverAddr)
-	log.Printf("Conectado a API externa: %s", cfg.ExternalAPIURL)
 
 	if err := http.ListenAndServe(serverAddr, muxRouter); err != nil {
 		log.Fatalf("Error al iniciar servidor: %v", err)
 	}
 }
+
+// repository reúne los puertos de salida que implementa cada backend
+type repository interface {
+	domain.ItemRepository
+	domain.StockMovementRepository
+	domain.OrderRepository
+	domain.CategoryRepository
+}
+
+// newRepository crea el repositorio del backend configurado y la función
+// que libera sus recursos
+func newRepository(ctx context.Context, cfg *config.Config) (repository, func(), error) {
+	switch cfg.RepositoryBackend {
+	case config.BackendPostgres:
+		pool, err := postgres.NewPool(ctx, postgres.PoolConfig{
+			URL:             cfg.DatabaseURL,
+			MaxConns:        cfg.DatabaseMaxConns,
+			MinConns:        cfg.DatabaseMinConns,
+			MaxConnLifetime: cfg.DatabaseMaxConnLifetime,
+			MaxConnIdleTime: cfg.DatabaseMaxConnIdleTime,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Aplicar migraciones pendientes antes de atender peticiones
+		if err := postgres.Migrate(ctx, pool); err != nil {
+			pool.Close()
+			return nil, nil, err
+		}
+
+		log.Printf("Conectado a PostgreSQL (máximo %d conexiones)", cfg.DatabaseMaxConns)
+		return postgres.NewPostgresItemRepository(pool), pool.Close, nil
+	case config.BackendSQLite:
+		db, err := sqlite.Open(ctx, cfg.SQLitePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		log.Printf("Usando base SQLite: %s", cfg.SQLitePath)
+		return sqlite.NewSQLiteItemRepository(db), func() { db.Close() }, nil
+	case config.BackendMemory:
+		log.Printf("Usando repositorio en memoria: los datos se pierden al reiniciar")
+		return memory.NewMemoryItemRepository(), func() {}, nil
+	default:
+		log.Printf("Conectado a API externa: %s", cfg.ExternalAPIURL)
+		return api.NewHTTPItemRepository(cfg.ExternalAPIURL), func() {}, nil
+	}
+}
+
+// newDisplayCurrency crea la moneda de visualización con las cotizaciones del
+// archivo configurado; sin archivo solo se muestran los precios que ya están
+// en esa moneda
+func newDisplayCurrency(cfg *config.Config) (*domain.DisplayCurrency, error) {
+	var exchangeRates *domain.ExchangeRates
+	if cfg.ExchangeRatesFile != "" {
+		var err error
+		if exchangeRates, err = rates.LoadFile(cfg.ExchangeRatesFile); err != nil {
+			return nil, err
+		}
+		log.Printf("Cotizaciones cargadas de %s", cfg.ExchangeRatesFile)
+	}
+
+	return domain.NewDisplayCurrency(cfg.DisplayCurrency, exchangeRates)
+}
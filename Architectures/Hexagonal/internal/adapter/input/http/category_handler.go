@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"kiosco/internal/application"
+	"kiosco/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// CategoryHandler maneja las peticiones HTTP de las categorías de items
+type CategoryHandler struct {
+	service *application.CategoryService
+	display *domain.DisplayCurrency
+}
+
+// NewCategoryHandler crea una nueva instancia del handler; el valor del stock
+// también se informa en la moneda de visualización
+func NewCategoryHandler(service *application.CategoryService, display *domain.DisplayCurrency) *CategoryHandler {
+	return &CategoryHandler{
+		service: service,
+		display: display,
+	}
+}
+
+// ListCategories maneja GET /api/categories
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.service.ListCategories(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, categories)
+}
+
+// GetCategory maneja GET /api/categories/{id}
+func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	category, err := h.service.GetCategory(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrCategoryNotFound {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, category)
+}
+
+// CreateCategory maneja POST /api/categories
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var category domain.Category
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
+		return
+	}
+
+	created, err := h.service.CreateCategory(r.Context(), &category)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidCategoryName, domain.ErrCategoryIDNotAllowed:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case domain.ErrCategoryAlreadyExists:
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// UpdateCategory maneja PUT /api/categories/{id}; si cambia el nombre, los
+// items de la categoría pasan a usar el nuevo
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	var category domain.Category
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
+		return
+	}
+
+	updated, err := h.service.UpdateCategory(r.Context(), id, &category)
+	if err != nil {
+		switch err {
+		case domain.ErrCategoryNotFound:
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case domain.ErrInvalidCategoryName:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		case domain.ErrCategoryAlreadyExists:
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// DeleteCategory maneja DELETE /api/categories/{id}; responde 409 si algún
+// item usa la categoría
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), id); err != nil {
+		switch err {
+		case domain.ErrCategoryNotFound:
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case domain.ErrCategoryInUse:
+			respondWithError(w, http.StatusConflict, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// SummarizeCategories maneja GET /api/categories/summary
+func (h *CategoryHandler) SummarizeCategories(w http.ResponseWriter, r *http.Request) {
+	summaries, err := h.service.SummarizeCategories(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, newCategorySummaryResponses(summaries, h.display))
+}
@@ -0,0 +1,60 @@
+package http
+
+import "kiosco/internal/domain"
+
+// itemResponse agrega al item su precio en la moneda de visualización.
+// DisplayPrice se omite si falta la cotización de la moneda del item.
+type itemResponse struct {
+	*domain.Item
+	DisplayPrice *domain.Money `json:"display_price,omitempty"`
+}
+
+// itemPageResponse es una página de items con sus precios de visualización
+type itemPageResponse struct {
+	Items      []itemResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Total      int            `json:"total"`
+}
+
+// categorySummaryResponse agrega al resumen el valor del stock en la moneda
+// de visualización. StockValue se omite si falta alguna cotización.
+type categorySummaryResponse struct {
+	*domain.CategorySummary
+	StockValue *domain.Money `json:"stock_value,omitempty"`
+}
+
+// newItemResponse convierte el precio del item a la moneda de visualización
+func newItemResponse(item *domain.Item, display *domain.DisplayCurrency) itemResponse {
+	response := itemResponse{Item: item}
+	if price, err := display.Convert(item.Price); err == nil {
+		response.DisplayPrice = &price
+	}
+	return response
+}
+
+// newItemPageResponse convierte los precios de todos los items de la página
+func newItemPageResponse(page *domain.ItemPage, display *domain.DisplayCurrency) itemPageResponse {
+	response := itemPageResponse{
+		Items:      make([]itemResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		Total:      page.Total,
+	}
+	for _, item := range page.Items {
+		response.Items = append(response.Items, newItemResponse(item, display))
+	}
+	return response
+}
+
+// newCategorySummaryResponses suma el valor del stock de cada categoría en
+// la moneda de visualización
+func newCategorySummaryResponses(summaries []*domain.CategorySummary, display *domain.DisplayCurrency) []categorySummaryResponse {
+	responses := make([]categorySummaryResponse, 0, len(summaries))
+	for _, summary := range summaries {
+		response := categorySummaryResponse{CategorySummary: summary}
+		if value, err := display.Sum(summary.StockValues); err == nil {
+			response.StockValue = &value
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
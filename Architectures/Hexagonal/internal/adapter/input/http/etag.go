@@ -0,0 +1,92 @@
+package http
+
+import (
+	"kiosco/internal/domain"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// itemETag arma el ETag de un item a partir de su versión
+func itemETag(item *domain.Item) string {
+	return `"` + strconv.FormatInt(item.Version, 10) + `"`
+}
+
+// ifMatch es el header If-Match leído: "*" o las versiones de la lista de ETags
+type ifMatch struct {
+	any      bool
+	versions []int64
+}
+
+// parseIfMatch lee el header If-Match, que puede ser "*" o una lista de ETags
+// separados por comas (RFC 9110). Devuelve ErrVersionRequired si falta y
+// ErrVersionMismatch si ningún ETag es de item, porque un ETag que no
+// corresponde a ninguna versión nunca coincide. If-Match usa comparación
+// fuerte, así que los ETags débiles (W/"1") tampoco coinciden.
+func parseIfMatch(r *http.Request) (ifMatch, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return ifMatch{}, domain.ErrVersionRequired
+	}
+
+	var match ifMatch
+	for _, member := range strings.Split(value, ",") {
+		member = strings.TrimSpace(member)
+		if member == "*" {
+			match.any = true
+			continue
+		}
+		tag, err := strconv.Unquote(member)
+		if err != nil || !strings.HasPrefix(member, `"`) {
+			continue
+		}
+		version, err := strconv.ParseInt(tag, 10, 64)
+		if err != nil || version <= 0 {
+			continue
+		}
+		match.versions = append(match.versions, version)
+	}
+	if !match.any && len(match.versions) == 0 {
+		return ifMatch{}, domain.ErrVersionMismatch
+	}
+	return match, nil
+}
+
+// ifMatchVersion devuelve la versión que debe tener el item según If-Match.
+// Con un solo ETag es esa versión; con "*" o una lista se lee la versión
+// actual y se usa si coincide. El servicio la vuelve a comparar al guardar,
+// así que un cambio concurrente igual responde 412.
+func (h *ItemHandler) ifMatchVersion(r *http.Request, id string) (int64, error) {
+	match, err := parseIfMatch(r)
+	if err != nil {
+		return 0, err
+	}
+	if !match.any && len(match.versions) == 1 {
+		return match.versions[0], nil
+	}
+
+	item, err := h.service.GetItemByID(r.Context(), id)
+	if err != nil {
+		return 0, err
+	}
+	if match.any || slices.Contains(match.versions, item.Version) {
+		return item.Version, nil
+	}
+	return 0, domain.ErrVersionMismatch
+}
+
+// respondWithVersionError responde 428 si falta If-Match, 412 si no coincide
+// con la versión actual y 404 si el item no existe
+func respondWithVersionError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrVersionRequired:
+		respondWithError(w, http.StatusPreconditionRequired, err.Error())
+	case domain.ErrVersionMismatch:
+		respondWithError(w, http.StatusPreconditionFailed, err.Error())
+	case domain.ErrItemNotFound:
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
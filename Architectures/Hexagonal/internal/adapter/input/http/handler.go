@@ -2,32 +2,52 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"kiosco/internal/application"
 	"kiosco/internal/domain"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
 )
 
 // ItemHandler maneja las peticiones HTTP relacionadas con items
 type ItemHandler struct {
 	service *application.ItemService
+	display *domain.DisplayCurrency
 }
 
-// NewItemHandler crea una nueva instancia del handler
-func NewItemHandler(service *application.ItemService) *ItemHandler {
+// NewItemHandler crea una nueva instancia del handler; los precios también se
+// informan en la moneda de visualización
+func NewItemHandler(service *application.ItemService, display *domain.DisplayCurrency) *ItemHandler {
 	return &ItemHandler{
 		service: service,
+		display: display,
 	}
 }
 
 // GetAllItems maneja GET /api/items
 func (h *ItemHandler) GetAllItems(w http.ResponseWriter, r *http.Request) {
-	items, err := h.service.GetAllItems(r.Context())
+	query, err := parseItemQuery(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	
+	page, err := h.service.GetAllItems(r.Context(), query)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidItemQuery) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	
-	respondWithJSON(w, http.StatusOK, items)
+	respondWithJSON(w, http.StatusOK, newItemPageResponse(page, h.display))
 }
 
 // GetItemByID maneja GET /api/items/{id}
@@ -35,7 +55,7 @@ func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 	id := r.URL.Query().Get("id")
 	if id == "" {
 		// Intentar obtener desde la URL path si está disponible
-		id = r.PathValue("id")
+		id = mux.Vars(r)["id"]
 	}
 	
 	if id == "" {
@@ -53,7 +73,8 @@ func (h *ItemHandler) GetItemByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	respondWithJSON(w, http.StatusOK, item)
+	w.Header().Set("ETag", itemETag(item))
+	respondWithJSON(w, http.StatusOK, newItemResponse(item, h.display))
 }
 
 // CreateItem maneja POST /api/items
@@ -69,64 +90,164 @@ func (h *ItemHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err == domain.ErrInvalidItemName || 
 		   err == domain.ErrInvalidItemPrice || 
-		   err == domain.ErrInvalidItemStock {
+		   err == domain.ErrInvalidCurrency ||
+		   err == domain.ErrInvalidItemStock ||
+		   err == domain.ErrItemIDNotAllowed ||
+		   err == domain.ErrUnknownItemCategory {
 			respondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if err == domain.ErrItemAlreadyExists {
+			respondWithError(w, http.StatusConflict, err.Error())
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	
-	respondWithJSON(w, http.StatusCreated, createdItem)
+	w.Header().Set("ETag", itemETag(createdItem))
+	respondWithJSON(w, http.StatusCreated, newItemResponse(createdItem, h.display))
 }
 
-// UpdateItem maneja PUT /api/items/{id}
+// UpdateItem maneja PUT /api/items/{id}; requiere If-Match con el ETag leído
 func (h *ItemHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id := mux.Vars(r)["id"]
 	if id == "" {
 		respondWithError(w, http.StatusBadRequest, "ID es requerido")
 		return
 	}
 	
+	version, err := h.ifMatchVersion(r, id)
+	if err != nil {
+		respondWithVersionError(w, err)
+		return
+	}
+	
 	var item domain.Item
 	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
 		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
 		return
 	}
 	
-	updatedItem, err := h.service.UpdateItem(r.Context(), id, &item)
+	updatedItem, err := h.service.UpdateItem(r.Context(), id, version, &item)
 	if err != nil {
 		if err == domain.ErrItemNotFound {
 			respondWithError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if err == domain.ErrVersionRequired || err == domain.ErrVersionMismatch {
+			respondWithVersionError(w, err)
+			return
+		}
 		if err == domain.ErrInvalidItemName || 
 		   err == domain.ErrInvalidItemPrice || 
-		   err == domain.ErrInvalidItemStock {
+		   err == domain.ErrInvalidCurrency ||
+		   err == domain.ErrInvalidItemStock ||
+		   err == domain.ErrItemStockReadOnly ||
+		   err == domain.ErrUnknownItemCategory {
 			respondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if err == domain.ErrItemAlreadyExists {
+			respondWithError(w, http.StatusConflict, err.Error())
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	
-	respondWithJSON(w, http.StatusOK, updatedItem)
+	w.Header().Set("ETag", itemETag(updatedItem))
+	respondWithJSON(w, http.StatusOK, newItemResponse(updatedItem, h.display))
 }
 
-// DeleteItem maneja DELETE /api/items/{id}
+// PatchItem maneja PATCH /api/items/{id} con un JSON Merge Patch o un JSON
+// Patch; requiere If-Match con el ETag leído
+func (h *ItemHandler) PatchItem(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+	
+	version, err := h.ifMatchVersion(r, id)
+	if err != nil {
+		respondWithVersionError(w, err)
+		return
+	}
+	
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al leer el body: "+err.Error())
+		return
+	}
+	
+	patch, err := itemPatcher(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		if err == errUnsupportedPatchType {
+			w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+			respondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	
+	patchedItem, err := h.service.PatchItem(r.Context(), id, version, patch)
+	if err != nil {
+		if err == domain.ErrItemNotFound {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err == domain.ErrVersionRequired || err == domain.ErrVersionMismatch {
+			respondWithVersionError(w, err)
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidPatch) ||
+		   err == domain.ErrInvalidItemName || 
+		   err == domain.ErrInvalidItemPrice || 
+		   err == domain.ErrInvalidCurrency ||
+		   err == domain.ErrInvalidItemStock ||
+		   err == domain.ErrItemStockReadOnly ||
+		   err == domain.ErrUnknownItemCategory {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err == domain.ErrItemAlreadyExists {
+			respondWithError(w, http.StatusConflict, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	
+	w.Header().Set("ETag", itemETag(patchedItem))
+	respondWithJSON(w, http.StatusOK, newItemResponse(patchedItem, h.display))
+}
+
+// DeleteItem maneja DELETE /api/items/{id}; requiere If-Match con el ETag leído
 func (h *ItemHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
-	id := r.PathValue("id")
+	id := mux.Vars(r)["id"]
 	if id == "" {
 		respondWithError(w, http.StatusBadRequest, "ID es requerido")
 		return
 	}
 	
-	err := h.service.DeleteItem(r.Context(), id)
+	version, err := h.ifMatchVersion(r, id)
+	if err != nil {
+		respondWithVersionError(w, err)
+		return
+	}
+	
+	err = h.service.DeleteItem(r.Context(), id, version)
 	if err != nil {
 		if err == domain.ErrItemNotFound {
 			respondWithError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if err == domain.ErrVersionRequired || err == domain.ErrVersionMismatch {
+			respondWithVersionError(w, err)
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -134,6 +255,68 @@ func (h *ItemHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusNoContent, nil)
 }
 
+// parseItemQuery lee los filtros, el orden y la página de GET /api/items
+func parseItemQuery(values url.Values) (*domain.ItemQuery, error) {
+	query := &domain.ItemQuery{
+		Category:     values.Get("category"),
+		NameContains: values.Get("name"),
+		SortBy:       values.Get("sort"),
+		Cursor:       values.Get("cursor"),
+	}
+	
+	currency := values.Get("currency")
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	
+	var err error
+	if query.MinPrice, err = parsePrice(values, "min_price", currency); err != nil {
+		return nil, err
+	}
+	if query.MaxPrice, err = parsePrice(values, "max_price", currency); err != nil {
+		return nil, err
+	}
+	
+	if value := values.Get("in_stock"); value != "" {
+		if query.InStockOnly, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("in_stock debe ser true o false")
+		}
+	}
+	
+	switch order := values.Get("order"); order {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, fmt.Errorf("order debe ser asc o desc")
+	}
+	
+	if value := values.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil || query.Limit <= 0 {
+			return nil, fmt.Errorf("limit debe ser un entero mayor que cero")
+		}
+	}
+	
+	return query, nil
+}
+
+// parsePrice lee un precio opcional de la query string, en unidades de la
+// moneda (por ejemplo 150.50)
+func parsePrice(values url.Values, name, currency string) (*domain.Money, error) {
+	value := values.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := domain.ParseMoney(value, currency)
+	if errors.Is(err, domain.ErrInvalidCurrency) {
+		return nil, fmt.Errorf("currency debe ser un código ISO-4217 admitido")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s debe ser un número con los decimales de %s", name, currency)
+	}
+	return &price, nil
+}
+
 // respondWithJSON envía una respuesta JSON
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, err := json.Marshal(payload)
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"encoding/json"
+	"kiosco/internal/adapter/output/idgen"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/application"
+	"kiosco/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestRouter arma las rutas de la API sobre un repositorio en memoria
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	repo := memory.NewMemoryItemRepository()
+	display, err := domain.NewDisplayCurrency(domain.DefaultCurrency, nil)
+	if err != nil {
+		t.Fatalf("NewDisplayCurrency: %v", err)
+	}
+	router := NewRouter(
+		application.NewItemService(repo, idgen.NewUUIDv7Generator()),
+		application.NewStockService(repo),
+		application.NewOrderService(repo, idgen.NewUUIDv7Generator(), 0),
+		application.NewCategoryService(repo, idgen.NewUUIDv7Generator()),
+		display,
+	)
+	return router.SetupRoutes()
+}
+
+// serve envía una petición al router; ifMatch vacío omite el header. Los
+// PATCH se envían como JSON Merge Patch.
+func serve(router http.Handler, method, path, ifMatch, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if method == "PATCH" {
+		req.Header.Set("Content-Type", mergePatchType)
+	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// createTestItem crea un item y lo actualiza una vez, así su versión es 2
+func createTestItem(t *testing.T, router http.Handler) string {
+	t.Helper()
+	rec := serve(router, "POST", "/api/items", "", `{"name":"Alfajor","stock":5}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /api/items = %d: %s", rec.Code, rec.Body)
+	}
+	var item domain.Item
+	if err := json.NewDecoder(rec.Body).Decode(&item); err != nil {
+		t.Fatalf("error al decodificar el item: %v", err)
+	}
+	rec = serve(router, "PUT", "/api/items/"+item.ID, `"1"`, `{"name":"Alfajor triple","stock":5}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT /api/items/%s = %d: %s", item.ID, rec.Code, rec.Body)
+	}
+	return item.ID
+}
+
+func TestGetItemReturnsETag(t *testing.T) {
+	router := newTestRouter(t)
+	id := createTestItem(t, router)
+
+	rec := serve(router, "GET", "/api/items/"+id, "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/items/%s = %d: %s", id, rec.Code, rec.Body)
+	}
+	if etag := rec.Header().Get("ETag"); etag != `"2"` {
+		t.Fatalf("ETag = %q, se esperaba %q", etag, `"2"`)
+	}
+}
+
+func TestItemIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		ifMatch string
+		missing bool
+		want    int
+	}{
+		{name: "sin If-Match", method: "PUT", want: http.StatusPreconditionRequired},
+		{name: "versión vieja", method: "PUT", ifMatch: `"1"`, want: http.StatusPreconditionFailed},
+		{name: "versión actual", method: "PUT", ifMatch: `"2"`, want: http.StatusOK},
+		{name: "asterisco", method: "PUT", ifMatch: "*", want: http.StatusOK},
+		{name: "lista con la versión actual", method: "PUT", ifMatch: `"1", "2"`, want: http.StatusOK},
+		{name: "lista sin la versión actual", method: "PUT", ifMatch: `"1","3"`, want: http.StatusPreconditionFailed},
+		{name: "ETag débil", method: "PUT", ifMatch: `W/"2"`, want: http.StatusPreconditionFailed},
+		{name: "ETag que no es de item", method: "PUT", ifMatch: `"abc"`, want: http.StatusPreconditionFailed},
+		{name: "asterisco con item inexistente", method: "PUT", ifMatch: "*", missing: true, want: http.StatusNotFound},
+		{name: "PATCH con lista", method: "PATCH", ifMatch: `"7", "2"`, want: http.StatusOK},
+		{name: "PATCH sin If-Match", method: "PATCH", want: http.StatusPreconditionRequired},
+		{name: "DELETE con asterisco", method: "DELETE", ifMatch: "*", want: http.StatusNoContent},
+		{name: "DELETE con versión vieja", method: "DELETE", ifMatch: `"1"`, want: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+			id := createTestItem(t, router)
+			if tt.missing {
+				id = "no-existe"
+			}
+
+			body := `{"name":"Alfajor de maicena","stock":5}`
+			if tt.method == "PATCH" {
+				body = `{"name":"Alfajor de maicena"}`
+			}
+			if tt.method == "DELETE" {
+				body = ""
+			}
+			rec := serve(router, tt.method, "/api/items/"+id, tt.ifMatch, body)
+			if rec.Code != tt.want {
+				t.Fatalf("%s con If-Match %q = %d, se esperaba %d: %s", tt.method, tt.ifMatch, rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestItemStockChangeReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		method, body string
+	}{
+		{"PUT", `{"name":"Alfajor triple","stock":8}`},
+		{"PUT", `{"name":"Alfajor triple"}`},
+		{"PATCH", `{"stock":8}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.body, func(t *testing.T) {
+			router := newTestRouter(t)
+			id := createTestItem(t, router)
+
+			rec := serve(router, tt.method, "/api/items/"+id, `"2"`, tt.body)
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "/movements") {
+				t.Fatalf("%s = %d, se esperaba 400 con la ruta de movimientos: %s", tt.method, rec.Code, rec.Body)
+			}
+			rec = serve(router, "GET", "/api/items/"+id, "", "")
+			if etag := rec.Header().Get("ETag"); etag != `"2"` {
+				t.Fatalf("el item cambió: ETag = %q", etag)
+			}
+		})
+	}
+}
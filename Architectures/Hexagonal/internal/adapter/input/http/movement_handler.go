@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"kiosco/internal/application"
+	"kiosco/internal/domain"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// MovementHandler maneja las peticiones HTTP del libro de stock
+type MovementHandler struct {
+	service *application.StockService
+}
+
+// NewMovementHandler crea una nueva instancia del handler
+func NewMovementHandler(service *application.StockService) *MovementHandler {
+	return &MovementHandler{
+		service: service,
+	}
+}
+
+// RecordMovement maneja POST /api/items/{id}/movements
+func (h *MovementHandler) RecordMovement(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	var movement domain.StockMovement
+	if err := json.NewDecoder(r.Body).Decode(&movement); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
+		return
+	}
+
+	recorded, err := h.service.RecordMovement(r.Context(), id, &movement)
+	if err != nil {
+		switch err {
+		case domain.ErrItemNotFound:
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case domain.ErrInsufficientStock:
+			respondWithError(w, http.StatusConflict, err.Error())
+		case domain.ErrInvalidMovementType, domain.ErrInvalidMovementQuantity,
+			domain.ErrMovementReasonRequired, domain.ErrMovementActorRequired:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, recorded)
+}
+
+// ListMovements maneja GET /api/items/{id}/movements
+func (h *MovementHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	movements, err := h.service.ListMovements(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrItemNotFound {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, movements)
+}
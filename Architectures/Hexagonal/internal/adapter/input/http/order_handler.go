@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"kiosco/internal/application"
+	"kiosco/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// OrderHandler maneja las peticiones HTTP de las órdenes de venta
+type OrderHandler struct {
+	service *application.OrderService
+}
+
+// NewOrderHandler crea una nueva instancia del handler
+func NewOrderHandler(service *application.OrderService) *OrderHandler {
+	return &OrderHandler{
+		service: service,
+	}
+}
+
+// voidOrderRequest es el body de POST /api/orders/{id}/void
+type voidOrderRequest struct {
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+}
+
+// orderStockErrorResponse es la respuesta 409 de una orden rechazada por
+// falta de stock: incluye cada línea que no se pudo vender
+type orderStockErrorResponse struct {
+	Error string                  `json:"error"`
+	Lines []domain.OrderLineError `json:"lines"`
+}
+
+// PlaceOrder maneja POST /api/orders
+func (h *OrderHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var order domain.Order
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
+		return
+	}
+
+	placed, err := h.service.PlaceOrder(r.Context(), &order)
+	if err != nil {
+		var stockErr *domain.OrderStockError
+		if errors.As(err, &stockErr) {
+			respondWithJSON(w, http.StatusConflict, orderStockErrorResponse{Error: err.Error(), Lines: stockErr.Lines})
+			return
+		}
+
+		switch err {
+		case domain.ErrOrderWithoutLines, domain.ErrOrderLineItemRequired, domain.ErrInvalidOrderQuantity,
+			domain.ErrInvalidOrderDiscount, domain.ErrInvalidTaxRate, domain.ErrInvalidPaymentMethod,
+			domain.ErrOrderCashierRequired, domain.ErrOrderCurrencyMismatch:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, placed)
+}
+
+// GetOrder maneja GET /api/orders/{id}
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	order, err := h.service.GetOrder(r.Context(), id)
+	if err != nil {
+		if err == domain.ErrOrderNotFound {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, order)
+}
+
+// ListOrders maneja GET /api/orders?status=completed|voided&limit=N
+func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	query := &domain.OrderQuery{Status: r.URL.Query().Get("status")}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			respondWithError(w, http.StatusBadRequest, "limit debe ser un entero mayor que cero")
+			return
+		}
+		query.Limit = limit
+	}
+
+	orders, err := h.service.ListOrders(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOrderQuery) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, orders)
+}
+
+// VoidOrder maneja POST /api/orders/{id}/void
+func (h *OrderHandler) VoidOrder(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		respondWithError(w, http.StatusBadRequest, "ID es requerido")
+		return
+	}
+
+	var request voidOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Error al decodificar el body: "+err.Error())
+		return
+	}
+
+	voided, err := h.service.VoidOrder(r.Context(), id, request.Actor, request.Reason)
+	if err != nil {
+		switch err {
+		case domain.ErrOrderNotFound:
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case domain.ErrOrderAlreadyVoided:
+			respondWithError(w, http.StatusConflict, err.Error())
+		case domain.ErrVoidReasonRequired, domain.ErrVoidActorRequired:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, voided)
+}
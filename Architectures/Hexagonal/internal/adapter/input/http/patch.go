@@ -0,0 +1,370 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Formatos aceptados por PATCH /api/items/{id}
+const (
+	// mergePatchType es un JSON Merge Patch (RFC 7396): un objeto con los
+	// campos a cambiar; null quita el campo
+	mergePatchType = "application/merge-patch+json"
+	// jsonPatchType es un JSON Patch (RFC 6902): una lista de operaciones
+	jsonPatchType = "application/json-patch+json"
+)
+
+// errUnsupportedPatchType indica un Content-Type que PATCH no acepta
+var errUnsupportedPatchType = errors.New("Content-Type no soportado: use " + mergePatchType + " o " + jsonPatchType)
+
+// jsonPatchOperation es una operación de un JSON Patch
+type jsonPatchOperation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	From string `json:"from"`
+	// Value queda vacío si la operación no trae value; un null explícito es "null"
+	Value json.RawMessage `json:"value"`
+}
+
+// itemPatcher interpreta el body según su Content-Type y devuelve la función
+// que aplica el cambio a un item. Los errores de formato se detectan acá,
+// antes de leer el item.
+func itemPatcher(contentType string, body []byte) (func(item *domain.Item) error, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errUnsupportedPatchType
+	}
+
+	var apply func(doc any) (any, error)
+	switch mediaType {
+	case mergePatchType:
+		var patch any
+		if err := json.Unmarshal(body, &patch); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPatch, err)
+		}
+		apply = func(doc any) (any, error) {
+			return mergePatch(doc, patch), nil
+		}
+	case jsonPatchType:
+		var operations []jsonPatchOperation
+		if err := json.Unmarshal(body, &operations); err != nil {
+			return nil, fmt.Errorf("%w: se espera una lista de operaciones: %v", domain.ErrInvalidPatch, err)
+		}
+		apply = func(doc any) (any, error) {
+			return applyJSONPatch(doc, operations)
+		}
+	default:
+		return nil, errUnsupportedPatchType
+	}
+
+	return func(item *domain.Item) error {
+		// El patch se aplica sobre la representación JSON del item
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		var doc any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+
+		patched, err := apply(doc)
+		if err != nil {
+			return err
+		}
+
+		if data, err = json.Marshal(patched); err != nil {
+			return err
+		}
+		var result domain.Item
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&result); err != nil {
+			return fmt.Errorf("%w: el resultado no es un item válido: %v", domain.ErrInvalidPatch, err)
+		}
+
+		*item = result
+		return nil
+	}, nil
+}
+
+// mergePatch aplica un JSON Merge Patch según el algoritmo de RFC 7396
+func mergePatch(target, patch any) any {
+	fields, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+	for name, value := range fields {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = mergePatch(object[name], value)
+	}
+	return object
+}
+
+// applyJSONPatch aplica las operaciones en orden; si una falla no se aplica
+// ninguna, porque el documento modificado se descarta
+func applyJSONPatch(doc any, operations []jsonPatchOperation) (any, error) {
+	for i, operation := range operations {
+		var err error
+		if doc, err = applyOperation(doc, operation); err != nil {
+			return nil, fmt.Errorf("%w: operación %d (%s %s): %v", domain.ErrInvalidPatch, i, operation.Op, operation.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+// applyOperation aplica una operación de JSON Patch según RFC 6902
+func applyOperation(doc any, operation jsonPatchOperation) (any, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "remove":
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+	case "replace":
+		value, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		if doc, _, err = removeValue(doc, path); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "move":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, errors.New("no se puede mover un valor dentro de sí mismo")
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = deepCopy(value); err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	case "test":
+		want, err := operation.value()
+		if err != nil {
+			return nil, err
+		}
+		got, err := getValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, errors.New("el valor no coincide")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("operación desconocida %q", operation.Op)
+	}
+}
+
+// value decodifica el value de la operación, que es obligatorio
+func (o jsonPatchOperation) value() (any, error) {
+	if len(o.Value) == 0 {
+		return nil, errors.New("falta value")
+	}
+	var value any
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parsePointer separa un JSON Pointer (RFC 6901) en sus referencias
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("ruta inválida %q: debe empezar con /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// getValue devuelve el valor al que apunta path
+func getValue(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("no existe %q", token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("no existe %q", token)
+		}
+	}
+	return doc, nil
+}
+
+// addValue agrega o reemplaza el valor en path y devuelve el documento modificado
+func addValue(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]any:
+		if last {
+			node[token] = value
+			return node, nil
+		}
+		child, ok := node[token]
+		if !ok {
+			return nil, fmt.Errorf("no existe %q", token)
+		}
+		child, err := addValue(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = child
+		return node, nil
+	case []any:
+		if last {
+			if token == "-" {
+				return append(node, value), nil
+			}
+			// Al agregar, el índice puede ser igual al largo: equivale a "-"
+			i, err := arrayIndex(token, len(node)+1)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		}
+		i, err := arrayIndex(token, len(node))
+		if err != nil {
+			return nil, err
+		}
+		child, err := addValue(node[i], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = child
+		return node, nil
+	default:
+		return nil, fmt.Errorf("no existe %q", token)
+	}
+}
+
+// removeValue quita el valor en path y devuelve el documento modificado y el
+// valor quitado
+func removeValue(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("no se puede quitar el documento completo")
+	}
+
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("no existe %q", token)
+		}
+		if last {
+			delete(node, token)
+			return node, child, nil
+		}
+		child, removed, err := removeValue(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = child
+		return node, removed, nil
+	case []any:
+		i, err := arrayIndex(token, len(node))
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := node[i]
+			return append(node[:i], node[i+1:]...), removed, nil
+		}
+		child, removed, err := removeValue(node[i], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[i] = child
+		return node, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("no existe %q", token)
+	}
+}
+
+// arrayIndex interpreta una referencia a un elemento de una lista de largo size
+func arrayIndex(token string, size int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || strconv.Itoa(i) != token {
+		return 0, fmt.Errorf("índice inválido %q", token)
+	}
+	if i >= size {
+		return 0, fmt.Errorf("índice fuera de rango %q", token)
+	}
+	return i, nil
+}
+
+// deepCopy copia un valor JSON para que copy no comparta listas ni objetos
+func deepCopy(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied any
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
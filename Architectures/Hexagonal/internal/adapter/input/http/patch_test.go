@@ -0,0 +1,174 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"kiosco/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Ejemplos del apéndice A de RFC 7396
+	tests := []struct {
+		target, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tt := range tests {
+		got := mergePatch(decodeJSON(t, tt.target), decodeJSON(t, tt.patch))
+		if !reflect.DeepEqual(got, decodeJSON(t, tt.want)) {
+			t.Errorf("mergePatch(%s, %s) = %v, se esperaba %s", tt.target, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	// Ejemplos del apéndice A de RFC 6902
+	tests := []struct {
+		name, doc, patch, want string
+	}{
+		{"add a un objeto", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add a una lista", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"remove", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove de una lista", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"move en una lista", `{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"test", `{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{"add de un objeto anidado", `{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{"claves con ~ y /", `{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"copy","from":"/~1","path":"/a~0b"}]`, `{"/":9,"~1":10,"a~b":9}`},
+		{"add al final con -", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{"add de null", `{}`, `[{"op":"add","path":"/foo","value":null}]`, `{"foo":null}`},
+	}
+	for _, tt := range tests {
+		var operations []jsonPatchOperation
+		if err := json.Unmarshal([]byte(tt.patch), &operations); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got, err := applyJSONPatch(decodeJSON(t, tt.doc), operations)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, decodeJSON(t, tt.want)) {
+			t.Errorf("%s: se obtuvo %v, se esperaba %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name, doc, patch string
+	}{
+		{"test que no se cumple", `{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`},
+		{"add a un padre inexistente", `{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`},
+		{"remove de un campo inexistente", `{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`},
+		{"replace de un campo inexistente", `{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`},
+		{"índice fuera de rango", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":1}]`},
+		{"índice con ceros a la izquierda", `{"foo":["bar","baz"]}`, `[{"op":"remove","path":"/foo/01"}]`},
+		{"operación desconocida", `{}`, `[{"op":"merge","path":"/a","value":1}]`},
+		{"sin value", `{}`, `[{"op":"add","path":"/a"}]`},
+		{"ruta sin /", `{}`, `[{"op":"add","path":"a","value":1}]`},
+		{"move dentro de sí mismo", `{"a":{"b":{}}}`, `[{"op":"move","from":"/a","path":"/a/b/c"}]`},
+	}
+	for _, tt := range tests {
+		var operations []jsonPatchOperation
+		if err := json.Unmarshal([]byte(tt.patch), &operations); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if _, err := applyJSONPatch(decodeJSON(t, tt.doc), operations); !errors.Is(err, domain.ErrInvalidPatch) {
+			t.Errorf("%s: se esperaba ErrInvalidPatch, se obtuvo %v", tt.name, err)
+		}
+	}
+}
+
+func TestItemPatcher(t *testing.T) {
+	original := domain.Item{
+		ID:          "item-1",
+		Name:        "Alfajor",
+		Description: "Alfajor de chocolate",
+		Price:       domain.NewMoney(10000, "ARS"),
+		Stock:       10,
+		Category:    "Golosinas",
+		CreatedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		Version:     3,
+	}
+
+	tests := []struct {
+		name, contentType, body string
+		want                    func(item *domain.Item)
+	}{
+		{
+			"merge patch", "application/merge-patch+json", `{"price":{"amount":12000,"currency":"ARS"},"description":null}`,
+			func(item *domain.Item) { item.Price, item.Description = domain.NewMoney(12000, "ARS"), "" },
+		},
+		{
+			"merge patch con charset", "application/merge-patch+json; charset=utf-8", `{"name":"Alfajor triple"}`,
+			func(item *domain.Item) { item.Name = "Alfajor triple" },
+		},
+		{
+			"json patch", "application/json-patch+json", `[{"op":"test","path":"/price/amount","value":10000},{"op":"replace","path":"/price/amount","value":9000}]`,
+			func(item *domain.Item) { item.Price = domain.NewMoney(9000, "ARS") },
+		},
+	}
+	for _, tt := range tests {
+		patch, err := itemPatcher(tt.contentType, []byte(tt.body))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		item := original
+		if err := patch(&item); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want := original
+		tt.want(&want)
+		if !reflect.DeepEqual(item, want) {
+			t.Errorf("%s: item = %+v, se esperaba %+v", tt.name, item, want)
+		}
+	}
+
+	if _, err := itemPatcher("application/json", []byte(`{}`)); err != errUnsupportedPatchType {
+		t.Errorf("application/json: se esperaba errUnsupportedPatchType, se obtuvo %v", err)
+	}
+	if _, err := itemPatcher(mergePatchType, []byte(`{`)); !errors.Is(err, domain.ErrInvalidPatch) {
+		t.Errorf("JSON inválido: se esperaba ErrInvalidPatch, se obtuvo %v", err)
+	}
+
+	// Un campo desconocido o de otro tipo no produce un item
+	for _, body := range []string{`{"precio":1}`, `{"price":"caro"}`, `["no es un objeto"]`} {
+		patch, err := itemPatcher(mergePatchType, []byte(body))
+		if err != nil {
+			t.Fatalf("%s: %v", body, err)
+		}
+		item := original
+		if err := patch(&item); !errors.Is(err, domain.ErrInvalidPatch) {
+			t.Errorf("%s: se esperaba ErrInvalidPatch, se obtuvo %v", body, err)
+		}
+	}
+}
+
+func decodeJSON(t *testing.T, data string) any {
+	t.Helper()
+
+	var value any
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatalf("JSON inválido %s: %v", data, err)
+	}
+	return value
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"kiosco/internal/application"
+	"kiosco/internal/domain"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -9,13 +10,20 @@ import (
 
 // Router configura las rutas de la API
 type Router struct {
-	itemHandler *ItemHandler
+	itemHandler     *ItemHandler
+	movementHandler *MovementHandler
+	orderHandler    *OrderHandler
+	categoryHandler *CategoryHandler
 }
 
-// NewRouter crea una nueva instancia del router
-func NewRouter(itemService *application.ItemService) *Router {
+// NewRouter crea una nueva instancia del router; display es la moneda en la
+// que se informan los precios además de la propia de cada item
+func NewRouter(itemService *application.ItemService, stockService *application.StockService, orderService *application.OrderService, categoryService *application.CategoryService, display *domain.DisplayCurrency) *Router {
 	return &Router{
-		itemHandler: NewItemHandler(itemService),
+		itemHandler:     NewItemHandler(itemService, display),
+		movementHandler: NewMovementHandler(stockService),
+		orderHandler:    NewOrderHandler(orderService),
+		categoryHandler: NewCategoryHandler(categoryService, display),
 	}
 }
 
@@ -38,9 +46,48 @@ func (r *Router) SetupRoutes() *mux.Router {
 	// PUT /api/items/{id} - Actualizar un item
 	api.HandleFunc("/items/{id}", r.itemHandler.UpdateItem).Methods("PUT")
 	
+	// PATCH /api/items/{id} - Modificar algunos campos de un item
+	api.HandleFunc("/items/{id}", r.itemHandler.PatchItem).Methods("PATCH")
+	
 	// DELETE /api/items/{id} - Eliminar un item
 	api.HandleFunc("/items/{id}", r.itemHandler.DeleteItem).Methods("DELETE")
 	
+	// POST /api/items/{id}/movements - Registrar un movimiento de stock
+	api.HandleFunc("/items/{id}/movements", r.movementHandler.RecordMovement).Methods("POST")
+	
+	// GET /api/items/{id}/movements - Obtener los movimientos de stock de un item
+	api.HandleFunc("/items/{id}/movements", r.movementHandler.ListMovements).Methods("GET")
+	
+	// GET /api/categories - Obtener todas las categorías
+	api.HandleFunc("/categories", r.categoryHandler.ListCategories).Methods("GET")
+	
+	// GET /api/categories/summary - Cantidad de items y valor del stock de cada categoría
+	api.HandleFunc("/categories/summary", r.categoryHandler.SummarizeCategories).Methods("GET")
+	
+	// GET /api/categories/{id} - Obtener una categoría por ID
+	api.HandleFunc("/categories/{id}", r.categoryHandler.GetCategory).Methods("GET")
+	
+	// POST /api/categories - Crear una nueva categoría
+	api.HandleFunc("/categories", r.categoryHandler.CreateCategory).Methods("POST")
+	
+	// PUT /api/categories/{id} - Actualizar una categoría y, si se renombra, sus items
+	api.HandleFunc("/categories/{id}", r.categoryHandler.UpdateCategory).Methods("PUT")
+	
+	// DELETE /api/categories/{id} - Eliminar una categoría sin items
+	api.HandleFunc("/categories/{id}", r.categoryHandler.DeleteCategory).Methods("DELETE")
+	
+	// POST /api/orders - Registrar una venta y descontar el stock de sus items
+	api.HandleFunc("/orders", r.orderHandler.PlaceOrder).Methods("POST")
+	
+	// GET /api/orders - Obtener las órdenes, de la más reciente a la más antigua
+	api.HandleFunc("/orders", r.orderHandler.ListOrders).Methods("GET")
+	
+	// GET /api/orders/{id} - Obtener una orden por ID
+	api.HandleFunc("/orders/{id}", r.orderHandler.GetOrder).Methods("GET")
+	
+	// POST /api/orders/{id}/void - Anular una orden y devolver su mercadería al stock
+	api.HandleFunc("/orders/{id}/void", r.orderHandler.VoidOrder).Methods("POST")
+	
 	// Health check
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kiosco/internal/domain"
+	"net/http"
+	neturl "net/url"
+)
+
+// ListCategories obtiene todas las categorías desde la API externa, ordenadas
+// por nombre
+func (r *HTTPItemRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	categories := []*domain.Category{}
+	if err := r.getCategoryResource(ctx, "categories", &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetCategory obtiene una categoría por su ID desde la API externa
+func (r *HTTPItemRepository) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	var category domain.Category
+	if err := r.getCategoryResource(ctx, "categories/"+neturl.PathEscape(id), &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// SummarizeCategories obtiene el resumen de cada categoría desde la API externa
+func (r *HTTPItemRepository) SummarizeCategories(ctx context.Context) ([]*domain.CategorySummary, error) {
+	summaries := []*domain.CategorySummary{}
+	if err := r.getCategoryResource(ctx, "categories/summary", &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// CreateCategory crea una nueva categoría en la API externa
+func (r *HTTPItemRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	url := fmt.Sprintf("%s/categories", r.baseURL)
+
+	body, err := json.Marshal(category)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar categoría: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrCategoryAlreadyExists
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var created domain.Category
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &created, nil
+}
+
+// UpdateCategory actualiza una categoría en la API externa, que cambia la
+// categoría de sus items en la misma operación
+func (r *HTTPItemRepository) UpdateCategory(ctx context.Context, id string, category *domain.Category) (*domain.Category, error) {
+	url := fmt.Sprintf("%s/categories/%s", r.baseURL, neturl.PathEscape(id))
+
+	body, err := json.Marshal(category)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar categoría: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrCategoryAlreadyExists
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var updated domain.Category
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteCategory elimina una categoría de la API externa, que la rechaza si
+// algún item la referencia
+func (r *HTTPItemRepository) DeleteCategory(ctx context.Context, id string) error {
+	url := fmt.Sprintf("%s/categories/%s", r.baseURL, neturl.PathEscape(id))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ErrCategoryNotFound
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return domain.ErrCategoryInUse
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// getCategoryResource hace un GET a la API externa y decodifica la respuesta
+// en target; un 404 es ErrCategoryNotFound
+func (r *HTTPItemRepository) getCategoryResource(ctx context.Context, path string, target any) error {
+	url := fmt.Sprintf("%s/%s", r.baseURL, path)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ErrCategoryNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return nil
+}
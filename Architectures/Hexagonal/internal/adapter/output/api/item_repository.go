@@ -8,6 +8,9 @@ import (
 	"io"
 	"kiosco/internal/domain"
 	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,9 +30,10 @@ func NewHTTPItemRepository(baseURL string) *HTTPItemRepository {
 	}
 }
 
-// GetAll obtiene todos los items desde la API externa
-func (r *HTTPItemRepository) GetAll(ctx context.Context) ([]*domain.Item, error) {
-	url := fmt.Sprintf("%s/items", r.baseURL)
+// GetAll obtiene una página de items desde la API externa. Los filtros, el
+// orden y el cursor se envían como parámetros para que la API los resuelva.
+func (r *HTTPItemRepository) GetAll(ctx context.Context, query *domain.ItemQuery) (*domain.ItemPage, error) {
+	url := fmt.Sprintf("%s/items?%s", r.baseURL, itemQueryParams(query).Encode())
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -44,17 +48,56 @@ func (r *HTTPItemRepository) GetAll(ctx context.Context) ([]*domain.Item, error)
 	}
 	defer resp.Body.Close()
 	
+	if resp.StatusCode == http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidItemQuery, strings.TrimSpace(string(body)))
+	}
+	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
 	}
 	
-	var items []*domain.Item
-	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+	var page domain.ItemPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
 		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
 	}
+	if page.Items == nil {
+		page.Items = []*domain.Item{}
+	}
 	
-	return items, nil
+	return &page, nil
+}
+
+// itemQueryParams traduce la consulta a los parámetros de GET /items
+func itemQueryParams(query *domain.ItemQuery) neturl.Values {
+	params := neturl.Values{}
+	if query.Category != "" {
+		params.Set("category", query.Category)
+	}
+	if query.NameContains != "" {
+		params.Set("name", query.NameContains)
+	}
+	if query.MinPrice != nil {
+		params.Set("min_price", query.MinPrice.Decimal())
+		params.Set("currency", query.MinPrice.Currency)
+	}
+	if query.MaxPrice != nil {
+		params.Set("max_price", query.MaxPrice.Decimal())
+		params.Set("currency", query.MaxPrice.Currency)
+	}
+	if query.InStockOnly {
+		params.Set("in_stock", "true")
+	}
+	params.Set("sort", query.SortBy)
+	if query.Descending {
+		params.Set("order", "desc")
+	}
+	params.Set("limit", strconv.Itoa(query.Limit))
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	}
+	return params
 }
 
 // GetByID obtiene un item por su ID desde la API externa
@@ -113,6 +156,14 @@ func (r *HTTPItemRepository) Create(ctx context.Context, item *domain.Item) (*do
 	}
 	defer resp.Body.Close()
 	
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrItemAlreadyExists
+	}
+	
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		return nil, domain.ErrUnknownItemCategory
+	}
+	
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
@@ -126,7 +177,8 @@ func (r *HTTPItemRepository) Create(ctx context.Context, item *domain.Item) (*do
 	return &createdItem, nil
 }
 
-// Update actualiza un item existente en la API externa
+// Update actualiza un item existente en la API externa. La versión leída
+// viaja en If-Match para que la API rechace la escritura si cambió
 func (r *HTTPItemRepository) Update(ctx context.Context, id string, item *domain.Item) (*domain.Item, error) {
 	url := fmt.Sprintf("%s/items/%s", r.baseURL, id)
 	
@@ -141,6 +193,7 @@ func (r *HTTPItemRepository) Update(ctx context.Context, id string, item *domain
 	}
 	
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", versionETag(item.Version))
 	
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
@@ -152,6 +205,18 @@ func (r *HTTPItemRepository) Update(ctx context.Context, id string, item *domain
 		return nil, domain.ErrItemNotFound
 	}
 	
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrItemAlreadyExists
+	}
+	
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, domain.ErrVersionMismatch
+	}
+	
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		return nil, domain.ErrUnknownItemCategory
+	}
+	
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
@@ -165,8 +230,8 @@ func (r *HTTPItemRepository) Update(ctx context.Context, id string, item *domain
 	return &updatedItem, nil
 }
 
-// Delete elimina un item de la API externa
-func (r *HTTPItemRepository) Delete(ctx context.Context, id string) error {
+// Delete elimina un item de la API externa si su versión no cambió
+func (r *HTTPItemRepository) Delete(ctx context.Context, id string, version int64) error {
 	url := fmt.Sprintf("%s/items/%s", r.baseURL, id)
 	
 	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
@@ -175,6 +240,7 @@ func (r *HTTPItemRepository) Delete(ctx context.Context, id string) error {
 	}
 	
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", versionETag(version))
 	
 	resp, err := r.httpClient.Do(req)
 	if err != nil {
@@ -186,6 +252,10 @@ func (r *HTTPItemRepository) Delete(ctx context.Context, id string) error {
 		return domain.ErrItemNotFound
 	}
 	
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return domain.ErrVersionMismatch
+	}
+	
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
@@ -193,3 +263,8 @@ func (r *HTTPItemRepository) Delete(ctx context.Context, id string) error {
 	
 	return nil
 }
+
+// versionETag arma el ETag que identifica una versión del item
+func versionETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
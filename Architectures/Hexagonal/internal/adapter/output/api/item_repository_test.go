@@ -0,0 +1,291 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/adapter/output/repositorytest"
+	"kiosco/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestHTTPItemRepository(t *testing.T) {
+	repositorytest.RunItemRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newFakeAPIRepository(t)
+	})
+}
+
+func TestHTTPCategoryRepository(t *testing.T) {
+	repositorytest.RunCategoryRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newFakeAPIRepository(t)
+	})
+}
+
+func TestHTTPStockMovementRepository(t *testing.T) {
+	repositorytest.RunStockMovementRepositoryTests(t, func(t *testing.T) repositorytest.StockRepository {
+		return newFakeAPIRepository(t)
+	})
+}
+
+func TestHTTPOrderRepository(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) repositorytest.OrderRepository {
+		return newFakeAPIRepository(t)
+	})
+}
+
+func TestPlaceOrderRejectionCodes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		body    string
+		want    error
+		generic bool
+	}{
+		{name: "descuento", body: `{"error":"descuento inválido","code":"invalid_discount"}`, want: domain.ErrInvalidOrderDiscount},
+		{name: "monedas", body: `{"error":"monedas distintas","code":"currency_mismatch"}`, want: domain.ErrOrderCurrencyMismatch},
+		{name: "código desconocido", body: `{"error":"tasa inválida","code":"invalid_tax_rate"}`, generic: true},
+		{name: "sin JSON", body: "bad request", generic: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, tc.body, http.StatusBadRequest)
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := NewHTTPItemRepository(server.URL).PlaceOrder(context.Background(), &domain.Order{ID: "orden-1"})
+			if tc.generic {
+				if err == nil || errors.Is(err, domain.ErrInvalidOrderDiscount) || errors.Is(err, domain.ErrOrderCurrencyMismatch) {
+					t.Fatalf("se esperaba un error genérico de la API, se obtuvo %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("se esperaba %v, se obtuvo %v", tc.want, err)
+			}
+		})
+	}
+}
+
+// newFakeAPIRepository crea un HTTPItemRepository conectado a una API externa simulada
+func newFakeAPIRepository(t *testing.T) *HTTPItemRepository {
+	server := httptest.NewServer(newFakeAPI(memory.NewMemoryItemRepository()))
+	t.Cleanup(server.Close)
+	return NewHTTPItemRepository(server.URL)
+}
+
+// newFakeAPI simula la API externa sobre un repositorio en memoria,
+// respondiendo 404, 409 y 422 como lo hace la API real
+func newFakeAPI(repo *memory.MemoryItemRepository) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		query, err := fakeItemQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		page, err := repo.GetAll(r.Context(), query)
+		writeFakeResponse(w, http.StatusOK, page, err)
+	})
+	mux.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		item, err := repo.GetByID(r.Context(), r.PathValue("id"))
+		writeFakeResponse(w, http.StatusOK, item, err)
+	})
+	mux.HandleFunc("POST /items", func(w http.ResponseWriter, r *http.Request) {
+		var item domain.Item
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created, err := repo.Create(r.Context(), &item)
+		writeFakeResponse(w, http.StatusCreated, created, err)
+	})
+	mux.HandleFunc("PUT /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var item domain.Item
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		item.Version = fakeIfMatch(r)
+		updated, err := repo.Update(r.Context(), r.PathValue("id"), &item)
+		writeFakeResponse(w, http.StatusOK, updated, err)
+	})
+	mux.HandleFunc("DELETE /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		err := repo.Delete(r.Context(), r.PathValue("id"), fakeIfMatch(r))
+		writeFakeResponse(w, http.StatusNoContent, nil, err)
+	})
+
+	mux.HandleFunc("GET /categories", func(w http.ResponseWriter, r *http.Request) {
+		categories, err := repo.ListCategories(r.Context())
+		writeFakeResponse(w, http.StatusOK, categories, err)
+	})
+	mux.HandleFunc("GET /categories/summary", func(w http.ResponseWriter, r *http.Request) {
+		summaries, err := repo.SummarizeCategories(r.Context())
+		writeFakeResponse(w, http.StatusOK, summaries, err)
+	})
+	mux.HandleFunc("GET /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		category, err := repo.GetCategory(r.Context(), r.PathValue("id"))
+		writeFakeResponse(w, http.StatusOK, category, err)
+	})
+	mux.HandleFunc("POST /categories", func(w http.ResponseWriter, r *http.Request) {
+		var category domain.Category
+		if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created, err := repo.CreateCategory(r.Context(), &category)
+		writeFakeResponse(w, http.StatusCreated, created, err)
+	})
+	mux.HandleFunc("PUT /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var category domain.Category
+		if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		updated, err := repo.UpdateCategory(r.Context(), r.PathValue("id"), &category)
+		writeFakeResponse(w, http.StatusOK, updated, err)
+	})
+	mux.HandleFunc("DELETE /categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		err := repo.DeleteCategory(r.Context(), r.PathValue("id"))
+		writeFakeResponse(w, http.StatusNoContent, nil, err)
+	})
+
+	mux.HandleFunc("POST /items/{id}/movements", func(w http.ResponseWriter, r *http.Request) {
+		var movement domain.StockMovement
+		if err := json.NewDecoder(r.Body).Decode(&movement); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		movement.ItemID = r.PathValue("id")
+		recorded, err := repo.RecordMovement(r.Context(), &movement)
+		writeFakeResponse(w, http.StatusCreated, recorded, err)
+	})
+	mux.HandleFunc("GET /items/{id}/movements", func(w http.ResponseWriter, r *http.Request) {
+		movements, err := repo.ListMovements(r.Context(), r.PathValue("id"))
+		writeFakeResponse(w, http.StatusOK, movements, err)
+	})
+
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		var order domain.Order
+		if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		placed, err := repo.PlaceOrder(r.Context(), &order)
+		writeFakeResponse(w, http.StatusCreated, placed, err)
+	})
+	mux.HandleFunc("GET /orders", func(w http.ResponseWriter, r *http.Request) {
+		query := &domain.OrderQuery{Status: r.URL.Query().Get("status")}
+		query.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+		if err := query.Normalize(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		orders, err := repo.ListOrders(r.Context(), query)
+		writeFakeResponse(w, http.StatusOK, orders, err)
+	})
+	mux.HandleFunc("GET /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		order, err := repo.GetOrder(r.Context(), r.PathValue("id"))
+		writeFakeResponse(w, http.StatusOK, order, err)
+	})
+	mux.HandleFunc("POST /orders/{id}/void", func(w http.ResponseWriter, r *http.Request) {
+		var void voidRequest
+		if err := json.NewDecoder(r.Body).Decode(&void); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		voided, err := repo.VoidOrder(r.Context(), r.PathValue("id"), void.Actor, void.Reason, void.VoidedAt)
+		writeFakeResponse(w, http.StatusOK, voided, err)
+	})
+
+	return mux
+}
+
+// fakeItemQuery lee los parámetros de GET /items como lo hace la API real
+func fakeItemQuery(values url.Values) (*domain.ItemQuery, error) {
+	query := &domain.ItemQuery{
+		Category:     values.Get("category"),
+		NameContains: values.Get("name"),
+		InStockOnly:  values.Get("in_stock") == "true",
+		SortBy:       values.Get("sort"),
+		Descending:   values.Get("order") == "desc",
+		Cursor:       values.Get("cursor"),
+	}
+	currency := values.Get("currency")
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	for name, target := range map[string]**domain.Money{"min_price": &query.MinPrice, "max_price": &query.MaxPrice} {
+		if value := values.Get(name); value != "" {
+			price, err := domain.ParseMoney(value, currency)
+			if err != nil {
+				return nil, err
+			}
+			*target = &price
+		}
+	}
+	query.Limit, _ = strconv.Atoi(values.Get("limit"))
+
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// fakeIfMatch lee la versión de If-Match; sin un ETag válido devuelve 0, que
+// ningún item tiene
+func fakeIfMatch(r *http.Request) int64 {
+	tag, err := strconv.Unquote(r.Header.Get("If-Match"))
+	if err != nil {
+		return 0
+	}
+	version, _ := strconv.ParseInt(tag, 10, 64)
+	return version
+}
+
+func writeFakeResponse(w http.ResponseWriter, status int, body interface{}, err error) {
+	var stockErr *domain.OrderStockError
+	switch {
+	case errors.As(err, &stockErr):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(orderConflict{Error: err.Error(), Lines: stockErr.Lines})
+	case errors.Is(err, domain.ErrItemNotFound), errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrCategoryNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidOrderDiscount), errors.Is(err, domain.ErrOrderCurrencyMismatch):
+		rejection := orderRejection{Error: err.Error()}
+		for code, codeErr := range orderRejectionCodes {
+			if errors.Is(err, codeErr) {
+				rejection.Code = code
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(rejection)
+	case errors.Is(err, domain.ErrInvalidItemQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrVersionMismatch):
+		http.Error(w, err.Error(), http.StatusPreconditionFailed)
+	case errors.Is(err, domain.ErrItemAlreadyExists), errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrOrderAlreadyExists), errors.Is(err, domain.ErrOrderAlreadyVoided),
+		errors.Is(err, domain.ErrCategoryAlreadyExists), errors.Is(err, domain.ErrCategoryInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, domain.ErrUnknownItemCategory):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case status == http.StatusNoContent:
+		w.WriteHeader(status)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+}
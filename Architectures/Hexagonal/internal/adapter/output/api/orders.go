@@ -0,0 +1,217 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kiosco/internal/domain"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// orderConflict es el cuerpo de un 409 de POST /orders. Trae las líneas
+// rechazadas cuando falta stock; sin líneas, la orden ya existía.
+type orderConflict struct {
+	Error string                  `json:"error"`
+	Lines []domain.OrderLineError `json:"lines"`
+}
+
+// orderRejection es el cuerpo de un 400 de POST /orders. Code indica el
+// motivo; un 400 sin un código conocido se informa como error de la API.
+type orderRejection struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// orderRejectionCodes asocia los códigos de un 400 de POST /orders con los
+// errores del dominio
+var orderRejectionCodes = map[string]error{
+	"invalid_discount":  domain.ErrInvalidOrderDiscount,
+	"currency_mismatch": domain.ErrOrderCurrencyMismatch,
+}
+
+// voidRequest es el cuerpo de POST /orders/{id}/void
+type voidRequest struct {
+	Actor    string    `json:"actor"`
+	Reason   string    `json:"reason"`
+	VoidedAt time.Time `json:"voided_at"`
+}
+
+// PlaceOrder envía la orden a la API externa, que descuenta el stock de todas
+// sus líneas en la misma operación
+func (r *HTTPItemRepository) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	url := fmt.Sprintf("%s/orders", r.baseURL)
+
+	body, err := json.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar orden: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		var conflict orderConflict
+		if err := json.NewDecoder(resp.Body).Decode(&conflict); err == nil && len(conflict.Lines) > 0 {
+			return nil, &domain.OrderStockError{Lines: conflict.Lines}
+		}
+		return nil, domain.ErrOrderAlreadyExists
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		var rejection orderRejection
+		if err := json.Unmarshal(body, &rejection); err == nil {
+			if err, ok := orderRejectionCodes[rejection.Code]; ok {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var placed domain.Order
+	if err := json.NewDecoder(resp.Body).Decode(&placed); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &placed, nil
+}
+
+// GetOrder obtiene una orden por su ID desde la API externa
+func (r *HTTPItemRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	url := fmt.Sprintf("%s/orders/%s", r.baseURL, neturl.PathEscape(id))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var order domain.Order
+	if err := json.NewDecoder(resp.Body).Decode(&order); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &order, nil
+}
+
+// ListOrders obtiene las órdenes desde la API externa, de la más reciente a la
+// más antigua
+func (r *HTTPItemRepository) ListOrders(ctx context.Context, query *domain.OrderQuery) ([]*domain.Order, error) {
+	params := neturl.Values{}
+	if query.Status != "" {
+		params.Set("status", query.Status)
+	}
+	params.Set("limit", strconv.Itoa(query.Limit))
+	url := fmt.Sprintf("%s/orders?%s", r.baseURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidOrderQuery, strings.TrimSpace(string(body)))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	orders := []*domain.Order{}
+	if err := json.NewDecoder(resp.Body).Decode(&orders); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return orders, nil
+}
+
+// VoidOrder anula una orden en la API externa, que devuelve al stock sus
+// líneas en la misma operación
+func (r *HTTPItemRepository) VoidOrder(ctx context.Context, id, actor, reason string, at time.Time) (*domain.Order, error) {
+	url := fmt.Sprintf("%s/orders/%s/void", r.baseURL, neturl.PathEscape(id))
+
+	body, err := json.Marshal(voidRequest{Actor: actor, Reason: reason, VoidedAt: at})
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar anulación: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrOrderAlreadyVoided
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var voided domain.Order
+	if err := json.NewDecoder(resp.Body).Decode(&voided); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &voided, nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kiosco/internal/domain"
+	"net/http"
+	neturl "net/url"
+)
+
+// RecordMovement registra un movimiento de stock en la API externa, que
+// actualiza el stock del item en la misma operación
+func (r *HTTPItemRepository) RecordMovement(ctx context.Context, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	url := fmt.Sprintf("%s/items/%s/movements", r.baseURL, neturl.PathEscape(movement.ItemID))
+
+	body, err := json.Marshal(movement)
+	if err != nil {
+		return nil, fmt.Errorf("error al serializar movimiento: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrItemNotFound
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var recorded domain.StockMovement
+	if err := json.NewDecoder(resp.Body).Decode(&recorded); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return &recorded, nil
+}
+
+// ListMovements obtiene los movimientos de stock de un item desde la API externa
+func (r *HTTPItemRepository) ListMovements(ctx context.Context, itemID string) ([]*domain.StockMovement, error) {
+	url := fmt.Sprintf("%s/items/%s/movements", r.baseURL, neturl.PathEscape(itemID))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error al realizar request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrItemNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error en la API externa (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	movements := []*domain.StockMovement{}
+	if err := json.NewDecoder(resp.Body).Decode(&movements); err != nil {
+		return nil, fmt.Errorf("error al decodificar respuesta: %w", err)
+	}
+
+	return movements, nil
+}
@@ -0,0 +1,63 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UUIDv7Generator es el adaptador que genera IDs UUID versión 7 (RFC 9562).
+// Los IDs empiezan con el instante de creación en milisegundos, por lo que
+// se ordenan cronológicamente; dentro de un mismo milisegundo un contador
+// mantiene ese orden.
+type UUIDv7Generator struct {
+	mu      sync.Mutex
+	lastMs  int64
+	counter uint16
+	now     func() time.Time
+}
+
+// NewUUIDv7Generator crea una nueva instancia del generador de UUIDv7
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{
+		now: time.Now,
+	}
+}
+
+// NewID devuelve un UUIDv7 en su forma canónica
+func (g *UUIDv7Generator) NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	g.mu.Lock()
+	ms := g.now().UnixMilli()
+	if ms <= g.lastMs {
+		// Mismo milisegundo (o reloj atrasado): seguir la secuencia anterior
+		ms = g.lastMs
+		g.counter++
+		if g.counter > 0x0fff {
+			ms++
+			g.counter = 0
+		}
+	} else {
+		// El contador arranca en un valor aleatorio de la mitad inferior
+		// para dejar margen a los siguientes IDs del milisegundo
+		g.counter = uint16(b[6])<<3 | uint16(b[7])>>5
+	}
+	g.lastMs = ms
+	counter := g.counter
+	g.mu.Unlock()
+
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	b[6] = 0x70 | byte(counter>>8)
+	b[7] = byte(counter)
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
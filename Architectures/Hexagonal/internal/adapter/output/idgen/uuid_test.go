@@ -0,0 +1,44 @@
+package idgen
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+var uuidv7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestUUIDv7GeneratorFormat(t *testing.T) {
+	id := NewUUIDv7Generator().NewID()
+	if !uuidv7Pattern.MatchString(id) {
+		t.Fatalf("%q no es un UUIDv7", id)
+	}
+}
+
+func TestUUIDv7GeneratorIsOrderedWithinAMillisecond(t *testing.T) {
+	g := NewUUIDv7Generator()
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return frozen }
+
+	previous := g.NewID()
+	for i := 0; i < 10000; i++ {
+		id := g.NewID()
+		if id <= previous {
+			t.Fatalf("el ID %q no es mayor que el anterior %q", id, previous)
+		}
+		if !uuidv7Pattern.MatchString(id) {
+			t.Fatalf("%q no es un UUIDv7", id)
+		}
+		previous = id
+	}
+}
+
+func TestUUIDv7GeneratorEncodesTimestamp(t *testing.T) {
+	g := NewUUIDv7Generator()
+	g.now = func() time.Time { return time.UnixMilli(0x0123456789ab) }
+
+	id := g.NewID()
+	if id[:13] != "01234567-89ab" {
+		t.Fatalf("el ID %q no empieza con el timestamp", id)
+	}
+}
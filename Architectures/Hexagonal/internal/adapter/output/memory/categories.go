@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"kiosco/internal/domain"
+	"sort"
+	"strings"
+)
+
+// ListCategories obtiene todas las categorías ordenadas por nombre
+func (r *MemoryItemRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*domain.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		copied := *category
+		categories = append(categories, &copied)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+// GetCategory obtiene una categoría por su ID
+func (r *MemoryItemRepository) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return nil, domain.ErrCategoryNotFound
+	}
+	copied := *category
+	return &copied, nil
+}
+
+// CreateCategory guarda una nueva categoría
+func (r *MemoryItemRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.categories[category.ID]; exists || r.categoryNameTaken(category.ID, category.Name) {
+		return nil, domain.ErrCategoryAlreadyExists
+	}
+
+	created := *category
+	r.categories[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+// UpdateCategory reemplaza una categoría existente; si cambia el nombre,
+// sus items pasan al nuevo
+func (r *MemoryItemRepository) UpdateCategory(ctx context.Context, id string, category *domain.Category) (*domain.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.categories[id]
+	if !exists {
+		return nil, domain.ErrCategoryNotFound
+	}
+	if r.categoryNameTaken(id, category.Name) {
+		return nil, domain.ErrCategoryAlreadyExists
+	}
+
+	updated := *category
+	updated.ID = id
+	if updated.Name != existing.Name {
+		for _, item := range r.items {
+			if item.Category == existing.Name {
+				item.Category = updated.Name
+				item.UpdatedAt = updated.UpdatedAt
+				item.Version++
+			}
+		}
+	}
+
+	r.categories[id] = &updated
+	copied := updated
+	return &copied, nil
+}
+
+// DeleteCategory elimina una categoría que ningún item referencia
+func (r *MemoryItemRepository) DeleteCategory(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, exists := r.categories[id]
+	if !exists {
+		return domain.ErrCategoryNotFound
+	}
+	for _, item := range r.items {
+		if item.Category == category.Name {
+			return domain.ErrCategoryInUse
+		}
+	}
+
+	delete(r.categories, id)
+	return nil
+}
+
+// SummarizeCategories obtiene el resumen de cada categoría ordenado por nombre
+func (r *MemoryItemRepository) SummarizeCategories(ctx context.Context) ([]*domain.CategorySummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byName := make(map[string]*domain.CategorySummary, len(r.categories))
+	summaries := make([]*domain.CategorySummary, 0, len(r.categories))
+	for _, category := range r.categories {
+		summary := &domain.CategorySummary{CategoryID: category.ID, Name: category.Name, StockValues: []domain.Money{}}
+		byName[category.Name] = summary
+		summaries = append(summaries, summary)
+	}
+	for _, item := range r.items {
+		if summary, exists := byName[item.Category]; exists {
+			summary.Add(item)
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries, nil
+}
+
+// categoryName devuelve el nombre con que está registrada la categoría,
+// comparando sin distinguir mayúsculas. Un item sin categoría es válido.
+// Debe llamarse con el lock tomado.
+func (r *MemoryItemRepository) categoryName(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	for _, category := range r.categories {
+		if strings.EqualFold(category.Name, name) {
+			return category.Name, nil
+		}
+	}
+	return "", domain.ErrUnknownItemCategory
+}
+
+// categoryNameTaken indica si otra categoría tiene el mismo nombre. Debe
+// llamarse con el lock tomado.
+func (r *MemoryItemRepository) categoryNameTaken(id, name string) bool {
+	for otherID, other := range r.categories {
+		if otherID != id && strings.EqualFold(other.Name, name) {
+			return true
+		}
+	}
+	return false
+}
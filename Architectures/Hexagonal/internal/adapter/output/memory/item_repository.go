@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"kiosco/internal/domain"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryItemRepository es el adaptador de salida que guarda los items en memoria.
+// Está pensado para desarrollo local y tests: los datos se pierden al reiniciar.
+type MemoryItemRepository struct {
+	mu             sync.RWMutex
+	items          map[string]*domain.Item
+	movements      map[string][]*domain.StockMovement
+	lastMovementID int64
+	orders         map[string]*domain.Order
+	categories     map[string]*domain.Category
+}
+
+// NewMemoryItemRepository crea una nueva instancia del repositorio en memoria
+func NewMemoryItemRepository() *MemoryItemRepository {
+	return &MemoryItemRepository{
+		items:      make(map[string]*domain.Item),
+		movements:  make(map[string][]*domain.StockMovement),
+		orders:     make(map[string]*domain.Order),
+		categories: make(map[string]*domain.Category),
+	}
+}
+
+// GetAll obtiene una página de los items que cumplen la consulta
+func (r *MemoryItemRepository) GetAll(ctx context.Context, query *domain.ItemQuery) (*domain.ItemPage, error) {
+	after, err := query.After()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matches := make([]*domain.Item, 0, len(r.items))
+	for _, item := range r.items {
+		if matchesQuery(item, query) {
+			matches = append(matches, item)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return compareItems(matches[i], matches[j], query) < 0
+	})
+
+	page := &domain.ItemPage{Items: []*domain.Item{}, Total: len(matches)}
+
+	start := 0
+	if after != nil {
+		position := cursorItem(after)
+		start = sort.Search(len(matches), func(i int) bool {
+			return compareItems(matches[i], position, query) > 0
+		})
+	}
+
+	for _, item := range matches[start:] {
+		if len(page.Items) == query.Limit {
+			page.NextCursor = query.NextCursor(page.Items[len(page.Items)-1])
+			break
+		}
+		page.Items = append(page.Items, copyItem(item))
+	}
+
+	return page, nil
+}
+
+// GetByID obtiene un item por su ID
+func (r *MemoryItemRepository) GetByID(ctx context.Context, id string) (*domain.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domain.ErrItemNotFound
+	}
+
+	return copyItem(item), nil
+}
+
+// Create guarda un nuevo item
+func (r *MemoryItemRepository) Create(ctx context.Context, item *domain.Item) (*domain.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := copyItem(item)
+	var err error
+	if created.Category, err = r.categoryName(created.Category); err != nil {
+		return nil, err
+	}
+	if _, exists := r.items[created.ID]; exists || r.nameTaken(created) {
+		return nil, domain.ErrItemAlreadyExists
+	}
+	created.Version = 1
+
+	r.items[created.ID] = created
+	if created.Stock > 0 {
+		r.appendMovement(domain.NewOpeningMovement(created))
+	}
+	return copyItem(created), nil
+}
+
+// Update reemplaza un item existente; el stock solo cambia con movimientos
+func (r *MemoryItemRepository) Update(ctx context.Context, id string, item *domain.Item) (*domain.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.items[id]
+	if !exists {
+		return nil, domain.ErrItemNotFound
+	}
+	if existing.Version != item.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	updated := copyItem(item)
+	updated.ID = id
+	updated.Stock = existing.Stock
+	updated.Version = existing.Version + 1
+	var err error
+	if updated.Category, err = r.categoryName(updated.Category); err != nil {
+		return nil, err
+	}
+	if r.nameTaken(updated) {
+		return nil, domain.ErrItemAlreadyExists
+	}
+
+	r.items[id] = updated
+	return copyItem(updated), nil
+}
+
+// Delete elimina un item por su ID
+func (r *MemoryItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.items[id]
+	if !exists {
+		return domain.ErrItemNotFound
+	}
+	if existing.Version != version {
+		return domain.ErrVersionMismatch
+	}
+
+	delete(r.items, id)
+	delete(r.movements, id)
+	return nil
+}
+
+// RecordMovement registra el movimiento y actualiza el stock del item
+func (r *MemoryItemRepository) RecordMovement(ctx context.Context, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, exists := r.items[movement.ItemID]
+	if !exists {
+		return nil, domain.ErrItemNotFound
+	}
+
+	stock := item.Stock + movement.Delta()
+	if stock < 0 {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	item.Stock = stock
+	item.UpdatedAt = movement.CreatedAt
+	item.Version++
+
+	recorded := *movement
+	recorded.StockAfter = stock
+	r.appendMovement(&recorded)
+	copied := recorded
+	return &copied, nil
+}
+
+// ListMovements obtiene los movimientos de un item en el orden en que se registraron
+func (r *MemoryItemRepository) ListMovements(ctx context.Context, itemID string) ([]*domain.StockMovement, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, exists := r.items[itemID]; !exists {
+		return nil, domain.ErrItemNotFound
+	}
+
+	movements := make([]*domain.StockMovement, len(r.movements[itemID]))
+	for i, movement := range r.movements[itemID] {
+		copied := *movement
+		movements[i] = &copied
+	}
+	return movements, nil
+}
+
+// appendMovement numera y guarda un movimiento. Debe llamarse con el lock tomado.
+func (r *MemoryItemRepository) appendMovement(movement *domain.StockMovement) {
+	r.lastMovementID++
+	movement.ID = r.lastMovementID
+	r.movements[movement.ItemID] = append(r.movements[movement.ItemID], movement)
+}
+
+// matchesQuery indica si el item cumple los filtros de la consulta
+func matchesQuery(item *domain.Item, query *domain.ItemQuery) bool {
+	if query.Category != "" && !strings.EqualFold(item.Category, query.Category) {
+		return false
+	}
+	if query.NameContains != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(query.NameContains)) {
+		return false
+	}
+	if query.MinPrice != nil && (item.Price.Currency != query.MinPrice.Currency || item.Price.Amount < query.MinPrice.Amount) {
+		return false
+	}
+	if query.MaxPrice != nil && (item.Price.Currency != query.MaxPrice.Currency || item.Price.Amount > query.MaxPrice.Amount) {
+		return false
+	}
+	if query.InStockOnly && item.Stock <= 0 {
+		return false
+	}
+	return true
+}
+
+// compareItems compara dos items según el orden de la consulta; el ID desempata
+func compareItems(a, b *domain.Item, query *domain.ItemQuery) int {
+	result := 0
+	switch query.SortBy {
+	case domain.SortByName:
+		result = strings.Compare(a.Name, b.Name)
+	case domain.SortByPrice:
+		result = a.Price.Compare(b.Price)
+	case domain.SortByStock:
+		result = cmp.Compare(a.Stock, b.Stock)
+	default:
+		result = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+	if query.Descending {
+		return -result
+	}
+	return result
+}
+
+// cursorItem reconstruye la clave de orden del último item de la página anterior
+func cursorItem(cursor *domain.ItemCursor) *domain.Item {
+	return &domain.Item{
+		ID:        cursor.ID,
+		Name:      cursor.Name,
+		Price:     cursor.Price,
+		Stock:     cursor.Stock,
+		CreatedAt: cursor.CreatedAt,
+	}
+}
+
+// copyItem evita que quien llama modifique los items guardados
+func copyItem(item *domain.Item) *domain.Item {
+	copied := *item
+	return &copied
+}
+
+// nameTaken indica si otro item de la misma categoría tiene el mismo nombre.
+// Debe llamarse con el lock tomado.
+func (r *MemoryItemRepository) nameTaken(item *domain.Item) bool {
+	for id, other := range r.items {
+		if id != item.ID && strings.EqualFold(other.Category, item.Category) && strings.EqualFold(other.Name, item.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"kiosco/internal/adapter/output/repositorytest"
+	"kiosco/internal/domain"
+	"sync"
+	"testing"
+)
+
+func TestMemoryItemRepository(t *testing.T) {
+	repositorytest.RunItemRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return NewMemoryItemRepository()
+	})
+}
+
+func TestMemoryCategoryRepository(t *testing.T) {
+	repositorytest.RunCategoryRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return NewMemoryItemRepository()
+	})
+}
+
+func TestMemoryStockMovementRepository(t *testing.T) {
+	repositorytest.RunStockMovementRepositoryTests(t, func(t *testing.T) repositorytest.StockRepository {
+		return NewMemoryItemRepository()
+	})
+}
+
+func TestMemoryOrderRepository(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) repositorytest.OrderRepository {
+		return NewMemoryItemRepository()
+	})
+}
+
+func TestMemoryItemRepositoryConcurrentAccess(t *testing.T) {
+	repo := NewMemoryItemRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			item := &domain.Item{ID: fmt.Sprintf("item-%d", i), Name: fmt.Sprintf("Alfajor %d", i), Stock: i}
+			if _, err := repo.Create(ctx, item); err != nil {
+				t.Errorf("Create: %v", err)
+			}
+			if _, err := repo.GetAll(ctx, &domain.ItemQuery{SortBy: domain.SortByCreatedAt, Limit: domain.MaxItemLimit}); err != nil {
+				t.Errorf("GetAll: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	page, err := repo.GetAll(ctx, &domain.ItemQuery{SortBy: domain.SortByCreatedAt, Limit: domain.MaxItemLimit})
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if page.Total != 50 {
+		t.Fatalf("se esperaban 50 items, se obtuvieron %d", page.Total)
+	}
+}
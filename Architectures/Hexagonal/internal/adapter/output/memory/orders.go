@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"kiosco/internal/domain"
+	"sort"
+	"time"
+)
+
+// PlaceOrder guarda la orden y descuenta el stock de todas sus líneas.
+// Primero se verifican todas las líneas; si alguna falla no se modifica nada.
+func (r *MemoryItemRepository) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.orders[order.ID]; exists {
+		return nil, domain.ErrOrderAlreadyExists
+	}
+
+	placed := copyOrder(order)
+	var rejected []domain.OrderLineError
+	for i := range placed.Lines {
+		line := &placed.Lines[i]
+		item, exists := r.items[line.ItemID]
+		switch {
+		case !exists:
+			rejected = append(rejected, domain.OrderLineError{
+				Line: i + 1, ItemID: line.ItemID, Requested: line.Quantity, Reason: domain.ErrItemNotFound.Error(),
+			})
+		case item.Stock < line.Quantity:
+			rejected = append(rejected, domain.OrderLineError{
+				Line: i + 1, ItemID: line.ItemID, Requested: line.Quantity, Available: item.Stock, Reason: domain.ErrInsufficientStock.Error(),
+			})
+		default:
+			line.ItemName = item.Name
+			line.UnitPrice = item.Price
+		}
+	}
+	if len(rejected) > 0 {
+		return nil, &domain.OrderStockError{Lines: rejected}
+	}
+
+	if err := placed.CalculateTotals(); err != nil {
+		return nil, err
+	}
+
+	for _, line := range placed.Lines {
+		item := r.items[line.ItemID]
+		item.Stock -= line.Quantity
+		item.UpdatedAt = placed.CreatedAt
+		item.Version++
+		r.appendMovement(placed.SaleMovement(line, item.Stock))
+	}
+
+	r.orders[placed.ID] = placed
+	return copyOrder(placed), nil
+}
+
+// GetOrder obtiene una orden por su ID
+func (r *MemoryItemRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, exists := r.orders[id]
+	if !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+	return copyOrder(order), nil
+}
+
+// ListOrders obtiene las órdenes de la más reciente a la más antigua
+func (r *MemoryItemRepository) ListOrders(ctx context.Context, query *domain.OrderQuery) ([]*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := []*domain.Order{}
+	for _, order := range r.orders {
+		if query.Status == "" || order.Status == query.Status {
+			orders = append(orders, order)
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.After(orders[j].CreatedAt)
+		}
+		return orders[i].ID > orders[j].ID
+	})
+	if len(orders) > query.Limit {
+		orders = orders[:query.Limit]
+	}
+
+	for i, order := range orders {
+		orders[i] = copyOrder(order)
+	}
+	return orders, nil
+}
+
+// VoidOrder anula la orden y devuelve al stock sus líneas. Las líneas de
+// items que ya no existen no se devuelven.
+func (r *MemoryItemRepository) VoidOrder(ctx context.Context, id, actor, reason string, at time.Time) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[id]
+	if !exists {
+		return nil, domain.ErrOrderNotFound
+	}
+	if order.Status == domain.OrderVoided {
+		return nil, domain.ErrOrderAlreadyVoided
+	}
+
+	order.Status = domain.OrderVoided
+	order.VoidedAt = &at
+	order.VoidedBy = actor
+	order.VoidReason = reason
+
+	for _, line := range order.Lines {
+		item, exists := r.items[line.ItemID]
+		if !exists {
+			continue
+		}
+		item.Stock += line.Quantity
+		item.UpdatedAt = at
+		item.Version++
+		r.appendMovement(order.ReturnMovement(line, item.Stock))
+	}
+
+	return copyOrder(order), nil
+}
+
+// copyOrder copia la orden con sus líneas
+func copyOrder(order *domain.Order) *domain.Order {
+	copied := *order
+	copied.Lines = append([]domain.OrderLine(nil), order.Lines...)
+	if order.VoidedAt != nil {
+		voidedAt := *order.VoidedAt
+		copied.VoidedAt = &voidedAt
+	}
+	return &copied
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// categoryColumns son las columnas de categories en el orden en que las lee scanCategory
+const categoryColumns = "id, name, description, created_at, updated_at"
+
+// ListCategories obtiene todas las categorías ordenadas por nombre
+func (r *PostgresItemRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+categoryColumns+` FROM categories ORDER BY name COLLATE "C", id COLLATE "C"`)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar categorías: %w", err)
+	}
+
+	categories, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Category, error) {
+		return scanCategory(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer categorías: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategory obtiene una categoría por su ID
+func (r *PostgresItemRepository) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	category, err := scanCategory(r.pool.QueryRow(ctx, "SELECT "+categoryColumns+" FROM categories WHERE id = $1", id))
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return category, nil
+}
+
+// CreateCategory inserta una nueva categoría
+func (r *PostgresItemRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	created, err := scanCategory(r.pool.QueryRow(ctx,
+		`INSERT INTO categories (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+categoryColumns,
+		category.ID, category.Name, category.Description, category.CreatedAt, category.UpdatedAt))
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return created, nil
+}
+
+// UpdateCategory actualiza una categoría existente y, si cambia el nombre,
+// la de sus items, en una misma transacción. La fila de la categoría queda
+// bloqueada hasta el final para que ningún item se cree con el nombre anterior.
+func (r *PostgresItemRepository) UpdateCategory(ctx context.Context, id string, category *domain.Category) (*domain.Category, error) {
+	var updated *domain.Category
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var previousName string
+		if err := tx.QueryRow(ctx, "SELECT name FROM categories WHERE id = $1 FOR UPDATE", id).Scan(&previousName); err != nil {
+			return err
+		}
+
+		row := tx.QueryRow(ctx,
+			`UPDATE categories
+			SET name = $2, description = $3, created_at = $4, updated_at = $5
+			WHERE id = $1
+			RETURNING `+categoryColumns,
+			id, category.Name, category.Description, category.CreatedAt, category.UpdatedAt)
+
+		var err error
+		if updated, err = scanCategory(row); err != nil {
+			return err
+		}
+
+		if updated.Name != previousName {
+			_, err = tx.Exec(ctx,
+				"UPDATE items SET category = $1, updated_at = $2, version = version + 1 WHERE category = $3",
+				updated.Name, updated.UpdatedAt, previousName)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCategory elimina una categoría si ningún item la referencia. Bloquear
+// la fila espera a que terminen las transacciones que están guardando items
+// en la categoría, y la consulta siguiente ya los ve.
+func (r *PostgresItemRepository) DeleteCategory(ctx context.Context, id string) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var name string
+		if err := tx.QueryRow(ctx, "SELECT name FROM categories WHERE id = $1 FOR UPDATE", id).Scan(&name); err != nil {
+			return err
+		}
+
+		var inUse bool
+		if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM items WHERE category = $1)", name).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrCategoryInUse
+		}
+
+		_, err := tx.Exec(ctx, "DELETE FROM categories WHERE id = $1", id)
+		return err
+	})
+	if err != nil {
+		return mapCategoryError(err)
+	}
+
+	return nil
+}
+
+// SummarizeCategories cuenta los items de cada categoría y suma el valor de
+// su stock en cada moneda
+func (r *PostgresItemRepository) SummarizeCategories(ctx context.Context) ([]*domain.CategorySummary, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT c.id, c.name, i.currency, COUNT(i.id), COALESCE(SUM(i.price * i.stock), 0)::BIGINT
+		FROM categories c
+		LEFT JOIN items i ON i.category = c.name
+		GROUP BY c.id, c.name, i.currency
+		ORDER BY c.name COLLATE "C", c.id COLLATE "C", i.currency COLLATE "C"`)
+	if err != nil {
+		return nil, fmt.Errorf("error al resumir categorías: %w", err)
+	}
+	defer rows.Close()
+
+	// Hay una fila por categoría y moneda; una categoría sin items tiene una
+	// sola fila, sin moneda
+	summaries := []*domain.CategorySummary{}
+	for rows.Next() {
+		var id, name string
+		var currency *string
+		var count int
+		var value int64
+		if err := rows.Scan(&id, &name, &currency, &count, &value); err != nil {
+			return nil, fmt.Errorf("error al leer el resumen de categorías: %w", err)
+		}
+		if len(summaries) == 0 || summaries[len(summaries)-1].CategoryID != id {
+			summaries = append(summaries, &domain.CategorySummary{CategoryID: id, Name: name, StockValues: []domain.Money{}})
+		}
+		summary := summaries[len(summaries)-1]
+		summary.ItemCount += count
+		if currency != nil {
+			summary.AddStockValue(domain.NewMoney(value, *currency))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer el resumen de categorías: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// categoryName devuelve el nombre con que está registrada la categoría,
+// comparando sin distinguir mayúsculas. Un item sin categoría es válido. La
+// fila queda bloqueada en modo compartido hasta el final de la transacción
+// para que la categoría no se borre ni se renombre mientras se guarda el item.
+func categoryName(ctx context.Context, tx pgx.Tx, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	err := tx.QueryRow(ctx, "SELECT name FROM categories WHERE lower(name) = lower($1) FOR SHARE", name).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrUnknownItemCategory
+	}
+	return name, err
+}
+
+// scanCategory lee una fila con las columnas de categoryColumns
+func scanCategory(row pgx.Row) (*domain.Category, error) {
+	var category domain.Category
+	err := row.Scan(&category.ID, &category.Name, &category.Description, &category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// mapCategoryError traduce los errores de PostgreSQL a errores del dominio de
+// las categorías
+func mapCategoryError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrCategoryNotFound
+	}
+	if errors.Is(err, domain.ErrCategoryInUse) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrCategoryAlreadyExists
+	}
+
+	return fmt.Errorf("error en la base de datos: %w", err)
+}
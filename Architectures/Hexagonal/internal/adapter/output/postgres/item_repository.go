@@ -0,0 +1,308 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// uniqueViolation es el código SQLSTATE de una clave duplicada
+const uniqueViolation = "23505"
+
+// itemColumns son las columnas de items en el orden en que las lee scanItem
+const itemColumns = "id, name, description, price, currency, stock, category, created_at, updated_at, version"
+
+// PostgresItemRepository es el adaptador de salida que persiste los items en PostgreSQL
+type PostgresItemRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresItemRepository crea una nueva instancia del repositorio PostgreSQL
+func NewPostgresItemRepository(pool *pgxpool.Pool) *PostgresItemRepository {
+	return &PostgresItemRepository{
+		pool: pool,
+	}
+}
+
+// GetAll obtiene una página de los items que cumplen la consulta. El conteo y
+// la página se leen en la misma transacción para que sean coherentes.
+func (r *PostgresItemRepository) GetAll(ctx context.Context, query *domain.ItemQuery) (*domain.ItemPage, error) {
+	after, err := query.After()
+	if err != nil {
+		return nil, err
+	}
+	where, args := itemFilters(query)
+
+	columns, direction := append(sortColumns(query), `id COLLATE "C"`), "ASC"
+	comparison := ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	pageWhere, pageArgs := where, args
+	if after != nil {
+		pageArgs = append(append(append([]any{}, args...), cursorValues(query.SortBy, after)...), after.ID)
+		placeholders := make([]string, len(columns))
+		for i := range placeholders {
+			placeholders[i] = fmt.Sprintf("$%d", len(pageArgs)-len(columns)+i+1)
+		}
+		pageWhere = appendCondition(where, fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), comparison, strings.Join(placeholders, ", ")))
+	}
+	pageArgs = append(pageArgs, query.Limit+1)
+
+	ordering := make([]string, len(columns))
+	for i, column := range columns {
+		ordering[i] = column + " " + direction
+	}
+	pageSQL := fmt.Sprintf(`SELECT %s FROM items%s ORDER BY %s LIMIT $%d`,
+		itemColumns, pageWhere, strings.Join(ordering, ", "), len(pageArgs))
+
+	page := &domain.ItemPage{}
+	err = pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM items"+where, args...).Scan(&page.Total); err != nil {
+			return fmt.Errorf("error al contar items: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, pageSQL, pageArgs...)
+		if err != nil {
+			return fmt.Errorf("error al consultar items: %w", err)
+		}
+		page.Items, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Item, error) {
+			return scanItem(row)
+		})
+		if err != nil {
+			return fmt.Errorf("error al leer items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Se pidió un item de más para saber si hay otra página
+	if len(page.Items) > query.Limit {
+		page.Items = page.Items[:query.Limit]
+		page.NextCursor = query.NextCursor(page.Items[query.Limit-1])
+	}
+
+	return page, nil
+}
+
+// GetByID obtiene un item por su ID
+func (r *PostgresItemRepository) GetByID(ctx context.Context, id string) (*domain.Item, error) {
+	item, err := scanItem(r.pool.QueryRow(ctx, "SELECT "+itemColumns+" FROM items WHERE id = $1", id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return item, nil
+}
+
+// Create inserta un nuevo item y el movimiento con su stock inicial
+func (r *PostgresItemRepository) Create(ctx context.Context, item *domain.Item) (*domain.Item, error) {
+	var createdItem *domain.Item
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		category, err := categoryName(ctx, tx, item.Category)
+		if err != nil {
+			return err
+		}
+
+		row := tx.QueryRow(ctx,
+			`INSERT INTO items (id, name, description, price, currency, stock, category, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING `+itemColumns,
+			item.ID, item.Name, item.Description, item.Price.Amount, item.Price.Currency, item.Stock, category,
+			item.CreatedAt, item.UpdatedAt)
+
+		if createdItem, err = scanItem(row); err != nil {
+			return err
+		}
+
+		if createdItem.Stock > 0 {
+			_, err = insertMovement(ctx, tx, domain.NewOpeningMovement(createdItem))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return createdItem, nil
+}
+
+// Update actualiza un item existente si su versión no cambió; el stock solo
+// cambia con movimientos
+func (r *PostgresItemRepository) Update(ctx context.Context, id string, item *domain.Item) (*domain.Item, error) {
+	var updatedItem *domain.Item
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		category, err := categoryName(ctx, tx, item.Category)
+		if err != nil {
+			return err
+		}
+
+		row := tx.QueryRow(ctx,
+			`UPDATE items
+			SET name = $2, description = $3, price = $4, currency = $5, category = $6, created_at = $7, updated_at = $8,
+				version = version + 1
+			WHERE id = $1 AND version = $9
+			RETURNING `+itemColumns,
+			id, item.Name, item.Description, item.Price.Amount, item.Price.Currency, category, item.CreatedAt, item.UpdatedAt,
+			item.Version)
+
+		updatedItem, err = scanItem(row)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return itemMissingOrStale(ctx, tx, id)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return updatedItem, nil
+}
+
+// Delete elimina un item por su ID si su versión no cambió
+func (r *PostgresItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM items WHERE id = $1 AND version = $2", id, version)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return itemMissingOrStale(ctx, tx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// itemMissingOrStale explica por qué una escritura condicionada a la versión
+// no encontró el item
+func itemMissingOrStale(ctx context.Context, tx pgx.Tx, id string) error {
+	var exists int
+	err := tx.QueryRow(ctx, "SELECT 1 FROM items WHERE id = $1", id).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrVersionMismatch
+}
+
+// itemFilters arma la cláusula WHERE con los filtros de la consulta
+func itemFilters(query *domain.ItemQuery) (string, []any) {
+	where := ""
+	var args []any
+
+	add := func(condition string, value any) {
+		args = append(args, value)
+		where = appendCondition(where, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.Category != "" {
+		add("lower(category) = lower($%d)", query.Category)
+	}
+	if query.NameContains != "" {
+		add("strpos(lower(name), lower($%d)) > 0", query.NameContains)
+	}
+	if query.MinPrice != nil {
+		add("currency = $%d", query.MinPrice.Currency)
+		add("price >= $%d", query.MinPrice.Amount)
+	}
+	if query.MaxPrice != nil {
+		add("currency = $%d", query.MaxPrice.Currency)
+		add("price <= $%d", query.MaxPrice.Amount)
+	}
+	if query.InStockOnly {
+		where = appendCondition(where, "stock > 0")
+	}
+
+	return where, args
+}
+
+func appendCondition(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// sortColumns devuelve las columnas por las que ordena la consulta; el
+// precio se ordena por moneda y después por importe. Los textos se comparan
+// byte a byte (COLLATE "C") para que el orden no dependa de la configuración
+// regional de la base
+func sortColumns(query *domain.ItemQuery) []string {
+	switch query.SortBy {
+	case domain.SortByName:
+		return []string{`name COLLATE "C"`}
+	case domain.SortByPrice:
+		return []string{`currency COLLATE "C"`, "price"}
+	case domain.SortByStock:
+		return []string{"stock"}
+	default:
+		return []string{"created_at"}
+	}
+}
+
+// cursorValues devuelve los valores de las columnas de orden guardados en el cursor
+func cursorValues(sortBy string, after *domain.ItemCursor) []any {
+	switch sortBy {
+	case domain.SortByName:
+		return []any{after.Name}
+	case domain.SortByPrice:
+		return []any{after.Price.Currency, after.Price.Amount}
+	case domain.SortByStock:
+		return []any{after.Stock}
+	default:
+		return []any{after.CreatedAt}
+	}
+}
+
+// scanItem lee una fila con las columnas de itemColumns
+func scanItem(row pgx.Row) (*domain.Item, error) {
+	var item domain.Item
+	err := row.Scan(&item.ID, &item.Name, &item.Description, &item.Price.Amount, &item.Price.Currency, &item.Stock,
+		&item.Category, &item.CreatedAt, &item.UpdatedAt, &item.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// mapError traduce los errores de PostgreSQL a errores del dominio
+func mapError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+
+	// Los errores del dominio devueltos dentro de una transacción pasan sin cambios
+	var stockErr *domain.OrderStockError
+	switch {
+	case errors.Is(err, domain.ErrItemNotFound), errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrVersionMismatch), errors.Is(err, domain.ErrUnknownItemCategory),
+		errors.Is(err, domain.ErrOrderNotFound), errors.Is(err, domain.ErrOrderAlreadyExists),
+		errors.Is(err, domain.ErrOrderAlreadyVoided), errors.Is(err, domain.ErrInvalidOrderDiscount),
+		errors.Is(err, domain.ErrOrderCurrencyMismatch), errors.As(err, &stockErr):
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrItemAlreadyExists
+	}
+
+	return fmt.Errorf("error en la base de datos: %w", err)
+}
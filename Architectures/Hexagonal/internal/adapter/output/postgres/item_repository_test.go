@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"kiosco/internal/adapter/output/repositorytest"
+	"os"
+	"testing"
+)
+
+// testDatabaseURLEnv apunta a una base de datos descartable: los tests
+// vacían las tablas
+const testDatabaseURLEnv = "KIOSCO_TEST_DATABASE_URL"
+
+func TestPostgresItemRepository(t *testing.T) {
+	url := os.Getenv(testDatabaseURLEnv)
+	if url == "" {
+		t.Skipf("%s no está definida", testDatabaseURLEnv)
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, PoolConfig{URL: url})
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if err := Migrate(ctx, pool); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	newRepository := func(t *testing.T) *PostgresItemRepository {
+		if _, err := pool.Exec(ctx, "TRUNCATE items, orders, categories CASCADE"); err != nil {
+			t.Fatalf("TRUNCATE: %v", err)
+		}
+		return NewPostgresItemRepository(pool)
+	}
+
+	repositorytest.RunItemRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newRepository(t)
+	})
+	repositorytest.RunCategoryRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newRepository(t)
+	})
+	repositorytest.RunStockMovementRepositoryTests(t, func(t *testing.T) repositorytest.StockRepository {
+		return newRepository(t)
+	})
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) repositorytest.OrderRepository {
+		return newRepository(t)
+	})
+}
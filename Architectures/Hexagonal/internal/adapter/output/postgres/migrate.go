@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationFiles contiene las migraciones versionadas, nombradas NNNN_descripcion.sql
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID identifica el advisory lock que serializa las migraciones
+// cuando varias instancias arrancan a la vez
+const migrationLockID = 7310412
+
+// migration es un archivo de migración con su versión
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// Migrate aplica, en orden y dentro de una única transacción, las migraciones
+// que todavía no figuran en la tabla schema_migrations
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("error al bloquear las migraciones: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`); err != nil {
+			return fmt.Errorf("error al crear schema_migrations: %w", err)
+		}
+
+		rows, err := tx.Query(ctx, "SELECT version FROM schema_migrations")
+		if err != nil {
+			return fmt.Errorf("error al leer schema_migrations: %w", err)
+		}
+		applied, err := pgx.CollectRows(rows, pgx.RowTo[int])
+		if err != nil {
+			return fmt.Errorf("error al leer schema_migrations: %w", err)
+		}
+		done := make(map[int]bool, len(applied))
+		for _, version := range applied {
+			done[version] = true
+		}
+
+		for _, m := range migrations {
+			if done[m.version] {
+				continue
+			}
+			if _, err := tx.Exec(ctx, m.sql); err != nil {
+				return fmt.Errorf("error en la migración %s: %w", m.name, err)
+			}
+			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", m.version, m.name); err != nil {
+				return fmt.Errorf("error al registrar la migración %s: %w", m.name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// loadMigrations lee las migraciones embebidas ordenadas por versión
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(entries))
+	seen := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, found := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil {
+			return nil, fmt.Errorf("nombre de migración inválido %q: se espera NNNN_descripcion.sql", name)
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("las migraciones %s y %s tienen la misma versión", other, name)
+		}
+		seen[version] = name
+
+		sql, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{version: version, name: name, sql: string(sql)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
@@ -0,0 +1,11 @@
+-- Tabla principal de items del kiosco
+CREATE TABLE items (
+    id          TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price       NUMERIC(12, 2) NOT NULL CHECK (price >= 0),
+    stock       INTEGER NOT NULL CHECK (stock >= 0),
+    category    TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
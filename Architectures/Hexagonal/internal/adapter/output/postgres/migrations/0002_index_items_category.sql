@@ -0,0 +1,2 @@
+-- Los listados filtran y agrupan por categoría
+CREATE INDEX items_category_idx ON items (category);
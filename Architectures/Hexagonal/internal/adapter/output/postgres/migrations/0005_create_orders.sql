@@ -0,0 +1,31 @@
+-- Órdenes de venta del mostrador
+CREATE TABLE orders (
+    id             TEXT PRIMARY KEY,
+    status         TEXT NOT NULL CHECK (status IN ('completed', 'voided')),
+    payment_method TEXT NOT NULL,
+    subtotal       NUMERIC(12, 2) NOT NULL,
+    discount       NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    tax_rate       NUMERIC(5, 4) NOT NULL DEFAULT 0,
+    taxes          NUMERIC(12, 2) NOT NULL,
+    total          NUMERIC(12, 2) NOT NULL,
+    cashier        TEXT NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL,
+    voided_at      TIMESTAMPTZ,
+    voided_by      TEXT NOT NULL DEFAULT '',
+    void_reason    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX orders_created_at_idx ON orders (created_at, id);
+
+-- Las líneas guardan el nombre y el precio del item al venderse; no referencian
+-- items para que la orden sobreviva al borrado del item
+CREATE TABLE order_lines (
+    order_id   TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    line_no    INTEGER NOT NULL,
+    item_id    TEXT NOT NULL,
+    item_name  TEXT NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price NUMERIC(12, 2) NOT NULL,
+    line_total NUMERIC(12, 2) NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
@@ -0,0 +1,2 @@
+-- Versión de cada item para detectar ediciones concurrentes
+ALTER TABLE items ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,25 @@
+-- Categorías de items: los items las referencian por su nombre
+CREATE TABLE categories (
+    id          TEXT PRIMARY KEY DEFAULT gen_random_uuid()::text,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+-- Los nombres de categorías no se repiten
+CREATE UNIQUE INDEX categories_name_key ON categories (lower(name));
+
+-- Las categorías que ya usan los items quedan registradas; de las que solo
+-- difieren en mayúsculas se conserva una
+INSERT INTO categories (name, created_at, updated_at)
+SELECT MIN(category), MIN(created_at), MIN(created_at)
+FROM items
+WHERE category <> ''
+GROUP BY lower(category);
+
+-- Los items pasan a usar el nombre registrado de su categoría
+UPDATE items
+SET category = categories.name
+FROM categories
+WHERE lower(categories.name) = lower(items.category) AND items.category <> categories.name;
@@ -0,0 +1,18 @@
+-- Los montos pasan a guardarse como enteros en la unidad menor de la moneda
+-- (centavos), junto con su código ISO-4217. Los montos existentes estaban en
+-- pesos con dos decimales.
+ALTER TABLE items
+    ALTER COLUMN price TYPE BIGINT USING round(price * 100)::BIGINT,
+    ADD COLUMN currency TEXT NOT NULL DEFAULT 'ARS';
+
+-- Las líneas están en la moneda de su orden
+ALTER TABLE orders
+    ALTER COLUMN subtotal TYPE BIGINT USING round(subtotal * 100)::BIGINT,
+    ALTER COLUMN discount TYPE BIGINT USING round(discount * 100)::BIGINT,
+    ALTER COLUMN taxes TYPE BIGINT USING round(taxes * 100)::BIGINT,
+    ALTER COLUMN total TYPE BIGINT USING round(total * 100)::BIGINT,
+    ADD COLUMN currency TEXT NOT NULL DEFAULT 'ARS';
+
+ALTER TABLE order_lines
+    ALTER COLUMN unit_price TYPE BIGINT USING round(unit_price * 100)::BIGINT,
+    ALTER COLUMN line_total TYPE BIGINT USING round(line_total * 100)::BIGINT;
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// orderColumns son las columnas de orders en el orden en que las lee scanOrder
+const orderColumns = "id, status, payment_method, currency, subtotal, discount, tax_rate, taxes, total, cashier, created_at, voided_at, voided_by, void_reason"
+
+// PlaceOrder guarda la orden y descuenta el stock de todas sus líneas en una
+// misma transacción. Si alguna línea falla se sigue revisando el resto para
+// informarlas todas juntas, y la transacción se descarta.
+func (r *PostgresItemRepository) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	placed := *order
+	placed.Lines = append([]domain.OrderLine(nil), order.Lines...)
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		stocks := make([]int, len(placed.Lines))
+		var rejected []domain.OrderLineError
+		for _, i := range lockOrder(placed.Lines) {
+			line := &placed.Lines[i]
+			err := tx.QueryRow(ctx,
+				`UPDATE items SET stock = stock - $2, updated_at = $3, version = version + 1
+				WHERE id = $1 AND stock >= $2
+				RETURNING name, price, currency, stock`,
+				line.ItemID, line.Quantity, placed.CreatedAt).
+				Scan(&line.ItemName, &line.UnitPrice.Amount, &line.UnitPrice.Currency, &stocks[i])
+			if errors.Is(err, pgx.ErrNoRows) {
+				lineErr, err := rejectedLine(ctx, tx, i, *line)
+				if err != nil {
+					return err
+				}
+				rejected = append(rejected, lineErr)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(rejected) > 0 {
+			sort.Slice(rejected, func(a, b int) bool { return rejected[a].Line < rejected[b].Line })
+			return &domain.OrderStockError{Lines: rejected}
+		}
+
+		if err := placed.CalculateTotals(); err != nil {
+			return err
+		}
+
+		var id string
+		err := tx.QueryRow(ctx,
+			`INSERT INTO orders (id, status, payment_method, currency, subtotal, discount, tax_rate, taxes, total, cashier, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id`,
+			placed.ID, placed.Status, placed.PaymentMethod, placed.Total.Currency, placed.Subtotal.Amount, placed.Discount.Amount,
+			placed.TaxRate, placed.Taxes.Amount, placed.Total.Amount, placed.Cashier, placed.CreatedAt).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrOrderAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+
+		for i, line := range placed.Lines {
+			if _, err := tx.Exec(ctx,
+				`INSERT INTO order_lines (order_id, line_no, item_id, item_name, quantity, unit_price, line_total)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				placed.ID, i+1, line.ItemID, line.ItemName, line.Quantity, line.UnitPrice.Amount, line.LineTotal.Amount); err != nil {
+				return err
+			}
+			if _, err := insertMovement(ctx, tx, placed.SaleMovement(line, stocks[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &placed, nil
+}
+
+// GetOrder obtiene una orden por su ID
+func (r *PostgresItemRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	var order *domain.Order
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		var err error
+		order, err = loadOrder(ctx, tx, id, "")
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return order, nil
+}
+
+// ListOrders obtiene las órdenes de la más reciente a la más antigua
+func (r *PostgresItemRepository) ListOrders(ctx context.Context, query *domain.OrderQuery) ([]*domain.Order, error) {
+	where, args := "", []any{}
+	if query.Status != "" {
+		args = append(args, query.Status)
+		where = " WHERE status = $1"
+	}
+	args = append(args, query.Limit)
+	ordersSQL := fmt.Sprintf("SELECT %s FROM orders%s ORDER BY created_at DESC, id COLLATE \"C\" DESC LIMIT $%d",
+		orderColumns, where, len(args))
+
+	var orders []*domain.Order
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, ordersSQL, args...)
+		if err != nil {
+			return fmt.Errorf("error al consultar órdenes: %w", err)
+		}
+		orders, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Order, error) {
+			return scanOrder(row)
+		})
+		if err != nil {
+			return fmt.Errorf("error al leer órdenes: %w", err)
+		}
+
+		for _, order := range orders {
+			if order.Lines, err = loadOrderLines(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// VoidOrder anula la orden y devuelve al stock sus líneas en una misma
+// transacción. Las líneas de items que ya no existen no se devuelven.
+func (r *PostgresItemRepository) VoidOrder(ctx context.Context, id, actor, reason string, at time.Time) (*domain.Order, error) {
+	var order *domain.Order
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		// FOR UPDATE impide que dos anulaciones simultáneas devuelvan el stock dos veces
+		var err error
+		if order, err = loadOrder(ctx, tx, id, " FOR UPDATE"); err != nil {
+			return err
+		}
+		if order.Status == domain.OrderVoided {
+			return domain.ErrOrderAlreadyVoided
+		}
+
+		order.Status = domain.OrderVoided
+		order.VoidedAt = &at
+		order.VoidedBy = actor
+		order.VoidReason = reason
+		if _, err := tx.Exec(ctx,
+			"UPDATE orders SET status = $2, voided_at = $3, voided_by = $4, void_reason = $5 WHERE id = $1",
+			id, order.Status, at, actor, reason); err != nil {
+			return err
+		}
+
+		for _, i := range lockOrder(order.Lines) {
+			line := order.Lines[i]
+			var stock int
+			err := tx.QueryRow(ctx,
+				"UPDATE items SET stock = stock + $2, updated_at = $3, version = version + 1 WHERE id = $1 RETURNING stock",
+				line.ItemID, line.Quantity, at).Scan(&stock)
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := insertMovement(ctx, tx, order.ReturnMovement(line, stock)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return order, nil
+}
+
+// lockOrder devuelve los índices de las líneas ordenados por ID de item. Dos
+// transacciones que bloquean los mismos items en el mismo orden no pueden
+// quedar en deadlock.
+func lockOrder(lines []domain.OrderLine) []int {
+	indexes := make([]int, len(lines))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(a, b int) bool { return lines[indexes[a]].ItemID < lines[indexes[b]].ItemID })
+	return indexes
+}
+
+// rejectedLine explica por qué no se pudo descontar el stock de una línea
+func rejectedLine(ctx context.Context, tx pgx.Tx, index int, line domain.OrderLine) (domain.OrderLineError, error) {
+	lineErr := domain.OrderLineError{Line: index + 1, ItemID: line.ItemID, Requested: line.Quantity}
+
+	err := tx.QueryRow(ctx, "SELECT stock FROM items WHERE id = $1", line.ItemID).Scan(&lineErr.Available)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		lineErr.Reason = domain.ErrItemNotFound.Error()
+	case err != nil:
+		return lineErr, err
+	default:
+		lineErr.Reason = domain.ErrInsufficientStock.Error()
+	}
+	return lineErr, nil
+}
+
+// loadOrder lee una orden con sus líneas; lock se agrega a la consulta de la orden
+func loadOrder(ctx context.Context, tx pgx.Tx, id, lock string) (*domain.Order, error) {
+	order, err := scanOrder(tx.QueryRow(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = $1"+lock, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Lines, err = loadOrderLines(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// loadOrderLines lee las líneas de una orden en el orden en que se cargaron;
+// sus montos están en la moneda de la orden
+func loadOrderLines(ctx context.Context, tx pgx.Tx, order *domain.Order) ([]domain.OrderLine, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT item_id, item_name, quantity, unit_price, line_total
+		FROM order_lines WHERE order_id = $1 ORDER BY line_no`, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar líneas de la orden: %w", err)
+	}
+
+	lines, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OrderLine, error) {
+		line := domain.OrderLine{
+			UnitPrice: domain.NewMoney(0, order.Total.Currency),
+			LineTotal: domain.NewMoney(0, order.Total.Currency),
+		}
+		err := row.Scan(&line.ItemID, &line.ItemName, &line.Quantity, &line.UnitPrice.Amount, &line.LineTotal.Amount)
+		return line, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer líneas de la orden: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanOrder lee una fila con las columnas de orderColumns, sin las líneas
+func scanOrder(row pgx.Row) (*domain.Order, error) {
+	var order domain.Order
+	var currency string
+	err := row.Scan(&order.ID, &order.Status, &order.PaymentMethod, &currency, &order.Subtotal.Amount, &order.Discount.Amount,
+		&order.TaxRate, &order.Taxes.Amount, &order.Total.Amount, &order.Cashier, &order.CreatedAt, &order.VoidedAt,
+		&order.VoidedBy, &order.VoidReason)
+	if err != nil {
+		return nil, err
+	}
+	order.Subtotal.Currency, order.Discount.Currency = currency, currency
+	order.Taxes.Currency, order.Total.Currency = currency, currency
+	return &order, nil
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// movementColumns son las columnas de stock_movements en el orden en que las lee scanMovement
+const movementColumns = "id, item_id, type, quantity, reason, actor, stock_after, created_at"
+
+// RecordMovement registra el movimiento y aplica su Delta al stock del item en
+// una misma transacción; la condición del UPDATE impide el stock negativo
+func (r *PostgresItemRepository) RecordMovement(ctx context.Context, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	var recorded *domain.StockMovement
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		var stock int
+		err := tx.QueryRow(ctx,
+			`UPDATE items SET stock = stock + $2, updated_at = $3, version = version + 1
+			WHERE id = $1 AND stock + $2 >= 0
+			RETURNING stock`,
+			movement.ItemID, movement.Delta(), movement.CreatedAt).Scan(&stock)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return itemMissingOrShort(ctx, tx, movement.ItemID)
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := *movement
+		entry.StockAfter = stock
+		recorded, err = insertMovement(ctx, tx, &entry)
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return recorded, nil
+}
+
+// ListMovements obtiene los movimientos de un item en el orden en que se registraron
+func (r *PostgresItemRepository) ListMovements(ctx context.Context, itemID string) ([]*domain.StockMovement, error) {
+	if _, err := r.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE item_id = $1 ORDER BY id", itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar movimientos: %w", err)
+	}
+
+	movements, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.StockMovement, error) {
+		return scanMovement(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al leer movimientos: %w", err)
+	}
+
+	return movements, nil
+}
+
+// insertMovement guarda un movimiento cuyo StockAfter ya está calculado
+func insertMovement(ctx context.Context, tx pgx.Tx, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	row := tx.QueryRow(ctx,
+		`INSERT INTO stock_movements (item_id, type, quantity, reason, actor, stock_after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+movementColumns,
+		movement.ItemID, movement.Type, movement.Quantity, movement.Reason, movement.Actor,
+		movement.StockAfter, movement.CreatedAt)
+	return scanMovement(row)
+}
+
+// itemMissingOrShort explica por qué un movimiento no actualizó el item
+func itemMissingOrShort(ctx context.Context, tx pgx.Tx, itemID string) error {
+	var exists int
+	err := tx.QueryRow(ctx, "SELECT 1 FROM items WHERE id = $1", itemID).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrInsufficientStock
+}
+
+// scanMovement lee una fila con las columnas de movementColumns
+func scanMovement(row pgx.Row) (*domain.StockMovement, error) {
+	var movement domain.StockMovement
+	err := row.Scan(&movement.ID, &movement.ItemID, &movement.Type, &movement.Quantity, &movement.Reason,
+		&movement.Actor, &movement.StockAfter, &movement.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
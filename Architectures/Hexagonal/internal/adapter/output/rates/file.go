@@ -0,0 +1,43 @@
+package rates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"kiosco/internal/domain"
+	"os"
+)
+
+// file es el formato del archivo de cotizaciones, por ejemplo
+// {"base": "ARS", "rates": {"USD": "1050.50", "EUR": 1130}}. Cada cotización
+// es cuánto vale una unidad de la moneda en la base; puede escribirse como
+// número o como texto para no perder decimales.
+type file struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// LoadFile lee las cotizaciones de un archivo JSON
+func LoadFile(path string) (*domain.ExchangeRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer cotizaciones: %w", err)
+	}
+
+	var decoded file
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("error al decodificar cotizaciones de %s: %w", path, err)
+	}
+
+	rates := make(map[string]string, len(decoded.Rates))
+	for currency, rate := range decoded.Rates {
+		rates[currency] = rate.String()
+	}
+	exchangeRates, err := domain.NewExchangeRates(decoded.Base, rates)
+	if err != nil {
+		return nil, fmt.Errorf("cotizaciones inválidas en %s: %w", path, err)
+	}
+	return exchangeRates, nil
+}
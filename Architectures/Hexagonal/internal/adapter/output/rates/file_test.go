@@ -0,0 +1,46 @@
+package rates
+
+import (
+	"errors"
+	"kiosco/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	rates, err := LoadFile(writeFile(t, `{"base": "ARS", "rates": {"USD": "1050.50", "EUR": 1130}}`))
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	for from, want := range map[domain.Money]domain.Money{
+		domain.NewMoney(200, "USD"): domain.NewMoney(210100, "ARS"),
+		domain.NewMoney(100, "EUR"): domain.NewMoney(113000, "ARS"),
+	} {
+		if got, err := rates.Convert(from, "ARS"); err != nil || got != want {
+			t.Errorf("Convert(%v) = %v, %v; se esperaba %v", from, got, err, want)
+		}
+	}
+}
+
+func TestLoadFileRejectsInvalidRates(t *testing.T) {
+	if _, err := LoadFile(writeFile(t, `{"base": "ARS", "rates": {"USD": "-1"}}`)); !errors.Is(err, domain.ErrInvalidExchangeRate) {
+		t.Fatalf("se esperaba ErrInvalidExchangeRate, se obtuvo %v", err)
+	}
+	if _, err := LoadFile(writeFile(t, `{"base": "pesos", "rates": {}}`)); !errors.Is(err, domain.ErrInvalidCurrency) {
+		t.Fatalf("se esperaba ErrInvalidCurrency, se obtuvo %v", err)
+	}
+	if _, err := LoadFile(filepath.Join(t.TempDir(), "no-existe.json")); err == nil {
+		t.Fatal("se esperaba un error con un archivo inexistente")
+	}
+}
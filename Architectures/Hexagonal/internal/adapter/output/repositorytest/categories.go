@@ -0,0 +1,318 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// RunCategoryRepositoryTests ejecuta el contrato de domain.CategoryRepository
+// contra los repositorios que crea newRepository
+func RunCategoryRepositoryTests(t *testing.T, newRepository NewRepository) {
+	t.Run("CreateAndGetCategory", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		created, err := repo.CreateCategory(ctx, newCategory("categoria-1", "Bebidas"))
+		if err != nil {
+			t.Fatalf("CreateCategory: %v", err)
+		}
+		assertCategory(t, created, newCategory("categoria-1", "Bebidas"))
+
+		found, err := repo.GetCategory(ctx, "categoria-1")
+		if err != nil {
+			t.Fatalf("GetCategory: %v", err)
+		}
+		assertCategory(t, found, newCategory("categoria-1", "Bebidas"))
+	})
+
+	t.Run("CreateDuplicateNameReturnsErrCategoryAlreadyExists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.CreateCategory(ctx, newCategory("categoria-1", "Bebidas")); err != nil {
+			t.Fatalf("CreateCategory: %v", err)
+		}
+
+		// El nombre se compara sin distinguir mayúsculas
+		if _, err := repo.CreateCategory(ctx, newCategory("categoria-2", "BEBIDAS")); !errors.Is(err, domain.ErrCategoryAlreadyExists) {
+			t.Fatalf("mismo nombre: se esperaba ErrCategoryAlreadyExists, se obtuvo %v", err)
+		}
+		if _, err := repo.CreateCategory(ctx, newCategory("categoria-1", "Golosinas")); !errors.Is(err, domain.ErrCategoryAlreadyExists) {
+			t.Fatalf("mismo ID: se esperaba ErrCategoryAlreadyExists, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("GetCategoryMissingReturnsErrCategoryNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.GetCategory(context.Background(), "no-existe")
+		if !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("se esperaba ErrCategoryNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("ListCategoriesSortsByName", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		categories, err := repo.ListCategories(ctx)
+		if err != nil {
+			t.Fatalf("ListCategories: %v", err)
+		}
+		if len(categories) != 0 {
+			t.Fatalf("se esperaba una lista vacía, se obtuvieron %d categorías", len(categories))
+		}
+
+		for i, name := range []string{"Golosinas", "Bebidas", "Promociones"} {
+			if _, err := repo.CreateCategory(ctx, newCategory(fmt.Sprintf("categoria-%d", i+1), name)); err != nil {
+				t.Fatalf("CreateCategory(%q): %v", name, err)
+			}
+		}
+
+		categories, err = repo.ListCategories(ctx)
+		if err != nil {
+			t.Fatalf("ListCategories: %v", err)
+		}
+		var names []string
+		for _, category := range categories {
+			names = append(names, category.Name)
+		}
+		if fmt.Sprint(names) != "[Bebidas Golosinas Promociones]" {
+			t.Fatalf("categorías = %v, se esperaba [Bebidas Golosinas Promociones]", names)
+		}
+	})
+
+	t.Run("UpdateCategoryRenamesItsItems", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		renamed := newCategory("categoria-1", "Bebidas frías")
+		renamed.Description = "Heladera"
+		renamed.UpdatedAt = baseTime.Add(24 * time.Hour)
+		updated, err := repo.UpdateCategory(ctx, "categoria-1", renamed)
+		if err != nil {
+			t.Fatalf("UpdateCategory: %v", err)
+		}
+		assertCategory(t, updated, renamed)
+
+		for id, want := range map[string]string{"coca": "Bebidas frías", "agua": "Bebidas frías", "alfajor": "Golosinas"} {
+			item, err := repo.GetByID(ctx, id)
+			if err != nil {
+				t.Fatalf("GetByID(%q): %v", id, err)
+			}
+			if item.Category != want {
+				t.Fatalf("categoría de %s = %q, se esperaba %q", id, item.Category, want)
+			}
+			// Los items que cambian de categoría cambian de versión
+			moved := want != "Golosinas"
+			if moved && (item.Version != 2 || !item.UpdatedAt.Equal(renamed.UpdatedAt)) {
+				t.Fatalf("%s: versión %d, actualizado %v; se esperaba 2 y %v", id, item.Version, item.UpdatedAt, renamed.UpdatedAt)
+			}
+			if !moved && item.Version != 1 {
+				t.Fatalf("%s: versión %d, se esperaba 1", id, item.Version)
+			}
+		}
+
+		page, err := repo.GetAll(ctx, normalized(t, domain.ItemQuery{Category: "Bebidas"}))
+		if err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		if page.Total != 0 {
+			t.Fatalf("quedaron %d items en la categoría anterior", page.Total)
+		}
+	})
+
+	t.Run("UpdateCategoryWithoutRenameKeepsItems", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		changed := newCategory("categoria-1", "Bebidas")
+		changed.Description = "Heladera"
+		if _, err := repo.UpdateCategory(ctx, "categoria-1", changed); err != nil {
+			t.Fatalf("UpdateCategory: %v", err)
+		}
+
+		item, err := repo.GetByID(ctx, "coca")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if item.Version != 1 {
+			t.Fatalf("versión = %d, se esperaba 1", item.Version)
+		}
+	})
+
+	t.Run("UpdateCategoryToTakenNameReturnsErrCategoryAlreadyExists", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+
+		_, err := repo.UpdateCategory(ctx, "categoria-1", newCategory("categoria-1", "golosinas"))
+		if !errors.Is(err, domain.ErrCategoryAlreadyExists) {
+			t.Fatalf("se esperaba ErrCategoryAlreadyExists, se obtuvo %v", err)
+		}
+
+		found, err := repo.GetCategory(ctx, "categoria-1")
+		if err != nil {
+			t.Fatalf("GetCategory: %v", err)
+		}
+		if found.Name != "Bebidas" {
+			t.Fatalf("el update rechazado modificó la categoría: %q", found.Name)
+		}
+	})
+
+	t.Run("UpdateCategoryMissingReturnsErrCategoryNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.UpdateCategory(context.Background(), "no-existe", newCategory("no-existe", "Bebidas"))
+		if !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("se esperaba ErrCategoryNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("DeleteCategoryInUseReturnsErrCategoryInUse", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.DeleteCategory(ctx, "categoria-1"); !errors.Is(err, domain.ErrCategoryInUse) {
+			t.Fatalf("se esperaba ErrCategoryInUse, se obtuvo %v", err)
+		}
+
+		if err := repo.Delete(ctx, "item-1", 1); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := repo.DeleteCategory(ctx, "categoria-1"); err != nil {
+			t.Fatalf("DeleteCategory sin items: %v", err)
+		}
+		if _, err := repo.GetCategory(ctx, "categoria-1"); !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("se esperaba ErrCategoryNotFound tras borrar, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("DeleteCategoryMissingReturnsErrCategoryNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.DeleteCategory(context.Background(), "no-existe")
+		if !errors.Is(err, domain.ErrCategoryNotFound) {
+			t.Fatalf("se esperaba ErrCategoryNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("ConcurrentDeleteNeverLeavesItemsWithoutCategory", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				item := newItem(fmt.Sprintf("item-%d", i), fmt.Sprintf("Combo %d", i), i)
+				item.Category = "Promociones"
+				_, err := repo.Create(ctx, item)
+				if err != nil && !errors.Is(err, domain.ErrUnknownItemCategory) {
+					t.Errorf("Create: %v", err)
+				}
+			}(i)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.DeleteCategory(ctx, "categoria-3")
+			if err != nil && !errors.Is(err, domain.ErrCategoryInUse) {
+				t.Errorf("DeleteCategory: %v", err)
+			}
+		}()
+		wg.Wait()
+
+		page, err := repo.GetAll(ctx, normalized(t, domain.ItemQuery{Category: "Promociones"}))
+		if err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		_, err = repo.GetCategory(ctx, "categoria-3")
+		switch {
+		case errors.Is(err, domain.ErrCategoryNotFound):
+			if page.Total != 0 {
+				t.Fatalf("se borró la categoría pero quedaron %d items en ella", page.Total)
+			}
+		case err != nil:
+			t.Fatalf("GetCategory: %v", err)
+		}
+	})
+
+	t.Run("SummarizeCategories", func(t *testing.T) {
+		repo := withCategories(newRepository)(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		// Los items sin categoría no figuran en el resumen
+		uncategorized := newItem("suelto", "Suelto", 9)
+		uncategorized.Category = ""
+		if _, err := repo.Create(ctx, uncategorized); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		// Cada moneda se suma por separado
+		imported := newItem("cerveza", "Cerveza importada", 10)
+		imported.Price, imported.Stock = domain.NewMoney(350, "USD"), 4
+		if _, err := repo.Create(ctx, imported); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		summaries, err := repo.SummarizeCategories(ctx)
+		if err != nil {
+			t.Fatalf("SummarizeCategories: %v", err)
+		}
+		want := []domain.CategorySummary{
+			{CategoryID: "categoria-1", Name: "Bebidas", ItemCount: 4,
+				StockValues: []domain.Money{domain.NewMoney(1625000, "ARS"), domain.NewMoney(1400, "USD")}},
+			{CategoryID: "categoria-2", Name: "Golosinas", ItemCount: 2,
+				StockValues: []domain.Money{domain.NewMoney(270000, "ARS")}},
+			{CategoryID: "categoria-3", Name: "Promociones", StockValues: []domain.Money{}},
+		}
+		if len(summaries) != len(want) {
+			t.Fatalf("se obtuvieron %d resúmenes, se esperaban %d", len(summaries), len(want))
+		}
+		for i, summary := range summaries {
+			if summary.CategoryID != want[i].CategoryID || summary.Name != want[i].Name ||
+				summary.ItemCount != want[i].ItemCount || !slices.Equal(summary.StockValues, want[i].StockValues) {
+				t.Fatalf("resumen %d = %+v, se esperaba %+v", i, *summary, want[i])
+			}
+		}
+	})
+}
+
+// newCategory crea una categoría válida
+func newCategory(id, name string) *domain.Category {
+	return &domain.Category{
+		ID:          id,
+		Name:        name,
+		Description: "Descripción de " + name,
+		CreatedAt:   baseTime,
+		UpdatedAt:   baseTime,
+	}
+}
+
+// assertCategory compara dos categorías campo a campo
+func assertCategory(t *testing.T, got, want *domain.Category) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatal("se obtuvo una categoría nil")
+	}
+	if got.ID != want.ID || got.Name != want.Name || got.Description != want.Description {
+		t.Fatalf("categoría = %+v, se esperaba %+v", got, want)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("fechas = %v / %v, se esperaba %v / %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+}
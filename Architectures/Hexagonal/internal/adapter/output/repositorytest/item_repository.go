@@ -0,0 +1,662 @@
+// Package repositorytest contiene el contrato que todo adaptador de salida
+// que implemente domain.ItemRepository debe cumplir.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+// CatalogRepository es un adaptador que guarda los items y sus categorías
+type CatalogRepository interface {
+	domain.ItemRepository
+	domain.CategoryRepository
+}
+
+// NewRepository crea un repositorio vacío para un subtest
+type NewRepository func(t *testing.T) CatalogRepository
+
+// RunItemRepositoryTests ejecuta el contrato de domain.ItemRepository contra
+// los repositorios que crea newRepository
+func RunItemRepositoryTests(t *testing.T, newRepository NewRepository) {
+	newRepository = withCategories(newRepository)
+
+	t.Run("CreateAndGetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		created, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0))
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		assertItem(t, created, newItem("item-1", "Coca Cola", 0))
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		assertItem(t, found, newItem("item-1", "Coca Cola", 0))
+	})
+
+	t.Run("CreateDuplicateNameInCategoryReturnsErrItemAlreadyExists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		// El nombre se compara sin distinguir mayúsculas
+		_, err := repo.Create(ctx, newItem("item-2", "COCA COLA", 1))
+		if !errors.Is(err, domain.ErrItemAlreadyExists) {
+			t.Fatalf("se esperaba ErrItemAlreadyExists, se obtuvo %v", err)
+		}
+
+		// El mismo nombre en otra categoría está permitido
+		other := newItem("item-3", "Coca Cola", 2)
+		other.Category = "Promociones"
+		if _, err := repo.Create(ctx, other); err != nil {
+			t.Fatalf("Create en otra categoría: %v", err)
+		}
+	})
+
+	t.Run("UpdateToDuplicateNameReturnsErrItemAlreadyExists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		for _, item := range []*domain.Item{newItem("item-1", "Coca Cola", 0), newItem("item-2", "Pepsi", 1)} {
+			if _, err := repo.Create(ctx, item); err != nil {
+				t.Fatalf("Create(%q): %v", item.ID, err)
+			}
+		}
+
+		_, err := repo.Update(ctx, "item-2", newItem("item-2", "Coca Cola", 1))
+		if !errors.Is(err, domain.ErrItemAlreadyExists) {
+			t.Fatalf("se esperaba ErrItemAlreadyExists, se obtuvo %v", err)
+		}
+
+		found, err := repo.GetByID(ctx, "item-2")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Name != "Pepsi" {
+			t.Fatalf("el update rechazado modificó el item: %q", found.Name)
+		}
+	})
+
+	t.Run("CreateDuplicateReturnsErrItemAlreadyExists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		_, err := repo.Create(ctx, newItem("item-1", "Pepsi", 1))
+		if !errors.Is(err, domain.ErrItemAlreadyExists) {
+			t.Fatalf("se esperaba ErrItemAlreadyExists, se obtuvo %v", err)
+		}
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Name != "Coca Cola" {
+			t.Fatalf("el duplicado reemplazó al item original: %q", found.Name)
+		}
+	})
+
+	t.Run("GetByIDMissingReturnsErrItemNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.GetByID(context.Background(), "no-existe")
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("se esperaba ErrItemNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		page, err := repo.GetAll(ctx, normalized(t, domain.ItemQuery{}))
+		if err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		if len(page.Items) != 0 || page.Total != 0 || page.NextCursor != "" {
+			t.Fatalf("se esperaba una página vacía, se obtuvo %+v", page)
+		}
+
+		// Se crean en desorden: por defecto GetAll ordena por fecha de creación
+		for _, item := range []*domain.Item{newItem("b", "Chicle", 1), newItem("a", "Alfajor", 0), newItem("c", "Caramelo", 2)} {
+			if _, err := repo.Create(ctx, item); err != nil {
+				t.Fatalf("Create(%q): %v", item.ID, err)
+			}
+		}
+
+		page, err = repo.GetAll(ctx, normalized(t, domain.ItemQuery{}))
+		if err != nil {
+			t.Fatalf("GetAll: %v", err)
+		}
+		assertIDs(t, page, "a", "b", "c")
+		if page.Total != 3 || page.NextCursor != "" {
+			t.Fatalf("total = %d, cursor = %q; se esperaba 3 y sin cursor", page.Total, page.NextCursor)
+		}
+	})
+
+	t.Run("GetAllFilters", func(t *testing.T) {
+		repo := newRepository(t)
+		createCatalog(t, repo)
+
+		tests := []struct {
+			name  string
+			query domain.ItemQuery
+			want  []string
+		}{
+			{"categoría sin distinguir mayúsculas", domain.ItemQuery{Category: "bebidas"}, []string{"coca", "agua", "jugo"}},
+			{"nombre contiene", domain.ItemQuery{NameContains: "CO"}, []string{"coca", "chocolate"}},
+			{"precio mínimo", domain.ItemQuery{MinPrice: price(15000)}, []string{"coca", "chocolate"}},
+			{"rango de precios", domain.ItemQuery{MinPrice: price(8000), MaxPrice: price(12000)}, []string{"agua", "alfajor", "jugo"}},
+			{"solo con stock", domain.ItemQuery{InStockOnly: true}, []string{"coca", "alfajor", "chocolate", "jugo"}},
+			{"filtros combinados", domain.ItemQuery{Category: "Bebidas", InStockOnly: true, MaxPrice: price(15000)}, []string{"jugo"}},
+			{"sin resultados", domain.ItemQuery{Category: "Limpieza"}, nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				page, err := repo.GetAll(context.Background(), normalized(t, tt.query))
+				if err != nil {
+					t.Fatalf("GetAll: %v", err)
+				}
+				assertIDs(t, page, tt.want...)
+				if page.Total != len(tt.want) {
+					t.Fatalf("total = %d, se esperaba %d", page.Total, len(tt.want))
+				}
+			})
+		}
+	})
+
+	t.Run("GetAllSorts", func(t *testing.T) {
+		repo := newRepository(t)
+		createCatalog(t, repo)
+
+		tests := []struct {
+			sortBy     string
+			descending bool
+			want       []string
+		}{
+			{domain.SortByCreatedAt, false, []string{"coca", "agua", "alfajor", "chocolate", "jugo"}},
+			{domain.SortByCreatedAt, true, []string{"jugo", "chocolate", "alfajor", "agua", "coca"}},
+			{domain.SortByName, false, []string{"agua", "alfajor", "chocolate", "coca", "jugo"}},
+			// Los empates de precio y stock se resuelven por ID
+			{domain.SortByPrice, false, []string{"agua", "alfajor", "jugo", "coca", "chocolate"}},
+			{domain.SortByPrice, true, []string{"chocolate", "coca", "jugo", "alfajor", "agua"}},
+			{domain.SortByStock, false, []string{"agua", "chocolate", "alfajor", "jugo", "coca"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s desc=%v", tt.sortBy, tt.descending), func(t *testing.T) {
+				page, err := repo.GetAll(context.Background(), normalized(t, domain.ItemQuery{SortBy: tt.sortBy, Descending: tt.descending}))
+				if err != nil {
+					t.Fatalf("GetAll: %v", err)
+				}
+				assertIDs(t, page, tt.want...)
+			})
+		}
+	})
+
+	t.Run("GetAllPaginates", func(t *testing.T) {
+		repo := newRepository(t)
+		createCatalog(t, repo)
+		createImportedItems(t, repo)
+
+		for _, sortBy := range []string{domain.SortByCreatedAt, domain.SortByName, domain.SortByPrice, domain.SortByStock} {
+			for _, descending := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s desc=%v", sortBy, descending), func(t *testing.T) {
+					ctx := context.Background()
+					all, err := repo.GetAll(ctx, normalized(t, domain.ItemQuery{SortBy: sortBy, Descending: descending}))
+					if err != nil {
+						t.Fatalf("GetAll: %v", err)
+					}
+
+					var paged []string
+					query := domain.ItemQuery{SortBy: sortBy, Descending: descending, Limit: 2}
+					for pages := 0; ; pages++ {
+						if pages > len(all.Items) {
+							t.Fatal("la paginación no termina")
+						}
+						page, err := repo.GetAll(ctx, normalized(t, query))
+						if err != nil {
+							t.Fatalf("GetAll: %v", err)
+						}
+						if page.Total != len(all.Items) {
+							t.Fatalf("total = %d, se esperaba %d", page.Total, len(all.Items))
+						}
+						if len(page.Items) > 2 {
+							t.Fatalf("la página tiene %d items, el límite es 2", len(page.Items))
+						}
+						for _, item := range page.Items {
+							paged = append(paged, item.ID)
+						}
+						if page.NextCursor == "" {
+							break
+						}
+						query.Cursor = page.NextCursor
+					}
+
+					var want []string
+					for _, item := range all.Items {
+						want = append(want, item.ID)
+					}
+					if fmt.Sprint(paged) != fmt.Sprint(want) {
+						t.Fatalf("páginas = %v, se esperaba %v", paged, want)
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("PricesInSeveralCurrencies", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+		createImportedItems(t, repo)
+
+		found, err := repo.GetByID(ctx, "cerveza")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Price != domain.NewMoney(350, "USD") {
+			t.Fatalf("precio = %v, se esperaba 3.50 USD", found.Price)
+		}
+
+		dollars := domain.NewMoney(400, "USD")
+		tests := []struct {
+			name  string
+			query domain.ItemQuery
+			want  []string
+		}{
+			// El filtro de precio solo incluye los items en su moneda
+			{"precio mínimo en pesos", domain.ItemQuery{MinPrice: price(15000)}, []string{"coca", "chocolate"}},
+			{"precio mínimo en dólares", domain.ItemQuery{MinPrice: &dollars}, []string{"vino"}},
+			{"precio máximo en dólares", domain.ItemQuery{MaxPrice: &dollars}, []string{"cerveza"}},
+			// El orden por precio agrupa por moneda
+			{"orden por precio", domain.ItemQuery{SortBy: domain.SortByPrice},
+				[]string{"agua", "alfajor", "jugo", "coca", "chocolate", "cerveza", "vino"}},
+			{"orden por precio descendente", domain.ItemQuery{SortBy: domain.SortByPrice, Descending: true},
+				[]string{"vino", "cerveza", "chocolate", "coca", "jugo", "alfajor", "agua"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				page, err := repo.GetAll(ctx, normalized(t, tt.query))
+				if err != nil {
+					t.Fatalf("GetAll: %v", err)
+				}
+				assertIDs(t, page, tt.want...)
+			})
+		}
+	})
+
+	t.Run("GetAllInvalidCursorReturnsErrInvalidItemQuery", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.GetAll(context.Background(), normalized(t, domain.ItemQuery{Cursor: "no-es-un-cursor"}))
+		if !errors.Is(err, domain.ErrInvalidItemQuery) {
+			t.Fatalf("se esperaba ErrInvalidItemQuery, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		original := newItem("item-1", "Coca Cola", 0)
+		if _, err := repo.Create(ctx, original); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		changed := newItem("item-1", "Coca Cola 500ml", 0)
+		changed.Price = domain.NewMoney(18000, "ARS")
+		changed.Stock = 80
+		changed.UpdatedAt = original.UpdatedAt.Add(time.Hour)
+
+		updated, err := repo.Update(ctx, "item-1", changed)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		// El stock solo cambia con movimientos: Update conserva el guardado
+		changed.Stock = original.Stock
+		changed.Version = 2
+		assertItem(t, updated, changed)
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		assertItem(t, found, changed)
+	})
+
+	t.Run("UpdateMissingReturnsErrItemNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		_, err := repo.Update(context.Background(), "no-existe", newItem("no-existe", "Coca Cola", 0))
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("se esperaba ErrItemNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("UpdateWithStaleVersionReturnsErrVersionMismatch", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := repo.Update(ctx, "item-1", newItem("item-1", "Coca Cola 500ml", 0)); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		// Una segunda edición basada en la versión 1 pisaría la anterior
+		_, err := repo.Update(ctx, "item-1", newItem("item-1", "Coca Cola 1.5l", 0))
+		if !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Fatalf("se esperaba ErrVersionMismatch, se obtuvo %v", err)
+		}
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Name != "Coca Cola 500ml" || found.Version != 2 {
+			t.Fatalf("el update rechazado modificó el item: %q (versión %d)", found.Name, found.Version)
+		}
+	})
+
+	t.Run("ConcurrentUpdatesOfTheSameVersionOnlyOneWins", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		won, stale := 0, 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := repo.Update(ctx, "item-1", newItem("item-1", fmt.Sprintf("Coca Cola %d", i), 0))
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					won++
+				case errors.Is(err, domain.ErrVersionMismatch):
+					stale++
+				default:
+					t.Errorf("Update: %v", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if won != 1 || stale != 9 {
+			t.Fatalf("updates aplicados = %d, rechazados = %d; se esperaba 1 y 9", won, stale)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.Delete(ctx, "item-1", 2); !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Fatalf("Delete con otra versión: se esperaba ErrVersionMismatch, se obtuvo %v", err)
+		}
+		if err := repo.Delete(ctx, "item-1", 1); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		_, err := repo.GetByID(ctx, "item-1")
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("se esperaba ErrItemNotFound tras borrar, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("DeleteMissingReturnsErrItemNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		err := repo.Delete(context.Background(), "no-existe", 1)
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("se esperaba ErrItemNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("CreateWithUnknownCategoryReturnsErrUnknownItemCategory", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		item := newItem("item-1", "Coca Cola", 0)
+		item.Category = "Bebiads"
+		if _, err := repo.Create(ctx, item); !errors.Is(err, domain.ErrUnknownItemCategory) {
+			t.Fatalf("se esperaba ErrUnknownItemCategory, se obtuvo %v", err)
+		}
+		if _, err := repo.GetByID(ctx, "item-1"); !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("el item rechazado se guardó: %v", err)
+		}
+
+		// Un item sin categoría es válido
+		item.Category = ""
+		if _, err := repo.Create(ctx, item); err != nil {
+			t.Fatalf("Create sin categoría: %v", err)
+		}
+	})
+
+	t.Run("UpdateToUnknownCategoryReturnsErrUnknownItemCategory", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		changed := newItem("item-1", "Coca Cola", 0)
+		changed.Category = "Limpieza"
+		if _, err := repo.Update(ctx, "item-1", changed); !errors.Is(err, domain.ErrUnknownItemCategory) {
+			t.Fatalf("se esperaba ErrUnknownItemCategory, se obtuvo %v", err)
+		}
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Category != "Bebidas" || found.Version != 1 {
+			t.Fatalf("el update rechazado modificó el item: %q (versión %d)", found.Category, found.Version)
+		}
+	})
+
+	t.Run("CategoryIsStoredWithItsRegisteredName", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		item := newItem("item-1", "Coca Cola", 0)
+		item.Category = "BEBIDAS"
+		created, err := repo.Create(ctx, item)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if created.Category != "Bebidas" {
+			t.Fatalf("categoría = %q, se esperaba Bebidas", created.Category)
+		}
+
+		changed := newItem("item-1", "Coca Cola", 0)
+		changed.Category = "golosinas"
+		updated, err := repo.Update(ctx, "item-1", changed)
+		if err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if updated.Category != "Golosinas" {
+			t.Fatalf("categoría = %q, se esperaba Golosinas", updated.Category)
+		}
+	})
+
+	t.Run("ReturnedItemsAreCopies", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		item := newItem("item-1", "Coca Cola", 0)
+		created, err := repo.Create(ctx, item)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		item.Name = "modificado"
+		created.Name = "modificado"
+
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Name != "Coca Cola" {
+			t.Fatalf("modificar un item devuelto cambió el guardado: %q", found.Name)
+		}
+	})
+}
+
+// baseTime es la fecha de creación de los items del contrato; se usa una
+// precisión de segundos que todos los backends conservan
+var baseTime = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+// newItem crea un item válido en su primera versión; offset separa las
+// fechas de creación
+func newItem(id, name string, offset int) *domain.Item {
+	createdAt := baseTime.Add(time.Duration(offset) * time.Minute)
+	return &domain.Item{
+		ID:          id,
+		Name:        name,
+		Description: "Descripción de " + name,
+		Price:       domain.NewMoney(15050, "ARS"),
+		Stock:       100,
+		Category:    "Bebidas",
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+		Version:     1,
+	}
+}
+
+// categoryNames son las categorías con que empieza cada repositorio del contrato
+var categoryNames = []string{"Bebidas", "Golosinas", "Promociones"}
+
+// withCategories envuelve newRepository para que cada repositorio empiece con
+// las categorías que usan los items del contrato
+func withCategories[R domain.CategoryRepository](newRepository func(t *testing.T) R) func(t *testing.T) R {
+	return func(t *testing.T) R {
+		repo := newRepository(t)
+		for i, name := range categoryNames {
+			if _, err := repo.CreateCategory(context.Background(), newCategory(fmt.Sprintf("categoria-%d", i+1), name)); err != nil {
+				t.Fatalf("CreateCategory(%q): %v", name, err)
+			}
+		}
+		return repo
+	}
+}
+
+// createCatalog crea cinco items con precios y stocks repetidos para probar
+// filtros, orden y desempates
+func createCatalog(t *testing.T, repo domain.ItemRepository) {
+	t.Helper()
+
+	catalog := []struct {
+		id, name, category string
+		price              int64
+		stock              int
+	}{
+		{"coca", "Coca Cola", "Bebidas", 15050, 100},
+		{"agua", "Agua", "Bebidas", 8000, 0},
+		{"alfajor", "Alfajor", "Golosinas", 12000, 10},
+		{"chocolate", "Chocolate", "Golosinas", 30000, 5},
+		{"jugo", "Jugo", "Bebidas", 12000, 10},
+	}
+
+	for i, entry := range catalog {
+		item := newItem(entry.id, entry.name, i)
+		item.Category = entry.category
+		item.Price = domain.NewMoney(entry.price, "ARS")
+		item.Stock = entry.stock
+		if _, err := repo.Create(context.Background(), item); err != nil {
+			t.Fatalf("Create(%q): %v", entry.id, err)
+		}
+	}
+}
+
+// createImportedItems agrega al catálogo dos bebidas con precio en dólares
+func createImportedItems(t *testing.T, repo domain.ItemRepository) {
+	t.Helper()
+
+	for i, entry := range []struct {
+		id, name string
+		price    int64
+	}{
+		{"vino", "Vino importado", 1200},
+		{"cerveza", "Cerveza importada", 350},
+	} {
+		item := newItem(entry.id, entry.name, 10+i)
+		item.Price = domain.NewMoney(entry.price, "USD")
+		if _, err := repo.Create(context.Background(), item); err != nil {
+			t.Fatalf("Create(%q): %v", entry.id, err)
+		}
+	}
+}
+
+// normalized devuelve la consulta lista para pasar al repositorio
+func normalized(t *testing.T, query domain.ItemQuery) *domain.ItemQuery {
+	t.Helper()
+
+	if err := query.Normalize(); err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	return &query
+}
+
+// price devuelve un precio en pesos a partir de centavos
+func price(amount int64) *domain.Money {
+	value := domain.NewMoney(amount, "ARS")
+	return &value
+}
+
+// assertIDs compara los IDs de la página con los esperados, en orden
+func assertIDs(t *testing.T, page *domain.ItemPage, want ...string) {
+	t.Helper()
+
+	got := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		got[i] = item.ID
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("IDs = %v, se esperaba %v", got, want)
+	}
+}
+
+// assertItem compara dos items campo a campo
+func assertItem(t *testing.T, got, want *domain.Item) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatal("se obtuvo un item nil")
+	}
+	if got.ID != want.ID || got.Name != want.Name || got.Description != want.Description ||
+		got.Price != want.Price || got.Stock != want.Stock || got.Category != want.Category ||
+		got.Version != want.Version {
+		t.Fatalf("item = %+v, se esperaba %+v", got, want)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("fechas = %v / %v, se esperaba %v / %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+}
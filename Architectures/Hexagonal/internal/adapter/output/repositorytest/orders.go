@@ -0,0 +1,387 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+// OrderRepository es un adaptador que guarda los items, su libro de stock y
+// las órdenes que lo modifican
+type OrderRepository interface {
+	StockRepository
+	domain.OrderRepository
+}
+
+// NewOrderRepository crea un repositorio vacío para un subtest
+type NewOrderRepository func(t *testing.T) OrderRepository
+
+// RunOrderRepositoryTests ejecuta el contrato de domain.OrderRepository
+// contra los repositorios que crea newRepository
+func RunOrderRepositoryTests(t *testing.T, newRepository NewOrderRepository) {
+	newRepository = withCategories(newRepository)
+
+	t.Run("PlaceOrderDecrementsStockAndSnapshotsPrices", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		order := newOrder("orden-1", 0, orderLine("coca", 3), orderLine("alfajor", 2))
+		order.Discount = domain.NewMoney(1000, "ARS")
+		order.TaxRate = 0.1
+
+		placed, err := repo.PlaceOrder(ctx, order)
+		if err != nil {
+			t.Fatalf("PlaceOrder: %v", err)
+		}
+		want := []domain.OrderLine{
+			{ItemID: "coca", ItemName: "Coca Cola", Quantity: 3, UnitPrice: domain.NewMoney(15050, "ARS"), LineTotal: domain.NewMoney(45150, "ARS")},
+			{ItemID: "alfajor", ItemName: "Alfajor", Quantity: 2, UnitPrice: domain.NewMoney(12000, "ARS"), LineTotal: domain.NewMoney(24000, "ARS")},
+		}
+		assertOrder(t, placed, order, want)
+		if placed.Subtotal != domain.NewMoney(69150, "ARS") || placed.Taxes != domain.NewMoney(6815, "ARS") ||
+			placed.Total != domain.NewMoney(74965, "ARS") {
+			t.Fatalf("totales = %v / %v / %v, se esperaba 691.50 / 68.15 / 749.65 ARS", placed.Subtotal, placed.Taxes, placed.Total)
+		}
+
+		assertStock(t, repo, "coca", 97)
+		assertStock(t, repo, "alfajor", 8)
+
+		movements, err := repo.ListMovements(ctx, "coca")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		sale := movements[len(movements)-1]
+		if sale.Type != domain.MovementSale || sale.Quantity != 3 || sale.StockAfter != 97 ||
+			sale.Reason != "orden orden-1" || sale.Actor != order.Cashier || !sale.CreatedAt.Equal(order.CreatedAt) {
+			t.Fatalf("movimiento de venta = %+v", sale)
+		}
+
+		// La orden conserva el nombre y el precio del momento de la venta
+		coca, err := repo.GetByID(ctx, "coca")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		coca.Name, coca.Price = "Coca Cola Zero", domain.NewMoney(20000, "ARS")
+		if _, err := repo.Update(ctx, "coca", coca); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		found, err := repo.GetOrder(ctx, "orden-1")
+		if err != nil {
+			t.Fatalf("GetOrder: %v", err)
+		}
+		assertOrder(t, found, order, want)
+	})
+
+	t.Run("PlaceOrderRejectsWholeOrderWhenAnyLineFails", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		order := newOrder("orden-1", 0,
+			orderLine("coca", 1), orderLine("chocolate", 6), orderLine("no-existe", 1), orderLine("agua", 1))
+
+		_, err := repo.PlaceOrder(ctx, order)
+		var stockErr *domain.OrderStockError
+		if !errors.As(err, &stockErr) {
+			t.Fatalf("se esperaba *OrderStockError, se obtuvo %v", err)
+		}
+		want := []domain.OrderLineError{
+			{Line: 2, ItemID: "chocolate", Requested: 6, Available: 5, Reason: domain.ErrInsufficientStock.Error()},
+			{Line: 3, ItemID: "no-existe", Requested: 1, Reason: domain.ErrItemNotFound.Error()},
+			{Line: 4, ItemID: "agua", Requested: 1, Available: 0, Reason: domain.ErrInsufficientStock.Error()},
+		}
+		if fmt.Sprint(stockErr.Lines) != fmt.Sprint(want) {
+			t.Fatalf("líneas rechazadas = %+v, se esperaba %+v", stockErr.Lines, want)
+		}
+
+		// La línea que sí tenía stock tampoco se vendió
+		assertStock(t, repo, "coca", 100)
+		assertStock(t, repo, "chocolate", 5)
+		movements, err := repo.ListMovements(ctx, "coca")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if len(movements) != 1 {
+			t.Fatalf("la orden rechazada registró movimientos: %d", len(movements))
+		}
+		if _, err := repo.GetOrder(ctx, "orden-1"); !errors.Is(err, domain.ErrOrderNotFound) {
+			t.Fatalf("la orden rechazada quedó guardada: %v", err)
+		}
+	})
+
+	t.Run("DiscountAboveSubtotalReturnsErrInvalidOrderDiscount", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		order := newOrder("orden-1", 0, orderLine("alfajor", 1))
+		order.Discount = domain.NewMoney(12001, "ARS")
+
+		if _, err := repo.PlaceOrder(ctx, order); !errors.Is(err, domain.ErrInvalidOrderDiscount) {
+			t.Fatalf("se esperaba ErrInvalidOrderDiscount, se obtuvo %v", err)
+		}
+		assertStock(t, repo, "alfajor", 10)
+	})
+
+	t.Run("MixedCurrenciesReturnErrOrderCurrencyMismatch", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		imported := newItem("importado", "Chocolate importado", 5)
+		imported.Category = "Golosinas"
+		imported.Price = domain.NewMoney(450, "USD")
+		if _, err := repo.Create(ctx, imported); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		order := newOrder("orden-1", 0, orderLine("coca", 1), orderLine("importado", 1))
+		if _, err := repo.PlaceOrder(ctx, order); !errors.Is(err, domain.ErrOrderCurrencyMismatch) {
+			t.Fatalf("items en monedas distintas: se esperaba ErrOrderCurrencyMismatch, se obtuvo %v", err)
+		}
+		assertStock(t, repo, "coca", 100)
+		assertStock(t, repo, "importado", 100)
+
+		order = newOrder("orden-2", 1, orderLine("importado", 1))
+		order.Discount = domain.NewMoney(100, "ARS")
+		if _, err := repo.PlaceOrder(ctx, order); !errors.Is(err, domain.ErrOrderCurrencyMismatch) {
+			t.Fatalf("descuento en otra moneda: se esperaba ErrOrderCurrencyMismatch, se obtuvo %v", err)
+		}
+		assertStock(t, repo, "importado", 100)
+
+		// Una orden con items en una sola moneda se cobra en esa moneda
+		order = newOrder("orden-3", 2, orderLine("importado", 2))
+		order.Discount = domain.NewMoney(100, "USD")
+		if _, err := repo.PlaceOrder(ctx, order); err != nil {
+			t.Fatalf("PlaceOrder: %v", err)
+		}
+		found, err := repo.GetOrder(ctx, "orden-3")
+		if err != nil {
+			t.Fatalf("GetOrder: %v", err)
+		}
+		want := []domain.OrderLine{
+			{ItemID: "importado", ItemName: "Chocolate importado", Quantity: 2, UnitPrice: domain.NewMoney(450, "USD"), LineTotal: domain.NewMoney(900, "USD")},
+		}
+		assertOrder(t, found, order, want)
+		if found.Discount != domain.NewMoney(100, "USD") || found.Total != domain.NewMoney(800, "USD") {
+			t.Fatalf("descuento = %v, total = %v; se esperaba 1.00 USD y 8.00 USD", found.Discount, found.Total)
+		}
+	})
+
+	t.Run("DuplicateIDReturnsErrOrderAlreadyExists", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		if _, err := repo.PlaceOrder(ctx, newOrder("orden-1", 0, orderLine("coca", 1))); err != nil {
+			t.Fatalf("PlaceOrder: %v", err)
+		}
+		_, err := repo.PlaceOrder(ctx, newOrder("orden-1", 1, orderLine("coca", 1)))
+		if !errors.Is(err, domain.ErrOrderAlreadyExists) {
+			t.Fatalf("se esperaba ErrOrderAlreadyExists, se obtuvo %v", err)
+		}
+		assertStock(t, repo, "coca", 99)
+	})
+
+	t.Run("ListOrdersNewestFirst", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		for i, id := range []string{"orden-1", "orden-2", "orden-3"} {
+			if _, err := repo.PlaceOrder(ctx, newOrder(id, i, orderLine("coca", 1))); err != nil {
+				t.Fatalf("PlaceOrder(%q): %v", id, err)
+			}
+		}
+		if _, err := repo.VoidOrder(ctx, "orden-2", "encargado", "error de carga", baseTime.Add(2*time.Hour)); err != nil {
+			t.Fatalf("VoidOrder: %v", err)
+		}
+
+		tests := []struct {
+			query domain.OrderQuery
+			want  []string
+		}{
+			{domain.OrderQuery{}, []string{"orden-3", "orden-2", "orden-1"}},
+			{domain.OrderQuery{Status: domain.OrderCompleted}, []string{"orden-3", "orden-1"}},
+			{domain.OrderQuery{Status: domain.OrderVoided}, []string{"orden-2"}},
+			{domain.OrderQuery{Limit: 2}, []string{"orden-3", "orden-2"}},
+		}
+		for _, tt := range tests {
+			query := tt.query
+			if err := query.Normalize(); err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+			orders, err := repo.ListOrders(ctx, &query)
+			if err != nil {
+				t.Fatalf("ListOrders(%+v): %v", tt.query, err)
+			}
+			ids := make([]string, len(orders))
+			for i, order := range orders {
+				ids[i] = order.ID
+				if len(order.Lines) != 1 {
+					t.Fatalf("la orden %q se listó con %d líneas", order.ID, len(order.Lines))
+				}
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Fatalf("ListOrders(%+v) = %v, se esperaba %v", tt.query, ids, tt.want)
+			}
+		}
+	})
+
+	t.Run("VoidOrderRestocksLines", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		order := newOrder("orden-1", 0, orderLine("coca", 3), orderLine("alfajor", 2))
+		if _, err := repo.PlaceOrder(ctx, order); err != nil {
+			t.Fatalf("PlaceOrder: %v", err)
+		}
+		// Las líneas de un item borrado no se devuelven, pero la anulación sigue
+		if err := repo.Delete(ctx, "alfajor", 2); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		voidedAt := baseTime.Add(2 * time.Hour)
+		voided, err := repo.VoidOrder(ctx, "orden-1", "encargado", "el cliente devolvió todo", voidedAt)
+		if err != nil {
+			t.Fatalf("VoidOrder: %v", err)
+		}
+		if voided.Status != domain.OrderVoided || voided.VoidedBy != "encargado" ||
+			voided.VoidReason != "el cliente devolvió todo" || voided.VoidedAt == nil || !voided.VoidedAt.Equal(voidedAt) {
+			t.Fatalf("orden anulada = %+v", voided)
+		}
+		if len(voided.Lines) != 2 || voided.Total != domain.NewMoney(69150, "ARS") {
+			t.Fatalf("la anulación cambió las líneas o el total: %+v", voided)
+		}
+
+		assertStock(t, repo, "coca", 100)
+		movements, err := repo.ListMovements(ctx, "coca")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		refund := movements[len(movements)-1]
+		if refund.Type != domain.MovementReturn || refund.Quantity != 3 || refund.StockAfter != 100 ||
+			refund.Reason != "anulación de la orden orden-1" || refund.Actor != "encargado" || !refund.CreatedAt.Equal(voidedAt) {
+			t.Fatalf("movimiento de devolución = %+v", refund)
+		}
+
+		found, err := repo.GetOrder(ctx, "orden-1")
+		if err != nil {
+			t.Fatalf("GetOrder: %v", err)
+		}
+		if found.Status != domain.OrderVoided || found.VoidedAt == nil || !found.VoidedAt.Equal(voidedAt) {
+			t.Fatalf("la orden guardada no quedó anulada: %+v", found)
+		}
+
+		_, err = repo.VoidOrder(ctx, "orden-1", "encargado", "otra vez", voidedAt)
+		if !errors.Is(err, domain.ErrOrderAlreadyVoided) {
+			t.Fatalf("se esperaba ErrOrderAlreadyVoided, se obtuvo %v", err)
+		}
+		assertStock(t, repo, "coca", 100)
+	})
+
+	t.Run("MissingOrderReturnsErrOrderNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.GetOrder(ctx, "no-existe"); !errors.Is(err, domain.ErrOrderNotFound) {
+			t.Fatalf("GetOrder: se esperaba ErrOrderNotFound, se obtuvo %v", err)
+		}
+		_, err := repo.VoidOrder(ctx, "no-existe", "encargado", "prueba", baseTime)
+		if !errors.Is(err, domain.ErrOrderNotFound) {
+			t.Fatalf("VoidOrder: se esperaba ErrOrderNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("ConcurrentOrdersNeverOversell", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+		createCatalog(t, repo)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		placed, short := 0, 0
+		for i := 0; i < 15; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// Los items en distinto orden también prueban que no hay deadlocks
+				lines := []domain.OrderLine{orderLine("alfajor", 1), orderLine("jugo", 1)}
+				if i%2 == 1 {
+					lines[0], lines[1] = lines[1], lines[0]
+				}
+				_, err := repo.PlaceOrder(ctx, newOrder(fmt.Sprintf("orden-%d", i), i, lines...))
+
+				var stockErr *domain.OrderStockError
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					placed++
+				case errors.As(err, &stockErr):
+					short++
+				default:
+					t.Errorf("PlaceOrder: %v", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if placed != 10 || short != 5 {
+			t.Fatalf("órdenes = %d, rechazadas = %d; se esperaba 10 y 5", placed, short)
+		}
+		assertStock(t, repo, "alfajor", 0)
+		assertStock(t, repo, "jugo", 0)
+	})
+}
+
+// newOrder crea una orden lista para PlaceOrder, como la prepara el servicio;
+// offset separa las fechas de creación
+func newOrder(id string, offset int, lines ...domain.OrderLine) *domain.Order {
+	return &domain.Order{
+		ID:            id,
+		Status:        domain.OrderCompleted,
+		Lines:         lines,
+		PaymentMethod: domain.PaymentCash,
+		Cashier:       "cajero-1",
+		CreatedAt:     baseTime.Add(time.Hour + time.Duration(offset)*time.Minute),
+	}
+}
+
+func orderLine(itemID string, quantity int) domain.OrderLine {
+	return domain.OrderLine{ItemID: itemID, Quantity: quantity}
+}
+
+// assertOrder compara la orden guardada con la enviada y las líneas esperadas
+func assertOrder(t *testing.T, got, sent *domain.Order, lines []domain.OrderLine) {
+	t.Helper()
+
+	if got.ID != sent.ID || got.Status != domain.OrderCompleted || got.PaymentMethod != sent.PaymentMethod ||
+		got.Cashier != sent.Cashier || got.Discount.Amount != sent.Discount.Amount || got.TaxRate != sent.TaxRate ||
+		!got.CreatedAt.Equal(sent.CreatedAt) || got.VoidedAt != nil {
+		t.Fatalf("orden = %+v, se esperaba %+v", got, sent)
+	}
+	if fmt.Sprint(got.Lines) != fmt.Sprint(lines) {
+		t.Fatalf("líneas = %+v, se esperaba %+v", got.Lines, lines)
+	}
+}
+
+// assertStock verifica el stock actual de un item
+func assertStock(t *testing.T, repo domain.ItemRepository, id string, want int) {
+	t.Helper()
+
+	item, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID(%q): %v", id, err)
+	}
+	if item.Stock != want {
+		t.Fatalf("stock de %q = %d, se esperaba %d", id, item.Stock, want)
+	}
+}
@@ -0,0 +1,257 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"kiosco/internal/domain"
+	"sync"
+	"testing"
+	"time"
+)
+
+// StockRepository es un adaptador que guarda los items y su libro de stock
+type StockRepository interface {
+	CatalogRepository
+	domain.StockMovementRepository
+}
+
+// NewStockRepository crea un repositorio vacío para un subtest
+type NewStockRepository func(t *testing.T) StockRepository
+
+// RunStockMovementRepositoryTests ejecuta el contrato de
+// domain.StockMovementRepository contra los repositorios que crea newRepository
+func RunStockMovementRepositoryTests(t *testing.T, newRepository NewStockRepository) {
+	newRepository = withCategories(newRepository)
+
+	t.Run("CreateRecordsOpeningMovement", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		empty := newItem("item-2", "Pepsi", 1)
+		empty.Stock = 0
+		if _, err := repo.Create(ctx, empty); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		movements, err := repo.ListMovements(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if len(movements) != 1 {
+			t.Fatalf("se esperaba el movimiento inicial, se obtuvieron %d movimientos", len(movements))
+		}
+		opening := movements[0]
+		if opening.Type != domain.MovementAdjustment || opening.Quantity != 100 || opening.StockAfter != 100 ||
+			opening.Reason != domain.OpeningStockReason || opening.Actor != domain.SystemActor {
+			t.Fatalf("movimiento inicial = %+v", opening)
+		}
+
+		movements, err = repo.ListMovements(ctx, "item-2")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if len(movements) != 0 {
+			t.Fatalf("un item sin stock no tiene movimientos, se obtuvieron %d", len(movements))
+		}
+	})
+
+	t.Run("RecordMovementUpdatesStock", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		steps := []struct {
+			movement   *domain.StockMovement
+			stockAfter int
+		}{
+			{newMovement("item-1", domain.MovementSale, 30), 70},
+			{newMovement("item-1", domain.MovementReturn, 2), 72},
+			{newMovement("item-1", domain.MovementRestock, 28), 100},
+			{newMovement("item-1", domain.MovementAdjustment, -5), 95},
+		}
+
+		var lastID int64
+		for _, step := range steps {
+			recorded, err := repo.RecordMovement(ctx, step.movement)
+			if err != nil {
+				t.Fatalf("RecordMovement(%s): %v", step.movement.Type, err)
+			}
+			if recorded.StockAfter != step.stockAfter {
+				t.Fatalf("%s: stock_after = %d, se esperaba %d", step.movement.Type, recorded.StockAfter, step.stockAfter)
+			}
+			if recorded.ID <= lastID {
+				t.Fatalf("%s: ID %d no es mayor que el anterior %d", step.movement.Type, recorded.ID, lastID)
+			}
+			if recorded.ItemID != "item-1" || recorded.Quantity != step.movement.Quantity ||
+				recorded.Reason != step.movement.Reason || recorded.Actor != step.movement.Actor ||
+				!recorded.CreatedAt.Equal(step.movement.CreatedAt) {
+				t.Fatalf("movimiento registrado = %+v, se esperaba %+v", recorded, step.movement)
+			}
+			lastID = recorded.ID
+		}
+
+		item, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if item.Stock != 95 {
+			t.Fatalf("stock = %d, se esperaba 95", item.Stock)
+		}
+		// Cada movimiento cambia el item, y con él su versión
+		if item.Version != 5 {
+			t.Fatalf("versión = %d, se esperaba 5", item.Version)
+		}
+
+		movements, err := repo.ListMovements(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if len(movements) != 5 {
+			t.Fatalf("se esperaban 5 movimientos, se obtuvieron %d", len(movements))
+		}
+		for i, step := range steps {
+			if movements[i+1].Type != step.movement.Type || movements[i+1].StockAfter != step.stockAfter {
+				t.Fatalf("movimiento %d = %+v", i+1, movements[i+1])
+			}
+		}
+	})
+
+	t.Run("RecordMovementRejectsNegativeStock", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		_, err := repo.RecordMovement(ctx, newMovement("item-1", domain.MovementSale, 101))
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("se esperaba ErrInsufficientStock, se obtuvo %v", err)
+		}
+		_, err = repo.RecordMovement(ctx, newMovement("item-1", domain.MovementAdjustment, -101))
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			t.Fatalf("se esperaba ErrInsufficientStock, se obtuvo %v", err)
+		}
+
+		item, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		movements, err := repo.ListMovements(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if item.Stock != 100 || len(movements) != 1 {
+			t.Fatalf("un movimiento rechazado cambió el stock (%d) o quedó registrado (%d movimientos)", item.Stock, len(movements))
+		}
+
+		// Vender todo el stock está permitido
+		recorded, err := repo.RecordMovement(ctx, newMovement("item-1", domain.MovementSale, 100))
+		if err != nil {
+			t.Fatalf("RecordMovement: %v", err)
+		}
+		if recorded.StockAfter != 0 {
+			t.Fatalf("stock_after = %d, se esperaba 0", recorded.StockAfter)
+		}
+	})
+
+	t.Run("MissingItemReturnsErrItemNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		_, err := repo.RecordMovement(ctx, newMovement("no-existe", domain.MovementRestock, 1))
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("RecordMovement: se esperaba ErrItemNotFound, se obtuvo %v", err)
+		}
+		_, err = repo.ListMovements(ctx, "no-existe")
+		if !errors.Is(err, domain.ErrItemNotFound) {
+			t.Fatalf("ListMovements: se esperaba ErrItemNotFound, se obtuvo %v", err)
+		}
+	})
+
+	t.Run("ConcurrentSalesNeverOversell", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		item := newItem("item-1", "Coca Cola", 0)
+		item.Stock = 10
+		if _, err := repo.Create(ctx, item); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		sold, short := 0, 0
+		for i := 0; i < 25; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.RecordMovement(ctx, newMovement("item-1", domain.MovementSale, 1))
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err == nil:
+					sold++
+				case errors.Is(err, domain.ErrInsufficientStock):
+					short++
+				default:
+					t.Errorf("RecordMovement: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if sold != 10 || short != 15 {
+			t.Fatalf("ventas = %d, rechazadas = %d; se esperaba 10 y 15", sold, short)
+		}
+		found, err := repo.GetByID(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if found.Stock != 0 {
+			t.Fatalf("stock = %d, se esperaba 0", found.Stock)
+		}
+	})
+
+	t.Run("DeleteRemovesMovements", func(t *testing.T) {
+		repo := newRepository(t)
+		ctx := context.Background()
+
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.Delete(ctx, "item-1", 1); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		// Un item nuevo con el mismo ID empieza con un libro vacío
+		if _, err := repo.Create(ctx, newItem("item-1", "Coca Cola", 0)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		movements, err := repo.ListMovements(ctx, "item-1")
+		if err != nil {
+			t.Fatalf("ListMovements: %v", err)
+		}
+		if len(movements) != 1 {
+			t.Fatalf("se esperaba solo el movimiento inicial, se obtuvieron %d", len(movements))
+		}
+	})
+}
+
+// newMovement crea un movimiento válido del tipo indicado
+func newMovement(itemID, movementType string, quantity int) *domain.StockMovement {
+	return &domain.StockMovement{
+		ItemID:    itemID,
+		Type:      movementType,
+		Quantity:  quantity,
+		Reason:    "contrato",
+		Actor:     "cajero-1",
+		CreatedAt: baseTime.Add(time.Hour),
+	}
+}
@@ -0,0 +1,220 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// categoryColumns son las columnas de categories en el orden en que las lee scanCategory
+const categoryColumns = "id, name, description, created_at, updated_at"
+
+// ListCategories obtiene todas las categorías ordenadas por nombre
+func (r *SQLiteItemRepository) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+categoryColumns+" FROM categories ORDER BY name, id")
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar categorías: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*domain.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer categorías: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer categorías: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategory obtiene una categoría por su ID
+func (r *SQLiteItemRepository) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	category, err := scanCategory(r.db.QueryRowContext(ctx, "SELECT "+categoryColumns+" FROM categories WHERE id = ?", id))
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return category, nil
+}
+
+// CreateCategory inserta una nueva categoría
+func (r *SQLiteItemRepository) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	created, err := scanCategory(r.db.QueryRowContext(ctx,
+		`INSERT INTO categories (id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING `+categoryColumns,
+		category.ID, category.Name, category.Description, formatTime(category.CreatedAt), formatTime(category.UpdatedAt)))
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return created, nil
+}
+
+// UpdateCategory actualiza una categoría existente y, si cambia el nombre,
+// la de sus items, en una misma transacción
+func (r *SQLiteItemRepository) UpdateCategory(ctx context.Context, id string, category *domain.Category) (*domain.Category, error) {
+	var updated *domain.Category
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var previousName string
+		if err := tx.QueryRowContext(ctx, "SELECT name FROM categories WHERE id = ?", id).Scan(&previousName); err != nil {
+			return err
+		}
+
+		row := tx.QueryRowContext(ctx,
+			`UPDATE categories
+			SET name = ?, description = ?, created_at = ?, updated_at = ?
+			WHERE id = ?
+			RETURNING `+categoryColumns,
+			category.Name, category.Description, formatTime(category.CreatedAt), formatTime(category.UpdatedAt), id)
+
+		var err error
+		if updated, err = scanCategory(row); err != nil {
+			return err
+		}
+
+		if updated.Name != previousName {
+			_, err = tx.ExecContext(ctx,
+				"UPDATE items SET category = ?, updated_at = ?, version = version + 1 WHERE category = ?",
+				updated.Name, formatTime(updated.UpdatedAt), previousName)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapCategoryError(err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCategory elimina una categoría si ningún item la referencia
+func (r *SQLiteItemRepository) DeleteCategory(ctx context.Context, id string) error {
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var name string
+		if err := tx.QueryRowContext(ctx, "SELECT name FROM categories WHERE id = ?", id).Scan(&name); err != nil {
+			return err
+		}
+
+		var inUse bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM items WHERE category = ?)", name).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrCategoryInUse
+		}
+
+		_, err := tx.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id)
+		return err
+	})
+	if err != nil {
+		return mapCategoryError(err)
+	}
+
+	return nil
+}
+
+// SummarizeCategories cuenta los items de cada categoría y suma el valor de
+// su stock en cada moneda
+func (r *SQLiteItemRepository) SummarizeCategories(ctx context.Context) ([]*domain.CategorySummary, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT c.id, c.name, i.currency, COUNT(i.id), COALESCE(SUM(i.price * i.stock), 0)
+		FROM categories c
+		LEFT JOIN items i ON i.category = c.name
+		GROUP BY c.id, c.name, i.currency
+		ORDER BY c.name, c.id, i.currency`)
+	if err != nil {
+		return nil, fmt.Errorf("error al resumir categorías: %w", err)
+	}
+	defer rows.Close()
+
+	// Hay una fila por categoría y moneda; una categoría sin items tiene una
+	// sola fila, sin moneda
+	summaries := []*domain.CategorySummary{}
+	for rows.Next() {
+		var id, name string
+		var currency sql.NullString
+		var count int
+		var value int64
+		if err := rows.Scan(&id, &name, &currency, &count, &value); err != nil {
+			return nil, fmt.Errorf("error al leer el resumen de categorías: %w", err)
+		}
+		if len(summaries) == 0 || summaries[len(summaries)-1].CategoryID != id {
+			summaries = append(summaries, &domain.CategorySummary{CategoryID: id, Name: name, StockValues: []domain.Money{}})
+		}
+		summary := summaries[len(summaries)-1]
+		summary.ItemCount += count
+		if currency.Valid {
+			summary.AddStockValue(domain.NewMoney(value, currency.String))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer el resumen de categorías: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// categoryName devuelve el nombre con que está registrada la categoría,
+// comparando sin distinguir mayúsculas. Un item sin categoría es válido.
+func categoryName(ctx context.Context, tx *sql.Tx, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	err := tx.QueryRowContext(ctx, "SELECT name FROM categories WHERE lower(name) = lower(?)", name).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrUnknownItemCategory
+	}
+	return name, err
+}
+
+// scanCategory lee una fila con las columnas de categoryColumns
+func scanCategory(row rowScanner) (*domain.Category, error) {
+	var category domain.Category
+	var createdAt, updatedAt string
+	if err := row.Scan(&category.ID, &category.Name, &category.Description, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if category.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, fmt.Errorf("created_at inválido: %w", err)
+	}
+	if category.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+		return nil, fmt.Errorf("updated_at inválido: %w", err)
+	}
+
+	return &category, nil
+}
+
+// mapCategoryError traduce los errores de SQLite a errores del dominio de
+// las categorías
+func mapCategoryError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrCategoryNotFound
+	}
+	if errors.Is(err, domain.ErrCategoryInUse) {
+		return err
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return domain.ErrCategoryAlreadyExists
+		}
+	}
+
+	return fmt.Errorf("error en la base de datos: %w", err)
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// migrationFiles contiene las migraciones versionadas, nombradas NNNN_descripcion.sql
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Open abre la base de datos SQLite del archivo indicado y aplica las
+// migraciones pendientes. Con path ":memory:" la base vive solo en memoria.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if path != ":memory:" {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error al abrir la base de datos SQLite: %w", err)
+	}
+
+	// SQLite admite un único escritor; una sola conexión evita errores
+	// SQLITE_BUSY y mantiene viva la base en memoria
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// withTx ejecuta fn en una transacción que se confirma si fn no devuelve error
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrate aplica en una transacción las migraciones que todavía no figuran
+// en la tabla schema_migrations
+func migrate(ctx context.Context, db *sql.DB) error {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return err
+	}
+	versions := make(map[int]string, len(entries))
+	order := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		version, err := strconv.Atoi(prefix)
+		if !found || err != nil {
+			return fmt.Errorf("nombre de migración inválido %q: se espera NNNN_descripcion.sql", entry.Name())
+		}
+		if other, exists := versions[version]; exists {
+			return fmt.Errorf("las migraciones %s y %s tienen la misma versión", other, entry.Name())
+		}
+		versions[version] = entry.Name()
+		order = append(order, version)
+	}
+	sort.Ints(order)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar las migraciones: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("error al crear schema_migrations: %w", err)
+	}
+
+	for _, version := range order {
+		var applied int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = ?", version).Scan(&applied); err != nil {
+			return fmt.Errorf("error al leer schema_migrations: %w", err)
+		}
+		if applied > 0 {
+			continue
+		}
+
+		name := versions[version]
+		script, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("error en la migración %s: %w", name, err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", version, name); err != nil {
+			return fmt.Errorf("error al registrar la migración %s: %w", name, err)
+		}
+	}
+
+	return tx.Commit()
+}
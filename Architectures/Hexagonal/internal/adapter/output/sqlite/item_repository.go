@@ -0,0 +1,326 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// timeLayout tiene ancho fijo para que el orden de texto coincida con el
+// orden cronológico
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// itemColumns son las columnas de items en el orden en que las lee scanItem
+const itemColumns = "id, name, description, price, currency, stock, category, created_at, updated_at, version"
+
+// SQLiteItemRepository es el adaptador de salida que persiste los items en
+// una base SQLite embebida
+type SQLiteItemRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteItemRepository crea una nueva instancia del repositorio SQLite
+func NewSQLiteItemRepository(db *sql.DB) *SQLiteItemRepository {
+	return &SQLiteItemRepository{
+		db: db,
+	}
+}
+
+// GetAll obtiene una página de los items que cumplen la consulta
+func (r *SQLiteItemRepository) GetAll(ctx context.Context, query *domain.ItemQuery) (*domain.ItemPage, error) {
+	after, err := query.After()
+	if err != nil {
+		return nil, err
+	}
+	where, args := itemFilters(query)
+
+	page := &domain.ItemPage{Items: []*domain.Item{}}
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM items"+where, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("error al contar items: %w", err)
+	}
+
+	columns, direction := append(sortColumns(query), "id"), "ASC"
+	comparison := ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+		where = appendCondition(where, fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), comparison, placeholders))
+		args = append(append(args, cursorValues(query.SortBy, after)...), after.ID)
+	}
+	args = append(args, query.Limit+1)
+
+	ordering := make([]string, len(columns))
+	for i, column := range columns {
+		ordering[i] = column + " " + direction
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT %s FROM items%s ORDER BY %s LIMIT ?", itemColumns, where, strings.Join(ordering, ", ")),
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer items: %w", err)
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer items: %w", err)
+	}
+
+	// Se pidió un item de más para saber si hay otra página
+	if len(page.Items) > query.Limit {
+		page.Items = page.Items[:query.Limit]
+		page.NextCursor = query.NextCursor(page.Items[query.Limit-1])
+	}
+
+	return page, nil
+}
+
+// GetByID obtiene un item por su ID
+func (r *SQLiteItemRepository) GetByID(ctx context.Context, id string) (*domain.Item, error) {
+	item, err := scanItem(r.db.QueryRowContext(ctx, "SELECT "+itemColumns+" FROM items WHERE id = ?", id))
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return item, nil
+}
+
+// Create inserta un nuevo item y el movimiento con su stock inicial
+func (r *SQLiteItemRepository) Create(ctx context.Context, item *domain.Item) (*domain.Item, error) {
+	var createdItem *domain.Item
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		category, err := categoryName(ctx, tx, item.Category)
+		if err != nil {
+			return err
+		}
+
+		row := tx.QueryRowContext(ctx,
+			`INSERT INTO items (id, name, description, price, currency, stock, category, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING `+itemColumns,
+			item.ID, item.Name, item.Description, item.Price.Amount, item.Price.Currency, item.Stock, category,
+			formatTime(item.CreatedAt), formatTime(item.UpdatedAt))
+
+		if createdItem, err = scanItem(row); err != nil {
+			return err
+		}
+
+		if createdItem.Stock > 0 {
+			_, err = insertMovement(ctx, tx, domain.NewOpeningMovement(createdItem))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return createdItem, nil
+}
+
+// Update actualiza un item existente si su versión no cambió; el stock solo
+// cambia con movimientos
+func (r *SQLiteItemRepository) Update(ctx context.Context, id string, item *domain.Item) (*domain.Item, error) {
+	var updatedItem *domain.Item
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		category, err := categoryName(ctx, tx, item.Category)
+		if err != nil {
+			return err
+		}
+
+		row := tx.QueryRowContext(ctx,
+			`UPDATE items
+			SET name = ?, description = ?, price = ?, currency = ?, category = ?, created_at = ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND version = ?
+			RETURNING `+itemColumns,
+			item.Name, item.Description, item.Price.Amount, item.Price.Currency, category,
+			formatTime(item.CreatedAt), formatTime(item.UpdatedAt), id, item.Version)
+
+		updatedItem, err = scanItem(row)
+		if errors.Is(err, sql.ErrNoRows) {
+			return itemMissingOrStale(ctx, tx, id)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return updatedItem, nil
+}
+
+// Delete elimina un item por su ID si su versión no cambió
+func (r *SQLiteItemRepository) Delete(ctx context.Context, id string, version int64) error {
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM items WHERE id = ? AND version = ?", id, version)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return itemMissingOrStale(ctx, tx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// itemMissingOrStale explica por qué una escritura condicionada a la versión
+// no encontró el item
+func itemMissingOrStale(ctx context.Context, tx *sql.Tx, id string) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, "SELECT 1 FROM items WHERE id = ?", id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrVersionMismatch
+}
+
+// itemFilters arma la cláusula WHERE con los filtros de la consulta
+func itemFilters(query *domain.ItemQuery) (string, []any) {
+	where := ""
+	var args []any
+
+	if query.Category != "" {
+		where = appendCondition(where, "lower(category) = lower(?)")
+		args = append(args, query.Category)
+	}
+	if query.NameContains != "" {
+		where = appendCondition(where, "instr(lower(name), lower(?)) > 0")
+		args = append(args, query.NameContains)
+	}
+	if query.MinPrice != nil {
+		where = appendCondition(where, "currency = ? AND price >= ?")
+		args = append(args, query.MinPrice.Currency, query.MinPrice.Amount)
+	}
+	if query.MaxPrice != nil {
+		where = appendCondition(where, "currency = ? AND price <= ?")
+		args = append(args, query.MaxPrice.Currency, query.MaxPrice.Amount)
+	}
+	if query.InStockOnly {
+		where = appendCondition(where, "stock > 0")
+	}
+
+	return where, args
+}
+
+func appendCondition(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// sortColumns devuelve las columnas por las que ordena la consulta; el
+// precio se ordena por moneda y después por importe
+func sortColumns(query *domain.ItemQuery) []string {
+	switch query.SortBy {
+	case domain.SortByName:
+		return []string{"name"}
+	case domain.SortByPrice:
+		return []string{"currency", "price"}
+	case domain.SortByStock:
+		return []string{"stock"}
+	default:
+		return []string{"created_at"}
+	}
+}
+
+// cursorValues devuelve los valores de las columnas de orden guardados en el cursor
+func cursorValues(sortBy string, after *domain.ItemCursor) []any {
+	switch sortBy {
+	case domain.SortByName:
+		return []any{after.Name}
+	case domain.SortByPrice:
+		return []any{after.Price.Currency, after.Price.Amount}
+	case domain.SortByStock:
+		return []any{after.Stock}
+	default:
+		return []any{formatTime(after.CreatedAt)}
+	}
+}
+
+// rowScanner es la parte común de *sql.Row y *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanItem lee una fila con las columnas de itemColumns
+func scanItem(row rowScanner) (*domain.Item, error) {
+	var item domain.Item
+	var createdAt, updatedAt string
+	err := row.Scan(&item.ID, &item.Name, &item.Description, &item.Price.Amount, &item.Price.Currency, &item.Stock,
+		&item.Category, &createdAt, &updatedAt, &item.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, fmt.Errorf("created_at inválido: %w", err)
+	}
+	if item.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+		return nil, fmt.Errorf("updated_at inválido: %w", err)
+	}
+
+	return &item, nil
+}
+
+// formatTime guarda las fechas en UTC con timeLayout
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// mapError traduce los errores de SQLite a errores del dominio
+func mapError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+
+	// Los errores del dominio devueltos dentro de una transacción pasan sin cambios
+	var stockErr *domain.OrderStockError
+	switch {
+	case errors.Is(err, domain.ErrItemNotFound), errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrVersionMismatch), errors.Is(err, domain.ErrUnknownItemCategory),
+		errors.Is(err, domain.ErrOrderNotFound), errors.Is(err, domain.ErrOrderAlreadyExists),
+		errors.Is(err, domain.ErrOrderAlreadyVoided), errors.Is(err, domain.ErrInvalidOrderDiscount),
+		errors.Is(err, domain.ErrOrderCurrencyMismatch), errors.As(err, &stockErr):
+		return err
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY, sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return domain.ErrItemAlreadyExists
+		}
+	}
+
+	return fmt.Errorf("error en la base de datos: %w", err)
+}
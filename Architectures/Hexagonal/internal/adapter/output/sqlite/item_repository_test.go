@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"kiosco/internal/adapter/output/repositorytest"
+	"kiosco/internal/domain"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteItemRepository(t *testing.T) {
+	repositorytest.RunItemRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newTestRepository(t)
+	})
+}
+
+func TestSQLiteCategoryRepository(t *testing.T) {
+	repositorytest.RunCategoryRepositoryTests(t, func(t *testing.T) repositorytest.CatalogRepository {
+		return newTestRepository(t)
+	})
+}
+
+func TestSQLiteStockMovementRepository(t *testing.T) {
+	repositorytest.RunStockMovementRepositoryTests(t, func(t *testing.T) repositorytest.StockRepository {
+		return newTestRepository(t)
+	})
+}
+
+func TestSQLiteOrderRepository(t *testing.T) {
+	repositorytest.RunOrderRepositoryTests(t, func(t *testing.T) repositorytest.OrderRepository {
+		return newTestRepository(t)
+	})
+}
+
+// newTestRepository crea un repositorio sobre una base en memoria
+func newTestRepository(t *testing.T) *SQLiteItemRepository {
+	db, err := Open(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewSQLiteItemRepository(db)
+}
+
+func TestOpenIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kiosco.db")
+	ctx := context.Background()
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := NewSQLiteItemRepository(db).Create(ctx, &domain.Item{ID: "item-1", Name: "Alfajor"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	db.Close()
+
+	// Reabrir no debe volver a aplicar migraciones ni perder datos
+	db, err = Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open al reabrir: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := NewSQLiteItemRepository(db).GetByID(ctx, "item-1"); err != nil {
+		t.Fatalf("GetByID tras reabrir: %v", err)
+	}
+}
@@ -0,0 +1,2 @@
+-- Los nombres de items no se repiten dentro de una categoría
+CREATE UNIQUE INDEX items_category_name_key ON items (lower(category), lower(name));
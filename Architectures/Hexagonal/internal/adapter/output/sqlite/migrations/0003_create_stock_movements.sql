@@ -0,0 +1,19 @@
+-- Libro de movimientos de stock: el stock de cada item es la suma de sus movimientos
+CREATE TABLE stock_movements (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id     TEXT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    type        TEXT NOT NULL CHECK (type IN ('sale', 'restock', 'adjustment', 'return')),
+    quantity    INTEGER NOT NULL,
+    reason      TEXT NOT NULL DEFAULT '',
+    actor       TEXT NOT NULL,
+    stock_after INTEGER NOT NULL CHECK (stock_after >= 0),
+    created_at  TEXT NOT NULL
+);
+
+CREATE INDEX stock_movements_item_idx ON stock_movements (item_id, id);
+
+-- El stock de los items existentes pasa a ser su movimiento inicial
+INSERT INTO stock_movements (item_id, type, quantity, reason, actor, stock_after, created_at)
+SELECT id, 'adjustment', stock, 'stock inicial', 'sistema', stock, created_at
+FROM items
+WHERE stock > 0;
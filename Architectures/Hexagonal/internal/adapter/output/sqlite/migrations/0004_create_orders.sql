@@ -0,0 +1,31 @@
+-- Órdenes de venta del mostrador
+CREATE TABLE orders (
+    id             TEXT PRIMARY KEY,
+    status         TEXT NOT NULL CHECK (status IN ('completed', 'voided')),
+    payment_method TEXT NOT NULL,
+    subtotal       REAL NOT NULL,
+    discount       REAL NOT NULL DEFAULT 0,
+    tax_rate       REAL NOT NULL DEFAULT 0,
+    taxes          REAL NOT NULL,
+    total          REAL NOT NULL,
+    cashier        TEXT NOT NULL,
+    created_at     TEXT NOT NULL,
+    voided_at      TEXT,
+    voided_by      TEXT NOT NULL DEFAULT '',
+    void_reason    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX orders_created_at_idx ON orders (created_at, id);
+
+-- Las líneas guardan el nombre y el precio del item al venderse; no referencian
+-- items para que la orden sobreviva al borrado del item
+CREATE TABLE order_lines (
+    order_id   TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    line_no    INTEGER NOT NULL,
+    item_id    TEXT NOT NULL,
+    item_name  TEXT NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price REAL NOT NULL,
+    line_total REAL NOT NULL,
+    PRIMARY KEY (order_id, line_no)
+);
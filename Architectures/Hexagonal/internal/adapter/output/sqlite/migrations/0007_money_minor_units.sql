@@ -0,0 +1,38 @@
+-- Los montos pasan a guardarse como enteros en la unidad menor de la moneda
+-- (centavos), junto con su código ISO-4217. Los montos existentes estaban en
+-- pesos con dos decimales.
+ALTER TABLE items ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0 CHECK (price_minor >= 0);
+ALTER TABLE items ADD COLUMN currency TEXT NOT NULL DEFAULT 'ARS';
+UPDATE items SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE items DROP COLUMN price;
+ALTER TABLE items RENAME COLUMN price_minor TO price;
+
+-- Las líneas están en la moneda de su orden
+ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT 'ARS';
+ALTER TABLE orders ADD COLUMN subtotal_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN discount_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN taxes_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN total_minor INTEGER NOT NULL DEFAULT 0;
+UPDATE orders SET
+    subtotal_minor = CAST(ROUND(subtotal * 100) AS INTEGER),
+    discount_minor = CAST(ROUND(discount * 100) AS INTEGER),
+    taxes_minor = CAST(ROUND(taxes * 100) AS INTEGER),
+    total_minor = CAST(ROUND(total * 100) AS INTEGER);
+ALTER TABLE orders DROP COLUMN subtotal;
+ALTER TABLE orders DROP COLUMN discount;
+ALTER TABLE orders DROP COLUMN taxes;
+ALTER TABLE orders DROP COLUMN total;
+ALTER TABLE orders RENAME COLUMN subtotal_minor TO subtotal;
+ALTER TABLE orders RENAME COLUMN discount_minor TO discount;
+ALTER TABLE orders RENAME COLUMN taxes_minor TO taxes;
+ALTER TABLE orders RENAME COLUMN total_minor TO total;
+
+ALTER TABLE order_lines ADD COLUMN unit_price_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_lines ADD COLUMN line_total_minor INTEGER NOT NULL DEFAULT 0;
+UPDATE order_lines SET
+    unit_price_minor = CAST(ROUND(unit_price * 100) AS INTEGER),
+    line_total_minor = CAST(ROUND(line_total * 100) AS INTEGER);
+ALTER TABLE order_lines DROP COLUMN unit_price;
+ALTER TABLE order_lines DROP COLUMN line_total;
+ALTER TABLE order_lines RENAME COLUMN unit_price_minor TO unit_price;
+ALTER TABLE order_lines RENAME COLUMN line_total_minor TO line_total;
@@ -0,0 +1,280 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"time"
+)
+
+// orderColumns son las columnas de orders en el orden en que las lee scanOrder
+const orderColumns = "id, status, payment_method, currency, subtotal, discount, tax_rate, taxes, total, cashier, created_at, voided_at, voided_by, void_reason"
+
+// PlaceOrder guarda la orden y descuenta el stock de todas sus líneas en una
+// misma transacción. Si alguna línea falla se sigue revisando el resto para
+// informarlas todas juntas, y la transacción se descarta.
+func (r *SQLiteItemRepository) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	placed := *order
+	placed.Lines = append([]domain.OrderLine(nil), order.Lines...)
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		stocks := make([]int, len(placed.Lines))
+		var rejected []domain.OrderLineError
+		for i := range placed.Lines {
+			line := &placed.Lines[i]
+			err := tx.QueryRowContext(ctx,
+				`UPDATE items SET stock = stock - ?, updated_at = ?, version = version + 1
+				WHERE id = ? AND stock >= ?
+				RETURNING name, price, currency, stock`,
+				line.Quantity, formatTime(placed.CreatedAt), line.ItemID, line.Quantity).
+				Scan(&line.ItemName, &line.UnitPrice.Amount, &line.UnitPrice.Currency, &stocks[i])
+			if errors.Is(err, sql.ErrNoRows) {
+				lineErr, err := rejectedLine(ctx, tx, i, *line)
+				if err != nil {
+					return err
+				}
+				rejected = append(rejected, lineErr)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(rejected) > 0 {
+			return &domain.OrderStockError{Lines: rejected}
+		}
+
+		if err := placed.CalculateTotals(); err != nil {
+			return err
+		}
+
+		var id string
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO orders (id, status, payment_method, currency, subtotal, discount, tax_rate, taxes, total, cashier, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id`,
+			placed.ID, placed.Status, placed.PaymentMethod, placed.Total.Currency, placed.Subtotal.Amount, placed.Discount.Amount,
+			placed.TaxRate, placed.Taxes.Amount, placed.Total.Amount, placed.Cashier, formatTime(placed.CreatedAt)).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrOrderAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+
+		for i, line := range placed.Lines {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO order_lines (order_id, line_no, item_id, item_name, quantity, unit_price, line_total)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				placed.ID, i+1, line.ItemID, line.ItemName, line.Quantity, line.UnitPrice.Amount, line.LineTotal.Amount); err != nil {
+				return err
+			}
+			if _, err := insertMovement(ctx, tx, placed.SaleMovement(line, stocks[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &placed, nil
+}
+
+// GetOrder obtiene una orden por su ID
+func (r *SQLiteItemRepository) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	var order *domain.Order
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		order, err = loadOrder(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return order, nil
+}
+
+// ListOrders obtiene las órdenes de la más reciente a la más antigua
+func (r *SQLiteItemRepository) ListOrders(ctx context.Context, query *domain.OrderQuery) ([]*domain.Order, error) {
+	where, args := "", []any{}
+	if query.Status != "" {
+		where, args = " WHERE status = ?", append(args, query.Status)
+	}
+	args = append(args, query.Limit)
+
+	orders := []*domain.Order{}
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx,
+			"SELECT "+orderColumns+" FROM orders"+where+" ORDER BY created_at DESC, id DESC LIMIT ?", args...)
+		if err != nil {
+			return fmt.Errorf("error al consultar órdenes: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			order, err := scanOrder(rows)
+			if err != nil {
+				return fmt.Errorf("error al leer órdenes: %w", err)
+			}
+			orders = append(orders, order)
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error al leer órdenes: %w", err)
+		}
+		rows.Close()
+
+		// Con una sola conexión las líneas se leen después de cerrar el cursor
+		for _, order := range orders {
+			if order.Lines, err = loadOrderLines(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// VoidOrder anula la orden y devuelve al stock sus líneas en una misma
+// transacción. Las líneas de items que ya no existen no se devuelven.
+func (r *SQLiteItemRepository) VoidOrder(ctx context.Context, id, actor, reason string, at time.Time) (*domain.Order, error) {
+	var order *domain.Order
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		if order, err = loadOrder(ctx, tx, id); err != nil {
+			return err
+		}
+		if order.Status == domain.OrderVoided {
+			return domain.ErrOrderAlreadyVoided
+		}
+
+		order.Status = domain.OrderVoided
+		order.VoidedAt = &at
+		order.VoidedBy = actor
+		order.VoidReason = reason
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE orders SET status = ?, voided_at = ?, voided_by = ?, void_reason = ? WHERE id = ?",
+			order.Status, formatTime(at), actor, reason, id); err != nil {
+			return err
+		}
+
+		for _, line := range order.Lines {
+			var stock int
+			err := tx.QueryRowContext(ctx,
+				"UPDATE items SET stock = stock + ?, updated_at = ?, version = version + 1 WHERE id = ? RETURNING stock",
+				line.Quantity, formatTime(at), line.ItemID).Scan(&stock)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := insertMovement(ctx, tx, order.ReturnMovement(line, stock)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return order, nil
+}
+
+// rejectedLine explica por qué no se pudo descontar el stock de una línea
+func rejectedLine(ctx context.Context, tx *sql.Tx, index int, line domain.OrderLine) (domain.OrderLineError, error) {
+	lineErr := domain.OrderLineError{Line: index + 1, ItemID: line.ItemID, Requested: line.Quantity}
+
+	err := tx.QueryRowContext(ctx, "SELECT stock FROM items WHERE id = ?", line.ItemID).Scan(&lineErr.Available)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		lineErr.Reason = domain.ErrItemNotFound.Error()
+	case err != nil:
+		return lineErr, err
+	default:
+		lineErr.Reason = domain.ErrInsufficientStock.Error()
+	}
+	return lineErr, nil
+}
+
+// loadOrder lee una orden con sus líneas
+func loadOrder(ctx context.Context, tx *sql.Tx, id string) (*domain.Order, error) {
+	order, err := scanOrder(tx.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Lines, err = loadOrderLines(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// loadOrderLines lee las líneas de una orden en el orden en que se cargaron;
+// sus montos están en la moneda de la orden
+func loadOrderLines(ctx context.Context, tx *sql.Tx, order *domain.Order) ([]domain.OrderLine, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT item_id, item_name, quantity, unit_price, line_total
+		FROM order_lines WHERE order_id = ? ORDER BY line_no`, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar líneas de la orden: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []domain.OrderLine{}
+	for rows.Next() {
+		line := domain.OrderLine{
+			UnitPrice: domain.NewMoney(0, order.Total.Currency),
+			LineTotal: domain.NewMoney(0, order.Total.Currency),
+		}
+		if err := rows.Scan(&line.ItemID, &line.ItemName, &line.Quantity, &line.UnitPrice.Amount, &line.LineTotal.Amount); err != nil {
+			return nil, fmt.Errorf("error al leer líneas de la orden: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer líneas de la orden: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanOrder lee una fila con las columnas de orderColumns, sin las líneas
+func scanOrder(row rowScanner) (*domain.Order, error) {
+	var order domain.Order
+	var currency, createdAt string
+	var voidedAt sql.NullString
+	err := row.Scan(&order.ID, &order.Status, &order.PaymentMethod, &currency, &order.Subtotal.Amount, &order.Discount.Amount,
+		&order.TaxRate, &order.Taxes.Amount, &order.Total.Amount, &order.Cashier, &createdAt, &voidedAt, &order.VoidedBy, &order.VoidReason)
+	if err != nil {
+		return nil, err
+	}
+	order.Subtotal.Currency, order.Discount.Currency = currency, currency
+	order.Taxes.Currency, order.Total.Currency = currency, currency
+
+	if order.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, fmt.Errorf("created_at inválido: %w", err)
+	}
+	if voidedAt.Valid {
+		at, err := time.Parse(time.RFC3339Nano, voidedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("voided_at inválido: %w", err)
+		}
+		order.VoidedAt = &at
+	}
+
+	return &order, nil
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"kiosco/internal/domain"
+	"time"
+)
+
+// movementColumns son las columnas de stock_movements en el orden en que las lee scanMovement
+const movementColumns = "id, item_id, type, quantity, reason, actor, stock_after, created_at"
+
+// RecordMovement registra el movimiento y aplica su Delta al stock del item en
+// una misma transacción; la condición del UPDATE impide el stock negativo
+func (r *SQLiteItemRepository) RecordMovement(ctx context.Context, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	var recorded *domain.StockMovement
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		delta := movement.Delta()
+
+		var stock int
+		err := tx.QueryRowContext(ctx,
+			`UPDATE items SET stock = stock + ?, updated_at = ?, version = version + 1
+			WHERE id = ? AND stock + ? >= 0
+			RETURNING stock`,
+			delta, formatTime(movement.CreatedAt), movement.ItemID, delta).Scan(&stock)
+		if errors.Is(err, sql.ErrNoRows) {
+			return itemMissingOrShort(ctx, tx, movement.ItemID)
+		}
+		if err != nil {
+			return err
+		}
+
+		entry := *movement
+		entry.StockAfter = stock
+		recorded, err = insertMovement(ctx, tx, &entry)
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return recorded, nil
+}
+
+// ListMovements obtiene los movimientos de un item en el orden en que se registraron
+func (r *SQLiteItemRepository) ListMovements(ctx context.Context, itemID string) ([]*domain.StockMovement, error) {
+	if _, err := r.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE item_id = ? ORDER BY id", itemID)
+	if err != nil {
+		return nil, fmt.Errorf("error al consultar movimientos: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []*domain.StockMovement{}
+	for rows.Next() {
+		movement, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al leer movimientos: %w", err)
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al leer movimientos: %w", err)
+	}
+
+	return movements, nil
+}
+
+// insertMovement guarda un movimiento cuyo StockAfter ya está calculado
+func insertMovement(ctx context.Context, tx *sql.Tx, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	row := tx.QueryRowContext(ctx,
+		`INSERT INTO stock_movements (item_id, type, quantity, reason, actor, stock_after, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+movementColumns,
+		movement.ItemID, movement.Type, movement.Quantity, movement.Reason, movement.Actor,
+		movement.StockAfter, formatTime(movement.CreatedAt))
+	return scanMovement(row)
+}
+
+// itemMissingOrShort explica por qué un movimiento no actualizó el item
+func itemMissingOrShort(ctx context.Context, tx *sql.Tx, itemID string) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, "SELECT 1 FROM items WHERE id = ?", itemID).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrInsufficientStock
+}
+
+// scanMovement lee una fila con las columnas de movementColumns
+func scanMovement(row rowScanner) (*domain.StockMovement, error) {
+	var movement domain.StockMovement
+	var createdAt string
+	err := row.Scan(&movement.ID, &movement.ItemID, &movement.Type, &movement.Quantity, &movement.Reason,
+		&movement.Actor, &movement.StockAfter, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if movement.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, fmt.Errorf("created_at inválido: %w", err)
+	}
+
+	return &movement, nil
+}
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/domain"
+	"strings"
+	"time"
+)
+
+// CategoryService contiene los casos de uso de las categorías de items
+type CategoryService struct {
+	categories domain.CategoryRepository
+	ids        domain.IDGenerator
+}
+
+// NewCategoryService crea una nueva instancia del servicio de categorías
+func NewCategoryService(categories domain.CategoryRepository, ids domain.IDGenerator) *CategoryService {
+	return &CategoryService{
+		categories: categories,
+		ids:        ids,
+	}
+}
+
+// ListCategories obtiene todas las categorías ordenadas por nombre
+func (s *CategoryService) ListCategories(ctx context.Context) ([]*domain.Category, error) {
+	return s.categories.ListCategories(ctx)
+}
+
+// GetCategory obtiene una categoría por su ID
+func (s *CategoryService) GetCategory(ctx context.Context, id string) (*domain.Category, error) {
+	if id == "" {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return s.categories.GetCategory(ctx, id)
+}
+
+// CreateCategory crea una nueva categoría con un ID generado por el servidor
+func (s *CategoryService) CreateCategory(ctx context.Context, category *domain.Category) (*domain.Category, error) {
+	// El ID no lo elige el cliente
+	if category.ID != "" {
+		return nil, domain.ErrCategoryIDNotAllowed
+	}
+
+	// Los items referencian la categoría por su nombre: los espacios de los
+	// extremos no deben distinguir dos categorías
+	category.Name = strings.TrimSpace(category.Name)
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	category.ID = s.ids.NewID()
+
+	now := time.Now()
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	return s.categories.CreateCategory(ctx, category)
+}
+
+// UpdateCategory actualiza una categoría existente. Si cambia el nombre, sus
+// items pasan a usar el nuevo.
+func (s *CategoryService) UpdateCategory(ctx context.Context, id string, category *domain.Category) (*domain.Category, error) {
+	if id == "" {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	category.Name = strings.TrimSpace(category.Name)
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.categories.GetCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.ID = id
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+
+	return s.categories.UpdateCategory(ctx, id, category)
+}
+
+// DeleteCategory elimina una categoría que ningún item usa
+func (s *CategoryService) DeleteCategory(ctx context.Context, id string) error {
+	if id == "" {
+		return domain.ErrCategoryNotFound
+	}
+
+	return s.categories.DeleteCategory(ctx, id)
+}
+
+// SummarizeCategories obtiene la cantidad de items y el valor del stock de
+// cada categoría
+func (s *CategoryService) SummarizeCategories(ctx context.Context) ([]*domain.CategorySummary, error) {
+	return s.categories.SummarizeCategories(ctx)
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestCreateCategoryValidatesCategory(t *testing.T) {
+	ids := &sequenceIDs{}
+	service := NewCategoryService(memory.NewMemoryItemRepository(), ids)
+	ctx := context.Background()
+
+	if _, err := service.CreateCategory(ctx, &domain.Category{ID: "mia", Name: "Bebidas"}); err != domain.ErrCategoryIDNotAllowed {
+		t.Fatalf("con ID: se esperaba ErrCategoryIDNotAllowed, se obtuvo %v", err)
+	}
+	if _, err := service.CreateCategory(ctx, &domain.Category{Name: "   "}); err != domain.ErrInvalidCategoryName {
+		t.Fatalf("sin nombre: se esperaba ErrInvalidCategoryName, se obtuvo %v", err)
+	}
+	if ids.next != 0 {
+		t.Fatal("no se debe generar un ID para una categoría rechazada")
+	}
+
+	created, err := service.CreateCategory(ctx, &domain.Category{Name: "  Bebidas "})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	if created.ID != "id-1" || created.Name != "Bebidas" || created.CreatedAt.IsZero() {
+		t.Fatalf("categoría = %+v", created)
+	}
+
+	if _, err := service.CreateCategory(ctx, &domain.Category{Name: "bebidas"}); err != domain.ErrCategoryAlreadyExists {
+		t.Fatalf("se esperaba ErrCategoryAlreadyExists, se obtuvo %v", err)
+	}
+}
+
+func TestItemsUseRegisteredCategories(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	items := NewItemService(repo, &sequenceIDs{})
+	categories := NewCategoryService(repo, &sequenceIDs{})
+	ctx := context.Background()
+
+	category := createCategory(t, repo, "Golosinas")
+
+	// Un error de tipeo ya no crea una categoría nueva
+	if _, err := items.CreateItem(ctx, &domain.Item{Name: "Alfajor", Category: "Golosians"}); err != domain.ErrUnknownItemCategory {
+		t.Fatalf("se esperaba ErrUnknownItemCategory, se obtuvo %v", err)
+	}
+
+	item, err := items.CreateItem(ctx, &domain.Item{Name: "Alfajor", Price: domain.NewMoney(10000, "ARS"), Stock: 5, Category: "golosinas"})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	if item.Category != "Golosinas" {
+		t.Fatalf("categoría = %q, se esperaba Golosinas", item.Category)
+	}
+
+	if err := categories.DeleteCategory(ctx, category.ID); err != domain.ErrCategoryInUse {
+		t.Fatalf("se esperaba ErrCategoryInUse, se obtuvo %v", err)
+	}
+
+	renamed, err := categories.UpdateCategory(ctx, category.ID, &domain.Category{Name: "Dulces"})
+	if err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+	if !renamed.CreatedAt.Equal(category.CreatedAt) {
+		t.Fatalf("la fecha de creación cambió: %v", renamed.CreatedAt)
+	}
+	moved, err := items.GetItemByID(ctx, item.ID)
+	if err != nil {
+		t.Fatalf("GetItemByID: %v", err)
+	}
+	if moved.Category != "Dulces" || moved.Version != item.Version+1 {
+		t.Fatalf("item tras renombrar la categoría = %+v", moved)
+	}
+
+	summaries, err := categories.SummarizeCategories(ctx)
+	if err != nil {
+		t.Fatalf("SummarizeCategories: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].ItemCount != 1 || !slices.Equal(summaries[0].StockValues, []domain.Money{domain.NewMoney(50000, "ARS")}) {
+		t.Fatalf("resumen = %+v", summaries)
+	}
+
+	if err := items.DeleteItem(ctx, item.ID, moved.Version); err != nil {
+		t.Fatalf("DeleteItem: %v", err)
+	}
+	if err := categories.DeleteCategory(ctx, category.ID); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+}
+
+// createCategory registra una categoría para que los items puedan usarla
+func createCategory(t *testing.T, categories domain.CategoryRepository, name string) *domain.Category {
+	t.Helper()
+
+	category, err := NewCategoryService(categories, &sequenceIDs{}).CreateCategory(context.Background(), &domain.Category{Name: name})
+	if err != nil {
+		t.Fatalf("CreateCategory(%q): %v", name, err)
+	}
+	return category
+}
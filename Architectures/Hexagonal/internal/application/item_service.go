@@ -9,18 +9,24 @@ import (
 // ItemService contiene los casos de uso del dominio Item
 type ItemService struct {
 	repository domain.ItemRepository
+	ids        domain.IDGenerator
 }
 
 // NewItemService crea una nueva instancia del servicio de items
-func NewItemService(repository domain.ItemRepository) *ItemService {
+func NewItemService(repository domain.ItemRepository, ids domain.IDGenerator) *ItemService {
 	return &ItemService{
 		repository: repository,
+		ids:        ids,
 	}
 }
 
-// GetAllItems obtiene todos los items
-func (s *ItemService) GetAllItems(ctx context.Context) ([]*domain.Item, error) {
-	return s.repository.GetAll(ctx)
+// GetAllItems obtiene una página de los items que cumplen la consulta
+func (s *ItemService) GetAllItems(ctx context.Context, query *domain.ItemQuery) (*domain.ItemPage, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetAll(ctx, query)
 }
 
 // GetItemByID obtiene un item por su ID
@@ -41,13 +47,20 @@ func (s *ItemService) GetItemByID(ctx context.Context, id string) (*domain.Item,
 	return item, nil
 }
 
-// CreateItem crea un nuevo item
+// CreateItem crea un nuevo item con un ID generado por el servidor
 func (s *ItemService) CreateItem(ctx context.Context, item *domain.Item) (*domain.Item, error) {
+	// El ID no lo elige el cliente
+	if item.ID != "" {
+		return nil, domain.ErrItemIDNotAllowed
+	}
+
 	// Validar el item
 	if err := item.Validate(); err != nil {
 		return nil, err
 	}
 
+	item.ID = s.ids.NewID()
+
 	// Establecer timestamps
 	now := time.Now()
 	item.CreatedAt = now
@@ -57,11 +70,15 @@ func (s *ItemService) CreateItem(ctx context.Context, item *domain.Item) (*domai
 	return s.repository.Create(ctx, item)
 }
 
-// UpdateItem actualiza un item existente
-func (s *ItemService) UpdateItem(ctx context.Context, id string, item *domain.Item) (*domain.Item, error) {
+// UpdateItem actualiza un item existente si su versión sigue siendo la que
+// leyó el cliente
+func (s *ItemService) UpdateItem(ctx context.Context, id string, version int64, item *domain.Item) (*domain.Item, error) {
 	if id == "" {
 		return nil, domain.ErrItemNotFound
 	}
+	if version <= 0 {
+		return nil, domain.ErrVersionRequired
+	}
 
 	// Validar el item
 	if err := item.Validate(); err != nil {
@@ -78,18 +95,82 @@ func (s *ItemService) UpdateItem(ctx context.Context, id string, item *domain.It
 		return nil, domain.ErrItemNotFound
 	}
 
+	// El repositorio vuelve a comparar la versión al escribir; esta
+	// verificación solo evita el trabajo cuando ya se sabe que cambió
+	if existingItem.Version != version {
+		return nil, domain.ErrVersionMismatch
+	}
+	item.Version = version
+
 	// Actualizar timestamps
 	item.UpdatedAt = time.Now()
 	item.CreatedAt = existingItem.CreatedAt // Preservar fecha de creación
 
+	// El stock solo cambia con movimientos de stock
+	if item.Stock != existingItem.Stock {
+		return nil, domain.ErrItemStockReadOnly
+	}
+
 	return s.repository.Update(ctx, id, item)
 }
 
-// DeleteItem elimina un item por su ID
-func (s *ItemService) DeleteItem(ctx context.Context, id string) error {
+// PatchItem aplica un cambio parcial a un item existente si su versión sigue
+// siendo la que leyó el cliente. patch recibe una copia del item guardado y
+// la modifica; el resultado se valida completo. Como en UpdateItem, el ID y la
+// fecha de creación no cambian y un cambio de stock se rechaza.
+func (s *ItemService) PatchItem(ctx context.Context, id string, version int64, patch func(item *domain.Item) error) (*domain.Item, error) {
+	if id == "" {
+		return nil, domain.ErrItemNotFound
+	}
+	if version <= 0 {
+		return nil, domain.ErrVersionRequired
+	}
+
+	existingItem, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingItem == nil {
+		return nil, domain.ErrItemNotFound
+	}
+
+	if existingItem.Version != version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	item := *existingItem
+	if err := patch(&item); err != nil {
+		return nil, err
+	}
+
+	if item.Stock != existingItem.Stock {
+		return nil, domain.ErrItemStockReadOnly
+	}
+
+	// Los campos que define el servidor no se toman del patch
+	item.ID = id
+	item.CreatedAt = existingItem.CreatedAt
+	item.Version = version
+	item.UpdatedAt = time.Now()
+
+	// Validar el item resultante
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repository.Update(ctx, id, &item)
+}
+
+// DeleteItem elimina un item por su ID si su versión sigue siendo la que
+// leyó el cliente
+func (s *ItemService) DeleteItem(ctx context.Context, id string, version int64) error {
 	if id == "" {
 		return domain.ErrItemNotFound
 	}
+	if version <= 0 {
+		return domain.ErrVersionRequired
+	}
 
 	// Verificar que el item existe
 	item, err := s.repository.GetByID(ctx, id)
@@ -101,5 +182,5 @@ func (s *ItemService) DeleteItem(ctx context.Context, id string) error {
 		return domain.ErrItemNotFound
 	}
 
-	return s.repository.Delete(ctx, id)
+	return s.repository.Delete(ctx, id, version)
 }
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/domain"
+	"testing"
+	"time"
+)
+
+// sequenceIDs genera IDs predecibles para los tests
+type sequenceIDs struct {
+	next int
+}
+
+func (g *sequenceIDs) NewID() string {
+	g.next++
+	return fmt.Sprintf("id-%d", g.next)
+}
+
+func TestCreateItemGeneratesID(t *testing.T) {
+	service := NewItemService(memory.NewMemoryItemRepository(), &sequenceIDs{})
+
+	created, err := service.CreateItem(context.Background(), &domain.Item{Name: "Alfajor", Price: domain.NewMoney(10000, "ARS"), Stock: 5})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	if created.ID != "id-1" {
+		t.Fatalf("ID = %q, se esperaba id-1", created.ID)
+	}
+	if created.CreatedAt.IsZero() || !created.CreatedAt.Equal(created.UpdatedAt) {
+		t.Fatalf("fechas inválidas: %v / %v", created.CreatedAt, created.UpdatedAt)
+	}
+}
+
+func TestCreateItemRejectsClientID(t *testing.T) {
+	ids := &sequenceIDs{}
+	service := NewItemService(memory.NewMemoryItemRepository(), ids)
+
+	_, err := service.CreateItem(context.Background(), &domain.Item{ID: "mio", Name: "Alfajor"})
+	if err != domain.ErrItemIDNotAllowed {
+		t.Fatalf("se esperaba ErrItemIDNotAllowed, se obtuvo %v", err)
+	}
+	if ids.next != 0 {
+		t.Fatal("no se debe generar un ID para un item rechazado")
+	}
+}
+
+func TestCreateItemRejectsDuplicateNameInCategory(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	service := NewItemService(repo, &sequenceIDs{})
+	ctx := context.Background()
+	createCategory(t, repo, "Golosinas")
+
+	if _, err := service.CreateItem(ctx, &domain.Item{Name: "Alfajor", Category: "Golosinas"}); err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	_, err := service.CreateItem(ctx, &domain.Item{Name: "alfajor", Category: "Golosinas"})
+	if err != domain.ErrItemAlreadyExists {
+		t.Fatalf("se esperaba ErrItemAlreadyExists, se obtuvo %v", err)
+	}
+}
+
+func TestUpdateItemRequiresCurrentVersion(t *testing.T) {
+	service := NewItemService(memory.NewMemoryItemRepository(), &sequenceIDs{})
+	ctx := context.Background()
+
+	item, err := service.CreateItem(ctx, &domain.Item{Name: "Alfajor", Price: domain.NewMoney(10000, "ARS"), Stock: 5})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	if _, err := service.UpdateItem(ctx, item.ID, 0, &domain.Item{Name: "Alfajor triple"}); err != domain.ErrVersionRequired {
+		t.Fatalf("sin versión: se esperaba ErrVersionRequired, se obtuvo %v", err)
+	}
+
+	updated, err := service.UpdateItem(ctx, item.ID, item.Version, &domain.Item{Name: "Alfajor triple", Price: domain.NewMoney(12000, "ARS"), Stock: 5})
+	if err != nil {
+		t.Fatalf("UpdateItem: %v", err)
+	}
+	if updated.Version != item.Version+1 {
+		t.Fatalf("versión = %d, se esperaba %d", updated.Version, item.Version+1)
+	}
+
+	// Otro cajero que leyó la versión anterior no pisa el cambio
+	if _, err := service.UpdateItem(ctx, item.ID, item.Version, &domain.Item{Name: "Alfajor simple"}); err != domain.ErrVersionMismatch {
+		t.Fatalf("se esperaba ErrVersionMismatch, se obtuvo %v", err)
+	}
+	if err := service.DeleteItem(ctx, item.ID, item.Version); err != domain.ErrVersionMismatch {
+		t.Fatalf("DeleteItem: se esperaba ErrVersionMismatch, se obtuvo %v", err)
+	}
+	if err := service.DeleteItem(ctx, item.ID, updated.Version); err != nil {
+		t.Fatalf("DeleteItem: %v", err)
+	}
+}
+
+func TestPatchItemPreservesServerFields(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	service := NewItemService(repo, &sequenceIDs{})
+	ctx := context.Background()
+	createCategory(t, repo, "Golosinas")
+
+	item, err := service.CreateItem(ctx, &domain.Item{Name: "Alfajor", Price: domain.NewMoney(10000, "ARS"), Stock: 5, Category: "Golosinas"})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	patched, err := service.PatchItem(ctx, item.ID, item.Version, func(item *domain.Item) error {
+		item.Price = domain.NewMoney(12000, "ARS")
+		item.ID, item.CreatedAt = "otro", time.Time{}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("PatchItem: %v", err)
+	}
+	if patched.Price != domain.NewMoney(12000, "ARS") || patched.Name != "Alfajor" || patched.Category != "Golosinas" {
+		t.Fatalf("item = %+v", patched)
+	}
+	if patched.ID != item.ID || patched.Stock != 5 || !patched.CreatedAt.Equal(item.CreatedAt) || patched.Version != item.Version+1 {
+		t.Fatalf("el patch cambió campos del servidor: %+v", patched)
+	}
+
+	// El stock solo cambia con movimientos
+	_, err = service.PatchItem(ctx, item.ID, patched.Version, func(item *domain.Item) error {
+		item.Stock = 0
+		return nil
+	})
+	if err != domain.ErrItemStockReadOnly {
+		t.Fatalf("se esperaba ErrItemStockReadOnly, se obtuvo %v", err)
+	}
+
+	// El resultado se valida completo
+	_, err = service.PatchItem(ctx, item.ID, patched.Version, func(item *domain.Item) error {
+		item.Name = ""
+		return nil
+	})
+	if err != domain.ErrInvalidItemName {
+		t.Fatalf("se esperaba ErrInvalidItemName, se obtuvo %v", err)
+	}
+
+	_, err = service.PatchItem(ctx, item.ID, item.Version, func(item *domain.Item) error { return nil })
+	if err != domain.ErrVersionMismatch {
+		t.Fatalf("se esperaba ErrVersionMismatch, se obtuvo %v", err)
+	}
+}
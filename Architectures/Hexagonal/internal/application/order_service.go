@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/domain"
+	"time"
+)
+
+// OrderService contiene los casos de uso de las órdenes de venta
+type OrderService struct {
+	orders  domain.OrderRepository
+	ids     domain.IDGenerator
+	taxRate float64
+}
+
+// NewOrderService crea una nueva instancia del servicio de órdenes; taxRate
+// es la alícuota que se aplica a todas las órdenes
+func NewOrderService(orders domain.OrderRepository, ids domain.IDGenerator, taxRate float64) *OrderService {
+	return &OrderService{
+		orders:  orders,
+		ids:     ids,
+		taxRate: taxRate,
+	}
+}
+
+// PlaceOrder registra una venta y descuenta el stock de sus items
+func (s *OrderService) PlaceOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	// El ID, el estado, los precios, los totales y las fechas los define el servidor
+	order.MergeLines()
+	order.ID = ""
+	order.Status = domain.OrderCompleted
+	order.TaxRate = s.taxRate
+	order.Subtotal, order.Taxes, order.Total = domain.Money{}, domain.Money{}, domain.Money{}
+	order.VoidedAt, order.VoidedBy, order.VoidReason = nil, "", ""
+
+	// Validar la orden
+	if err := order.Validate(); err != nil {
+		return nil, err
+	}
+
+	order.ID = s.ids.NewID()
+	order.CreatedAt = time.Now()
+
+	return s.orders.PlaceOrder(ctx, order)
+}
+
+// GetOrder obtiene una orden por su ID
+func (s *OrderService) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	if id == "" {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	return s.orders.GetOrder(ctx, id)
+}
+
+// ListOrders obtiene las órdenes que cumplen la consulta
+func (s *OrderService) ListOrders(ctx context.Context, query *domain.OrderQuery) ([]*domain.Order, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.orders.ListOrders(ctx, query)
+}
+
+// VoidOrder anula una orden y devuelve su mercadería al stock
+func (s *OrderService) VoidOrder(ctx context.Context, id, actor, reason string) (*domain.Order, error) {
+	if id == "" {
+		return nil, domain.ErrOrderNotFound
+	}
+	if reason == "" {
+		return nil, domain.ErrVoidReasonRequired
+	}
+	if actor == "" {
+		return nil, domain.ErrVoidActorRequired
+	}
+
+	return s.orders.VoidOrder(ctx, id, actor, reason, time.Now())
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/domain"
+	"testing"
+)
+
+func TestPlaceOrderValidatesOrder(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	orders := NewOrderService(repo, &sequenceIDs{}, 0.21)
+	ctx := context.Background()
+
+	line := domain.OrderLine{ItemID: "alfajor", Quantity: 1}
+	tests := []struct {
+		name  string
+		order domain.Order
+		want  error
+	}{
+		{"sin líneas", domain.Order{PaymentMethod: domain.PaymentCash, Cashier: "ana"}, domain.ErrOrderWithoutLines},
+		{"línea sin item", domain.Order{Lines: []domain.OrderLine{{Quantity: 1}}, PaymentMethod: domain.PaymentCash, Cashier: "ana"}, domain.ErrOrderLineItemRequired},
+		{"cantidad cero", domain.Order{Lines: []domain.OrderLine{{ItemID: "alfajor"}}, PaymentMethod: domain.PaymentCash, Cashier: "ana"}, domain.ErrInvalidOrderQuantity},
+		{"descuento negativo", domain.Order{Lines: []domain.OrderLine{line}, Discount: domain.NewMoney(-100, "ARS"), PaymentMethod: domain.PaymentCash, Cashier: "ana"}, domain.ErrInvalidOrderDiscount},
+		{"medio de pago desconocido", domain.Order{Lines: []domain.OrderLine{line}, PaymentMethod: "cheque", Cashier: "ana"}, domain.ErrInvalidPaymentMethod},
+		{"sin cajero", domain.Order{Lines: []domain.OrderLine{line}, PaymentMethod: domain.PaymentCash}, domain.ErrOrderCashierRequired},
+	}
+	for _, tt := range tests {
+		if _, err := orders.PlaceOrder(ctx, &tt.order); err != tt.want {
+			t.Errorf("%s: se esperaba %v, se obtuvo %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestPlaceOrderMergesLinesAndAppliesTaxRate(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	items := NewItemService(repo, &sequenceIDs{})
+	orders := NewOrderService(repo, &sequenceIDs{}, 0.21)
+	ctx := context.Background()
+
+	item, err := items.CreateItem(ctx, &domain.Item{Name: "Alfajor", Price: domain.NewMoney(10000, "ARS"), Stock: 10})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	// El cliente no puede fijar precios, totales ni la alícuota
+	placed, err := orders.PlaceOrder(ctx, &domain.Order{
+		Lines: []domain.OrderLine{
+			{ItemID: item.ID, Quantity: 2, UnitPrice: domain.NewMoney(100, "ARS")},
+			{ItemID: item.ID, Quantity: 1},
+		},
+		TaxRate:       0,
+		Total:         domain.NewMoney(100, "ARS"),
+		PaymentMethod: domain.PaymentDebitCard,
+		Cashier:       "ana",
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if len(placed.Lines) != 1 || placed.Lines[0].Quantity != 3 || placed.Lines[0].UnitPrice != domain.NewMoney(10000, "ARS") {
+		t.Fatalf("líneas = %+v", placed.Lines)
+	}
+	if placed.ID != "id-1" || placed.Status != domain.OrderCompleted || placed.TaxRate != 0.21 ||
+		placed.Taxes != domain.NewMoney(6300, "ARS") || placed.Total != domain.NewMoney(36300, "ARS") || placed.CreatedAt.IsZero() {
+		t.Fatalf("orden = %+v", placed)
+	}
+
+	if _, err := orders.VoidOrder(ctx, placed.ID, "ana", ""); err != domain.ErrVoidReasonRequired {
+		t.Fatalf("se esperaba ErrVoidReasonRequired, se obtuvo %v", err)
+	}
+	if _, err := orders.VoidOrder(ctx, placed.ID, "", "error de carga"); err != domain.ErrVoidActorRequired {
+		t.Fatalf("se esperaba ErrVoidActorRequired, se obtuvo %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/domain"
+	"time"
+)
+
+// StockService contiene los casos de uso del libro de stock
+type StockService struct {
+	movements domain.StockMovementRepository
+}
+
+// NewStockService crea una nueva instancia del servicio de stock
+func NewStockService(movements domain.StockMovementRepository) *StockService {
+	return &StockService{
+		movements: movements,
+	}
+}
+
+// RecordMovement registra un movimiento de stock de un item
+func (s *StockService) RecordMovement(ctx context.Context, itemID string, movement *domain.StockMovement) (*domain.StockMovement, error) {
+	if itemID == "" {
+		return nil, domain.ErrItemNotFound
+	}
+
+	// El item, el ID, el stock resultante y la fecha los define el servidor
+	movement.ID = 0
+	movement.ItemID = itemID
+	movement.StockAfter = 0
+
+	// Validar el movimiento
+	if err := movement.Validate(); err != nil {
+		return nil, err
+	}
+
+	movement.CreatedAt = time.Now()
+
+	return s.movements.RecordMovement(ctx, movement)
+}
+
+// ListMovements obtiene los movimientos de stock de un item
+func (s *StockService) ListMovements(ctx context.Context, itemID string) ([]*domain.StockMovement, error) {
+	if itemID == "" {
+		return nil, domain.ErrItemNotFound
+	}
+
+	return s.movements.ListMovements(ctx, itemID)
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"kiosco/internal/adapter/output/memory"
+	"kiosco/internal/domain"
+	"testing"
+)
+
+func TestRecordMovementValidatesMovement(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	items := NewItemService(repo, &sequenceIDs{})
+	stock := NewStockService(repo)
+	ctx := context.Background()
+
+	item, err := items.CreateItem(ctx, &domain.Item{Name: "Alfajor", Stock: 10})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		movement domain.StockMovement
+		want     error
+	}{
+		{"tipo desconocido", domain.StockMovement{Type: "robo", Quantity: 1, Actor: "ana"}, domain.ErrInvalidMovementType},
+		{"venta sin cantidad", domain.StockMovement{Type: domain.MovementSale, Actor: "ana"}, domain.ErrInvalidMovementQuantity},
+		{"venta negativa", domain.StockMovement{Type: domain.MovementSale, Quantity: -1, Actor: "ana"}, domain.ErrInvalidMovementQuantity},
+		{"ajuste sin motivo", domain.StockMovement{Type: domain.MovementAdjustment, Quantity: -1, Actor: "ana"}, domain.ErrMovementReasonRequired},
+		{"sin responsable", domain.StockMovement{Type: domain.MovementRestock, Quantity: 1}, domain.ErrMovementActorRequired},
+		{"venta mayor al stock", domain.StockMovement{Type: domain.MovementSale, Quantity: 11, Actor: "ana"}, domain.ErrInsufficientStock},
+	}
+	for _, tt := range tests {
+		if _, err := stock.RecordMovement(ctx, item.ID, &tt.movement); err != tt.want {
+			t.Errorf("%s: se esperaba %v, se obtuvo %v", tt.name, tt.want, err)
+		}
+	}
+
+	// El cliente no puede fijar el stock resultante ni el item del movimiento
+	recorded, err := stock.RecordMovement(ctx, item.ID, &domain.StockMovement{
+		ItemID: "otro", Type: domain.MovementSale, Quantity: 4, Actor: "ana", StockAfter: 100,
+	})
+	if err != nil {
+		t.Fatalf("RecordMovement: %v", err)
+	}
+	if recorded.ItemID != item.ID || recorded.StockAfter != 6 || recorded.CreatedAt.IsZero() {
+		t.Fatalf("movimiento = %+v", recorded)
+	}
+}
+
+func TestUpdateItemRejectsStockChange(t *testing.T) {
+	repo := memory.NewMemoryItemRepository()
+	items := NewItemService(repo, &sequenceIDs{})
+	ctx := context.Background()
+
+	item, err := items.CreateItem(ctx, &domain.Item{Name: "Alfajor", Stock: 10})
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+
+	if _, err := items.UpdateItem(ctx, item.ID, item.Version, &domain.Item{Name: "Alfajor triple", Stock: 0}); err != domain.ErrItemStockReadOnly {
+		t.Fatalf("se esperaba ErrItemStockReadOnly, se obtuvo %v", err)
+	}
+
+	updated, err := items.UpdateItem(ctx, item.ID, item.Version, &domain.Item{Name: "Alfajor triple", Stock: 10})
+	if err != nil {
+		t.Fatalf("UpdateItem: %v", err)
+	}
+	if updated.Stock != 10 || updated.Name != "Alfajor triple" {
+		t.Fatalf("item = %+v", updated)
+	}
+}
@@ -3,13 +3,42 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Backends de persistencia disponibles
+const (
+	BackendAPI      = "api"
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+	BackendMemory   = "memory"
+)
+
 type Config struct {
-	ServerPort     string
-	ExternalAPIURL string
+	ServerPort string
+
+	// RepositoryBackend indica dónde se guardan los items: "api", "postgres",
+	// "sqlite" o "memory"
+	RepositoryBackend string
+	ExternalAPIURL    string
+	SQLitePath        string
+
+	DatabaseURL             string
+	DatabaseMaxConns        int32
+	DatabaseMinConns        int32
+	DatabaseMaxConnLifetime time.Duration
+	DatabaseMaxConnIdleTime time.Duration
+
+	// TaxRate es la alícuota de impuestos de las órdenes, por ejemplo 0.21
+	TaxRate float64
+
+	// DisplayCurrency es la moneda en la que se informan además los precios;
+	// ExchangeRatesFile, si está, es el archivo JSON con las cotizaciones
+	DisplayCurrency   string
+	ExchangeRatesFile string
 }
 
 func Load() (*Config, error) {
@@ -17,12 +46,48 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	config := &Config{
-		ServerPort:     getEnv("SERVER_PORT", "8080"),
-		ExternalAPIURL: getEnv("EXTERNAL_API_URL", "http://localhost:3000/api"),
+		ServerPort:        getEnv("SERVER_PORT", "8080"),
+		RepositoryBackend: getEnv("REPOSITORY_BACKEND", BackendAPI),
+		ExternalAPIURL:    getEnv("EXTERNAL_API_URL", "http://localhost:3000/api"),
+		SQLitePath:        getEnv("SQLITE_PATH", "kiosco.db"),
+		DatabaseURL:       getEnv("DATABASE_URL", ""),
+		DisplayCurrency:   getEnv("DISPLAY_CURRENCY", "ARS"),
+		ExchangeRatesFile: getEnv("EXCHANGE_RATES_FILE", ""),
+	}
+
+	var err error
+	if config.DatabaseMaxConns, err = getEnvInt32("DATABASE_MAX_CONNS", 10); err != nil {
+		return nil, err
+	}
+	if config.DatabaseMinConns, err = getEnvInt32("DATABASE_MIN_CONNS", 1); err != nil {
+		return nil, err
+	}
+	if config.DatabaseMaxConnLifetime, err = getEnvDuration("DATABASE_MAX_CONN_LIFETIME", time.Hour); err != nil {
+		return nil, err
+	}
+	if config.DatabaseMaxConnIdleTime, err = getEnvDuration("DATABASE_MAX_CONN_IDLE_TIME", 30*time.Minute); err != nil {
+		return nil, err
+	}
+	if config.TaxRate, err = getEnvTaxRate("TAX_RATE"); err != nil {
+		return nil, err
 	}
 
-	if config.ExternalAPIURL == "" {
-		return nil, fmt.Errorf("EXTERNAL_API_URL es requerida")
+	switch config.RepositoryBackend {
+	case BackendAPI:
+		if config.ExternalAPIURL == "" {
+			return nil, fmt.Errorf("EXTERNAL_API_URL es requerida")
+		}
+	case BackendPostgres:
+		if config.DatabaseURL == "" {
+			return nil, fmt.Errorf("DATABASE_URL es requerida con REPOSITORY_BACKEND=%s", BackendPostgres)
+		}
+		if config.DatabaseMinConns > config.DatabaseMaxConns {
+			return nil, fmt.Errorf("DATABASE_MIN_CONNS no puede ser mayor que DATABASE_MAX_CONNS")
+		}
+	case BackendSQLite, BackendMemory:
+	default:
+		return nil, fmt.Errorf("REPOSITORY_BACKEND inválido %q: use %s, %s, %s o %s",
+			config.RepositoryBackend, BackendAPI, BackendPostgres, BackendSQLite, BackendMemory)
 	}
 
 	return config, nil
@@ -34,3 +99,39 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt32(key string, defaultValue int32) (int32, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s debe ser un entero mayor o igual a cero", key)
+	}
+	return int32(n), nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s debe ser una duración válida, por ejemplo 30m", key)
+	}
+	return d, nil
+}
+
+func getEnvTaxRate(key string) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate >= 1 {
+		return 0, fmt.Errorf("%s debe ser una alícuota entre 0 y 1, por ejemplo 0.21", key)
+	}
+	return rate, nil
+}
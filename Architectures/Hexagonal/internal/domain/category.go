@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Category es una categoría de items del kiosco. Los items la referencian por
+// su nombre, que no se repite entre categorías (sin distinguir mayúsculas).
+type Category struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Validate valida que los campos requeridos de la categoría estén presentes
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return ErrInvalidCategoryName
+	}
+	return nil
+}
+
+// CategorySummary resume los items de una categoría
+type CategorySummary struct {
+	CategoryID string `json:"category_id"`
+	Name       string `json:"name"`
+	ItemCount  int    `json:"item_count"`
+	// StockValues es la suma del precio por el stock de sus items, un monto
+	// por moneda ordenados por moneda. Sin items está vacío.
+	StockValues []Money `json:"stock_values"`
+}
+
+// Add suma el item al resumen
+func (s *CategorySummary) Add(item *Item) {
+	s.ItemCount++
+	s.AddStockValue(item.Price.Times(item.Stock))
+}
+
+// AddStockValue suma un monto al valor del stock en su moneda
+func (s *CategorySummary) AddStockValue(value Money) {
+	for i := range s.StockValues {
+		if s.StockValues[i].Currency == value.Currency {
+			s.StockValues[i].Amount += value.Amount
+			return
+		}
+	}
+	position := sort.Search(len(s.StockValues), func(i int) bool {
+		return s.StockValues[i].Currency > value.Currency
+	})
+	s.StockValues = slices.Insert(s.StockValues, position, value)
+}
+
+// CategoryRepository define el puerto (interfaz) de las categorías. Los
+// adaptadores que lo implementan también implementan ItemRepository sobre los
+// mismos datos: Create y Update de items devuelven ErrUnknownItemCategory si
+// la categoría del item no está registrada.
+type CategoryRepository interface {
+	// ListCategories obtiene todas las categorías ordenadas por nombre
+	ListCategories(ctx context.Context) ([]*Category, error)
+
+	// GetCategory obtiene una categoría por su ID
+	GetCategory(ctx context.Context, id string) (*Category, error)
+
+	// CreateCategory crea una categoría con el ID que trae asignado. Devuelve
+	// ErrCategoryAlreadyExists si el ID existe o si otra categoría tiene el
+	// mismo nombre (sin distinguir mayúsculas)
+	CreateCategory(ctx context.Context, category *Category) (*Category, error)
+
+	// UpdateCategory actualiza una categoría existente. Si cambia el nombre,
+	// sus items pasan al nuevo en la misma operación atómica y aumentan su
+	// versión. Devuelve ErrCategoryAlreadyExists si otra categoría tiene el
+	// nuevo nombre
+	UpdateCategory(ctx context.Context, id string, category *Category) (*Category, error)
+
+	// DeleteCategory elimina una categoría por su ID. Devuelve
+	// ErrCategoryInUse si algún item la referencia; la verificación y el
+	// borrado son atómicos
+	DeleteCategory(ctx context.Context, id string) error
+
+	// SummarizeCategories obtiene el resumen de cada categoría, incluidas las
+	// que no tienen items, ordenadas por nombre
+	SummarizeCategories(ctx context.Context) ([]*CategorySummary, error)
+}
@@ -3,9 +3,49 @@ package domain
 import "errors"
 
 var (
-	ErrItemNotFound      = errors.New("item no encontrado")
-	ErrInvalidItemName   = errors.New("el nombre del item es requerido")
-	ErrInvalidItemPrice  = errors.New("el precio del item debe ser mayor o igual a cero")
-	ErrInvalidItemStock  = errors.New("el stock del item debe ser mayor o igual a cero")
-	ErrItemAlreadyExists = errors.New("el item ya existe")
+	ErrItemNotFound        = errors.New("item no encontrado")
+	ErrInvalidItemName     = errors.New("el nombre del item es requerido")
+	ErrInvalidItemPrice    = errors.New("el precio del item debe ser mayor o igual a cero")
+	ErrInvalidItemStock    = errors.New("el stock del item debe ser mayor o igual a cero")
+	ErrItemAlreadyExists   = errors.New("ya existe un item con ese nombre en la categoría")
+	ErrItemIDNotAllowed    = errors.New("el ID del item lo asigna el servidor")
+	ErrInvalidItemQuery    = errors.New("consulta de items inválida")
+	ErrVersionRequired     = errors.New("se requiere la versión del item que se leyó (If-Match)")
+	ErrVersionMismatch     = errors.New("el item cambió desde que se leyó; vuelva a obtenerlo")
+	ErrInvalidPatch        = errors.New("patch inválido")
+	ErrUnknownItemCategory = errors.New("la categoría del item no existe")
+	ErrItemStockReadOnly   = errors.New("el stock solo cambia con movimientos: registre uno con POST /api/items/{id}/movements")
+
+	ErrCategoryNotFound      = errors.New("categoría no encontrada")
+	ErrInvalidCategoryName   = errors.New("el nombre de la categoría es requerido")
+	ErrCategoryAlreadyExists = errors.New("ya existe una categoría con ese nombre")
+	ErrCategoryIDNotAllowed  = errors.New("el ID de la categoría lo asigna el servidor")
+	ErrCategoryInUse         = errors.New("la categoría tiene items; cámbielos de categoría antes de eliminarla")
+
+	ErrInvalidMovementType     = errors.New("el tipo de movimiento debe ser sale, restock, adjustment o return")
+	ErrInvalidMovementQuantity = errors.New("la cantidad del movimiento debe ser mayor que cero (distinta de cero en los ajustes)")
+	ErrMovementReasonRequired  = errors.New("los ajustes de stock requieren un motivo")
+	ErrMovementActorRequired   = errors.New("el responsable del movimiento es requerido")
+	ErrInsufficientStock       = errors.New("stock insuficiente")
+
+	ErrOrderNotFound         = errors.New("orden no encontrada")
+	ErrOrderAlreadyExists    = errors.New("la orden ya existe")
+	ErrOrderWithoutLines     = errors.New("la orden debe tener al menos una línea")
+	ErrOrderLineItemRequired = errors.New("cada línea de la orden requiere un item_id")
+	ErrInvalidOrderQuantity  = errors.New("la cantidad de cada línea debe ser mayor que cero")
+	ErrInvalidOrderDiscount  = errors.New("el descuento debe estar entre cero y el subtotal de la orden")
+	ErrInvalidTaxRate        = errors.New("la alícuota de impuestos debe estar entre 0 y 1")
+	ErrInvalidPaymentMethod  = errors.New("el medio de pago debe ser cash, debit_card, credit_card o transfer")
+	ErrOrderCashierRequired  = errors.New("el cajero de la orden es requerido")
+	ErrOrderAlreadyVoided    = errors.New("la orden ya está anulada")
+	ErrVoidReasonRequired    = errors.New("la anulación requiere un motivo")
+	ErrVoidActorRequired     = errors.New("el responsable de la anulación es requerido")
+	ErrInvalidOrderQuery     = errors.New("consulta de órdenes inválida")
+	ErrOrderCurrencyMismatch = errors.New("todos los items y el descuento de la orden deben estar en la misma moneda")
+
+	ErrInvalidCurrency     = errors.New("la moneda debe ser un código ISO-4217 admitido")
+	ErrInvalidAmount       = errors.New("monto inválido")
+	ErrCurrencyMismatch    = errors.New("no se pueden combinar montos en monedas distintas")
+	ErrInvalidExchangeRate = errors.New("cotización inválida")
+	ErrUnknownExchangeRate = errors.New("no hay cotización para convertir el monto")
 )
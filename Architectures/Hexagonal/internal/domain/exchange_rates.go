@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ExchangeRates guarda cuánto vale una unidad de cada moneda en la moneda
+// base. Convierte entre dos monedas cualesquiera pasando por la base.
+type ExchangeRates struct {
+	rates map[string]*big.Rat
+}
+
+// NewExchangeRates crea las cotizaciones a partir de números decimales: con
+// base "ARS", {"USD": "1050.5"} significa que un dólar vale 1050,50 pesos
+func NewExchangeRates(base string, rates map[string]string) (*ExchangeRates, error) {
+	if !ValidCurrency(base) {
+		return nil, fmt.Errorf("%w: moneda base %q", ErrInvalidCurrency, base)
+	}
+
+	parsed := map[string]*big.Rat{base: big.NewRat(1, 1)}
+	for currency, value := range rates {
+		if !ValidCurrency(currency) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: la cotización de %s debe ser un número mayor que cero", ErrInvalidExchangeRate, currency)
+		}
+		if currency == base && rate.Cmp(parsed[base]) != 0 {
+			return nil, fmt.Errorf("%w: la cotización de la moneda base debe ser 1", ErrInvalidExchangeRate)
+		}
+		parsed[currency] = rate
+	}
+
+	return &ExchangeRates{rates: parsed}, nil
+}
+
+// Convert expresa el monto en otra moneda, redondeado a su unidad menor.
+// Devuelve ErrUnknownExchangeRate si falta la cotización de alguna de las
+// dos monedas. Sin cotizaciones (nil) solo convierte a la misma moneda.
+func (r *ExchangeRates) Convert(m Money, currency string) (Money, error) {
+	if m.Currency == currency {
+		return m, nil
+	}
+
+	var from, to *big.Rat
+	if r != nil {
+		from, to = r.rates[m.Currency], r.rates[currency]
+	}
+	if from == nil || to == nil {
+		return Money{}, fmt.Errorf("%w: de %s a %s", ErrUnknownExchangeRate, m.Currency, currency)
+	}
+
+	// amount / 10^origen * from / to * 10^destino
+	converted := new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(currencyExponents[m.Currency]))
+	converted.Mul(converted, from)
+	converted.Quo(converted, to)
+	converted.Mul(converted, new(big.Rat).SetInt(pow10(currencyExponents[currency])))
+	return Money{Amount: roundRat(converted), Currency: currency}, nil
+}
+
+// DisplayCurrency es la moneda en la que se muestran los montos junto con
+// las cotizaciones para convertirlos
+type DisplayCurrency struct {
+	Currency string
+	// Rates puede ser nil: entonces solo se muestran los montos que ya están
+	// en Currency
+	Rates *ExchangeRates
+}
+
+// NewDisplayCurrency valida la moneda de visualización
+func NewDisplayCurrency(currency string, rates *ExchangeRates) (*DisplayCurrency, error) {
+	if !ValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: moneda de visualización %q", ErrInvalidCurrency, currency)
+	}
+	return &DisplayCurrency{Currency: currency, Rates: rates}, nil
+}
+
+// Convert expresa el monto en la moneda de visualización
+func (d *DisplayCurrency) Convert(m Money) (Money, error) {
+	return d.Rates.Convert(m, d.Currency)
+}
+
+// Sum convierte los montos a la moneda de visualización y los suma. Sin
+// montos devuelve cero.
+func (d *DisplayCurrency) Sum(values []Money) (Money, error) {
+	total := NewMoney(0, d.Currency)
+	for _, value := range values {
+		converted, err := d.Convert(value)
+		if err != nil {
+			return Money{}, err
+		}
+		total.Amount += converted.Amount
+	}
+	return total, nil
+}
@@ -0,0 +1,7 @@
+package domain
+
+// IDGenerator define el puerto (interfaz) que genera los IDs de las entidades
+type IDGenerator interface {
+	// NewID devuelve un ID nuevo y único
+	NewID() string
+}
@@ -7,21 +7,32 @@ type Item struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
-	Price       float64   `json:"price"`
+	Price       Money     `json:"price"`
 	Stock       int       `json:"stock"`
 	Category    string    `json:"category"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Version empieza en 1 y aumenta con cada cambio del item, incluidos los
+	// de stock. La asigna el repositorio: en Update indica la versión que el
+	// cliente leyó y que todavía debe estar vigente.
+	Version int64 `json:"version"`
 }
 
-// Validate valida que los campos requeridos del Item estén presentes
+// Validate valida que los campos requeridos del Item estén presentes. Un
+// item sin precio queda en cero en DefaultCurrency.
 func (i *Item) Validate() error {
 	if i.Name == "" {
 		return ErrInvalidItemName
 	}
-	if i.Price < 0 {
+	if i.Price == (Money{}) {
+		i.Price.Currency = DefaultCurrency
+	}
+	if i.Price.Amount < 0 {
 		return ErrInvalidItemPrice
 	}
+	if err := i.Price.Validate(); err != nil {
+		return err
+	}
 	if i.Stock < 0 {
 		return ErrInvalidItemStock
 	}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Campos por los que se pueden ordenar los listados de items
+const (
+	SortByCreatedAt = "created_at"
+	SortByName      = "name"
+	SortByPrice     = "price" // por moneda y, dentro de cada moneda, por importe
+	SortByStock     = "stock"
+)
+
+const (
+	// DefaultItemLimit es el tamaño de página si la consulta no indica uno
+	DefaultItemLimit = 50
+	// MaxItemLimit es el tamaño de página máximo
+	MaxItemLimit = 200
+)
+
+// ItemQuery describe un listado de items: filtros, orden y página.
+// Los filtros vacíos no se aplican.
+type ItemQuery struct {
+	// Category filtra por categoría, sin distinguir mayúsculas
+	Category string
+	// NameContains filtra los items cuyo nombre contiene el texto, sin distinguir mayúsculas
+	NameContains string
+	// MinPrice y MaxPrice filtran por precio; solo cumplen los items con
+	// precio en la moneda del filtro
+	MinPrice    *Money
+	MaxPrice    *Money
+	InStockOnly bool
+
+	SortBy     string
+	Descending bool
+
+	Limit int
+	// Cursor es el NextCursor de la página anterior. Es opaco: lo genera y lo
+	// interpreta el adaptador de salida
+	Cursor string
+}
+
+// ItemPage es una página de un listado de items
+type ItemPage struct {
+	Items []*Item `json:"items"`
+	// NextCursor está vacío en la última página
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total es la cantidad de items que cumplen los filtros, en todas las páginas
+	Total int `json:"total"`
+}
+
+// ItemCursor guarda la posición del último item de una página: la clave de
+// orden y el ID, que desempata
+type ItemCursor struct {
+	SortBy     string    `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	ID         string    `json:"i"`
+	Name       string    `json:"n,omitempty"`
+	Price      Money     `json:"p"`
+	Stock      int       `json:"k,omitempty"`
+	CreatedAt  time.Time `json:"c"`
+}
+
+// Normalize valida la consulta y completa los valores por defecto
+func (q *ItemQuery) Normalize() error {
+	switch q.SortBy {
+	case "":
+		q.SortBy = SortByCreatedAt
+	case SortByCreatedAt, SortByName, SortByPrice, SortByStock:
+	default:
+		return fmt.Errorf("%w: no se puede ordenar por %q (use %s, %s, %s o %s)",
+			ErrInvalidItemQuery, q.SortBy, SortByCreatedAt, SortByName, SortByPrice, SortByStock)
+	}
+
+	for _, price := range []*Money{q.MinPrice, q.MaxPrice} {
+		if price == nil {
+			continue
+		}
+		if err := price.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidItemQuery, err)
+		}
+		if price.Amount < 0 {
+			return fmt.Errorf("%w: el rango de precios no admite valores negativos", ErrInvalidItemQuery)
+		}
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil {
+		if q.MinPrice.Currency != q.MaxPrice.Currency {
+			return fmt.Errorf("%w: el precio mínimo y el máximo deben estar en la misma moneda", ErrInvalidItemQuery)
+		}
+		if q.MinPrice.Amount > q.MaxPrice.Amount {
+			return fmt.Errorf("%w: el precio mínimo supera al máximo", ErrInvalidItemQuery)
+		}
+	}
+
+	switch {
+	case q.Limit < 0:
+		return fmt.Errorf("%w: el límite debe ser mayor que cero", ErrInvalidItemQuery)
+	case q.Limit == 0:
+		q.Limit = DefaultItemLimit
+	case q.Limit > MaxItemLimit:
+		q.Limit = MaxItemLimit
+	}
+
+	return nil
+}
+
+// After decodifica un cursor generado con NextCursor: los adaptadores que lo
+// usan devuelven los items que siguen a esa posición. Sin cursor devuelve nil.
+func (q *ItemQuery) After() (*ItemCursor, error) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+
+	var c ItemCursor
+	data, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: cursor inválido", ErrInvalidItemQuery)
+	}
+	if c.SortBy != q.SortBy || c.Descending != q.Descending {
+		return nil, fmt.Errorf("%w: el cursor pertenece a otro orden", ErrInvalidItemQuery)
+	}
+	return &c, nil
+}
+
+// NextCursor devuelve el cursor que continúa el listado después del item
+func (q *ItemQuery) NextCursor(last *Item) string {
+	data, _ := json.Marshal(ItemCursor{
+		SortBy:     q.SortBy,
+		Descending: q.Descending,
+		ID:         last.ID,
+		Name:       last.Name,
+		Price:      last.Price,
+		Stock:      last.Stock,
+		CreatedAt:  last.CreatedAt,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
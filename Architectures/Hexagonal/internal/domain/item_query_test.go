@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestItemQueryNormalizeDefaults(t *testing.T) {
+	query := ItemQuery{}
+	if err := query.Normalize(); err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if query.SortBy != SortByCreatedAt || query.Limit != DefaultItemLimit {
+		t.Fatalf("sort = %q, limit = %d; se esperaba %q y %d", query.SortBy, query.Limit, SortByCreatedAt, DefaultItemLimit)
+	}
+
+	query = ItemQuery{Limit: MaxItemLimit + 1}
+	if err := query.Normalize(); err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if query.Limit != MaxItemLimit {
+		t.Fatalf("limit = %d, se esperaba %d", query.Limit, MaxItemLimit)
+	}
+}
+
+func TestItemQueryNormalizeRejectsInvalidQueries(t *testing.T) {
+	low, high, negative := NewMoney(10000, "ARS"), NewMoney(5000, "ARS"), NewMoney(-100, "ARS")
+	dollars, unknown := NewMoney(20000, "USD"), NewMoney(100, "XXX")
+
+	for name, query := range map[string]ItemQuery{
+		"orden desconocido":  {SortBy: "color"},
+		"precio negativo":    {MinPrice: &negative},
+		"rango invertido":    {MinPrice: &low, MaxPrice: &high},
+		"monedas distintas":  {MinPrice: &low, MaxPrice: &dollars},
+		"moneda desconocida": {MaxPrice: &unknown},
+		"límite negativo":    {Limit: -1},
+	} {
+		if err := query.Normalize(); !errors.Is(err, ErrInvalidItemQuery) {
+			t.Errorf("%s: se esperaba ErrInvalidItemQuery, se obtuvo %v", name, err)
+		}
+	}
+}
+
+func TestItemQueryCursorRoundTrip(t *testing.T) {
+	query := ItemQuery{SortBy: SortByPrice, Descending: true}
+	query.Cursor = query.NextCursor(&Item{ID: "item-1", Price: NewMoney(15050, "USD")})
+
+	after, err := query.After()
+	if err != nil {
+		t.Fatalf("After: %v", err)
+	}
+	if after.ID != "item-1" || after.Price != NewMoney(15050, "USD") {
+		t.Fatalf("cursor = %+v", after)
+	}
+
+	// Un cursor no sirve para otro orden
+	query.Descending = false
+	if _, err := query.After(); !errors.Is(err, ErrInvalidItemQuery) {
+		t.Fatalf("se esperaba ErrInvalidItemQuery, se obtuvo %v", err)
+	}
+}
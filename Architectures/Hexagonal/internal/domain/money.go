@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency es la moneda de los precios que no indican una, como los
+// precios con decimales de la versión anterior de la API
+const DefaultCurrency = "ARS"
+
+// currencyExponents son las monedas ISO-4217 admitidas y la cantidad de
+// decimales de cada una: una unidad equivale a 10^exponente unidades menores
+var currencyExponents = map[string]int{
+	"ARS": 2,
+	"BOB": 2,
+	"BRL": 2,
+	"CLP": 0,
+	"COP": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"MXN": 2,
+	"PEN": 2,
+	"PYG": 0,
+	"USD": 2,
+	"UYU": 2,
+}
+
+// Money es un monto exacto en una moneda. Amount está en la unidad menor de
+// la moneda, por ejemplo centavos: 150,50 pesos son Money{15050, "ARS"}.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney crea un monto a partir de unidades menores
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney interpreta un número decimal, como "150.5", en unidades de la
+// moneda. Devuelve ErrInvalidAmount si tiene más decimales de los que admite
+// la moneda.
+func ParseMoney(value, currency string) (Money, error) {
+	exponent, ok := currencyExponents[currency]
+	if !ok {
+		return Money{}, ErrInvalidCurrency
+	}
+
+	amount, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q no es un número", ErrInvalidAmount, value)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt(pow10(exponent)))
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s admite hasta %d decimales", ErrInvalidAmount, currency, exponent)
+	}
+	return Money{Amount: amount.Num().Int64(), Currency: currency}, nil
+}
+
+// ValidCurrency indica si la moneda es un código ISO-4217 admitido
+func ValidCurrency(currency string) bool {
+	_, ok := currencyExponents[currency]
+	return ok
+}
+
+// Validate valida que la moneda sea un código ISO-4217 admitido
+func (m Money) Validate() error {
+	if !ValidCurrency(m.Currency) {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+// IsZero indica si el monto es cero, en cualquier moneda
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add suma dos montos de la misma moneda
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub resta dos montos de la misma moneda
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Times multiplica el monto por una cantidad de unidades
+func (m Money) Times(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+// MulRate multiplica el monto por una alícuota, por ejemplo 0.21, y redondea
+// a la unidad menor. La alícuota se toma con los decimales con que se
+// escribe, no con su aproximación binaria.
+func (m Money) MulRate(rate float64) Money {
+	product, _ := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	product.Mul(product, new(big.Rat).SetInt64(m.Amount))
+	return Money{Amount: roundRat(product), Currency: m.Currency}
+}
+
+// Compare ordena los montos por moneda y, dentro de la moneda, por importe
+func (m Money) Compare(other Money) int {
+	if c := strings.Compare(m.Currency, other.Currency); c != 0 {
+		return c
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Decimal escribe el monto en unidades de la moneda, por ejemplo "150.50"
+func (m Money) Decimal() string {
+	exponent := currencyExponents[m.Currency]
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(exponent)).FloatString(exponent)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// UnmarshalJSON acepta {"amount": 15050, "currency": "ARS"} y, por
+// compatibilidad, un número con decimales en DefaultCurrency, como 150.5.
+// Sin moneda, el monto está en DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && (data[0] == '-' || data[0] >= '0' && data[0] <= '9') {
+		parsed, err := ParseMoney(string(data), DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	type money Money
+	var decoded money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Currency == "" {
+		decoded.Currency = DefaultCurrency
+	}
+	*m = Money(decoded)
+	return nil
+}
+
+// pow10 devuelve 10^n
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundRat redondea al entero más cercano; las mitades se alejan del cero
+func roundRat(value *big.Rat) int64 {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(value.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(value.Sign())))
+	}
+	return quotient.Int64()
+}
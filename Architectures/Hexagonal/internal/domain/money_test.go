@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Money
+		err      error
+	}{
+		{"150.5", "ARS", NewMoney(15050, "ARS"), nil},
+		{"0.1", "USD", NewMoney(10, "USD"), nil},
+		{"1e3", "ARS", NewMoney(100000, "ARS"), nil},
+		{"1500", "CLP", NewMoney(1500, "CLP"), nil},
+		{"150.555", "ARS", Money{}, ErrInvalidAmount},
+		{"1500.5", "CLP", Money{}, ErrInvalidAmount},
+		{"abc", "ARS", Money{}, ErrInvalidAmount},
+		{"10", "XXX", Money{}, ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value, tt.currency)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("ParseMoney(%q, %s) = %v, %v; se esperaba %v, %v", tt.value, tt.currency, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var item Item
+	if err := json.Unmarshal([]byte(`{"name": "Coca Cola", "price": {"amount": 15050, "currency": "USD"}}`), &item); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if item.Price != NewMoney(15050, "USD") {
+		t.Fatalf("precio = %v, se esperaba 150.50 USD", item.Price)
+	}
+
+	data, err := json.Marshal(item.Price)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `{"amount":15050,"currency":"USD"}` {
+		t.Fatalf("JSON = %s", data)
+	}
+
+	// Los precios con decimales de la versión anterior están en DefaultCurrency
+	for body, want := range map[string]Money{
+		`{"price": 150.5}`:             NewMoney(15050, DefaultCurrency),
+		`{"price": 0.3}`:               NewMoney(30, DefaultCurrency),
+		`{"price": {"amount": 15050}}`: NewMoney(15050, DefaultCurrency),
+	} {
+		item = Item{}
+		if err := json.Unmarshal([]byte(body), &item); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", body, err)
+		}
+		if item.Price != want {
+			t.Errorf("%s: precio = %v, se esperaba %v", body, item.Price, want)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"price": 150.555}`), &item); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("se esperaba ErrInvalidAmount, se obtuvo %v", err)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := NewMoney(15050, "ARS")
+
+	if got := price.Times(3); got != NewMoney(45150, "ARS") {
+		t.Fatalf("Times = %v", got)
+	}
+	if got, err := price.Add(NewMoney(50, "ARS")); err != nil || got != NewMoney(15100, "ARS") {
+		t.Fatalf("Add = %v, %v", got, err)
+	}
+	if _, err := price.Add(NewMoney(50, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("se esperaba ErrCurrencyMismatch, se obtuvo %v", err)
+	}
+
+	// 691,50 * 0,21 = 145,215: la mitad se redondea hacia arriba
+	if got := NewMoney(69150, "ARS").MulRate(0.21); got != NewMoney(14522, "ARS") {
+		t.Fatalf("MulRate = %v, se esperaba 145.22 ARS", got)
+	}
+	if got := NewMoney(-69150, "ARS").MulRate(0.21); got != NewMoney(-14522, "ARS") {
+		t.Fatalf("MulRate negativo = %v, se esperaba -145.22 ARS", got)
+	}
+
+	if got := NewMoney(15050, "ARS").String(); got != "150.50 ARS" {
+		t.Fatalf("String = %q", got)
+	}
+	if got := NewMoney(1500, "CLP").String(); got != "1500 CLP" {
+		t.Fatalf("String = %q", got)
+	}
+}
+
+func TestExchangeRatesConvert(t *testing.T) {
+	rates, err := NewExchangeRates("ARS", map[string]string{"USD": "1050.5", "CLP": "1.1"})
+	if err != nil {
+		t.Fatalf("NewExchangeRates: %v", err)
+	}
+
+	tests := []struct {
+		from     Money
+		currency string
+		want     Money
+	}{
+		{NewMoney(200, "USD"), "ARS", NewMoney(210100, "ARS")},
+		{NewMoney(210100, "ARS"), "USD", NewMoney(200, "USD")},
+		{NewMoney(1000, "CLP"), "ARS", NewMoney(110000, "ARS")},
+		// 1000 CLP = 1100 ARS = 1,0471... USD
+		{NewMoney(1000, "CLP"), "USD", NewMoney(105, "USD")},
+		{NewMoney(15050, "EUR"), "EUR", NewMoney(15050, "EUR")},
+	}
+	for _, tt := range tests {
+		got, err := rates.Convert(tt.from, tt.currency)
+		if err != nil || got != tt.want {
+			t.Errorf("Convert(%v, %s) = %v, %v; se esperaba %v", tt.from, tt.currency, got, err, tt.want)
+		}
+	}
+
+	if _, err := rates.Convert(NewMoney(100, "EUR"), "ARS"); !errors.Is(err, ErrUnknownExchangeRate) {
+		t.Fatalf("se esperaba ErrUnknownExchangeRate, se obtuvo %v", err)
+	}
+
+	// Sin cotizaciones solo se convierte a la misma moneda
+	var none *ExchangeRates
+	if _, err := none.Convert(NewMoney(100, "USD"), "ARS"); !errors.Is(err, ErrUnknownExchangeRate) {
+		t.Fatalf("se esperaba ErrUnknownExchangeRate, se obtuvo %v", err)
+	}
+
+	for name, rates := range map[string]map[string]string{
+		"moneda desconocida":     {"XXX": "1"},
+		"cotización negativa":    {"USD": "-1"},
+		"cotización no numérica": {"USD": "mil"},
+	} {
+		if _, err := NewExchangeRates("ARS", rates); err == nil {
+			t.Errorf("%s: se esperaba un error", name)
+		}
+	}
+}
+
+func TestDisplayCurrencySum(t *testing.T) {
+	rates, err := NewExchangeRates("ARS", map[string]string{"USD": "1000"})
+	if err != nil {
+		t.Fatalf("NewExchangeRates: %v", err)
+	}
+	display, err := NewDisplayCurrency("ARS", rates)
+	if err != nil {
+		t.Fatalf("NewDisplayCurrency: %v", err)
+	}
+
+	total, err := display.Sum([]Money{NewMoney(150, "USD"), NewMoney(50000, "ARS")})
+	if err != nil || total != NewMoney(200000, "ARS") {
+		t.Fatalf("Sum = %v, %v; se esperaba 2000.00 ARS", total, err)
+	}
+
+	if _, err := NewDisplayCurrency("pesos", rates); !errors.Is(err, ErrInvalidCurrency) {
+		t.Fatalf("se esperaba ErrInvalidCurrency, se obtuvo %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Estados de una orden
+const (
+	OrderCompleted = "completed"
+	OrderVoided    = "voided"
+)
+
+// Medios de pago aceptados
+const (
+	PaymentCash       = "cash"
+	PaymentDebitCard  = "debit_card"
+	PaymentCreditCard = "credit_card"
+	PaymentTransfer   = "transfer"
+)
+
+const (
+	// DefaultOrderLimit es la cantidad de órdenes que lista ListOrders por defecto
+	DefaultOrderLimit = 50
+	// MaxOrderLimit es la cantidad máxima de órdenes por listado
+	MaxOrderLimit = 200
+)
+
+// Order representa una venta en el mostrador: varios items cobrados juntos
+type Order struct {
+	ID     string      `json:"id"`
+	Status string      `json:"status"`
+	Lines  []OrderLine `json:"lines"`
+
+	// Los montos están en la moneda de los items de la orden, que es una sola
+	Subtotal Money `json:"subtotal"`
+	// Discount es un monto que se descuenta del subtotal
+	Discount Money `json:"discount"`
+	// TaxRate es la alícuota aplicada sobre el subtotal con descuento, por ejemplo 0.21
+	TaxRate float64 `json:"tax_rate"`
+	Taxes   Money   `json:"taxes"`
+	Total   Money   `json:"total"`
+
+	PaymentMethod string    `json:"payment_method"`
+	Cashier       string    `json:"cashier"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	VoidedAt   *time.Time `json:"voided_at,omitempty"`
+	VoidedBy   string     `json:"voided_by,omitempty"`
+	VoidReason string     `json:"void_reason,omitempty"`
+}
+
+// OrderLine es un item de la orden con el precio y el nombre que tenía al venderse
+type OrderLine struct {
+	ItemID    string `json:"item_id"`
+	ItemName  string `json:"item_name"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice Money  `json:"unit_price"`
+	LineTotal Money  `json:"line_total"`
+}
+
+// OrderQuery describe un listado de órdenes, de la más reciente a la más antigua
+type OrderQuery struct {
+	// Status filtra por estado; vacío lista todas
+	Status string
+	Limit  int
+}
+
+// OrderLineError explica por qué no se puede vender una línea de la orden
+type OrderLineError struct {
+	// Line es la posición de la línea en la orden, desde 1
+	Line      int    `json:"line"`
+	ItemID    string `json:"item_id"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+	Reason    string `json:"reason"`
+}
+
+// OrderStockError rechaza una orden completa e indica cada línea que no se
+// puede vender
+type OrderStockError struct {
+	Lines []OrderLineError
+}
+
+func (e *OrderStockError) Error() string {
+	reasons := make([]string, len(e.Lines))
+	for i, line := range e.Lines {
+		reasons[i] = fmt.Sprintf("línea %d (%s): %s", line.Line, line.ItemID, line.Reason)
+	}
+	return "no se puede completar la orden: " + strings.Join(reasons, "; ")
+}
+
+// Validate valida los datos que envía el cliente al crear la orden
+func (o *Order) Validate() error {
+	if len(o.Lines) == 0 {
+		return ErrOrderWithoutLines
+	}
+	for _, line := range o.Lines {
+		if line.ItemID == "" {
+			return ErrOrderLineItemRequired
+		}
+		if line.Quantity <= 0 {
+			return ErrInvalidOrderQuantity
+		}
+	}
+	if o.Discount.Amount < 0 {
+		return ErrInvalidOrderDiscount
+	}
+	if !o.Discount.IsZero() {
+		if err := o.Discount.Validate(); err != nil {
+			return err
+		}
+	}
+	if o.TaxRate < 0 || o.TaxRate >= 1 {
+		return ErrInvalidTaxRate
+	}
+	switch o.PaymentMethod {
+	case PaymentCash, PaymentDebitCard, PaymentCreditCard, PaymentTransfer:
+	default:
+		return ErrInvalidPaymentMethod
+	}
+	if o.Cashier == "" {
+		return ErrOrderCashierRequired
+	}
+	return nil
+}
+
+// MergeLines junta las líneas del mismo item conservando el orden en que
+// aparece cada item por primera vez
+func (o *Order) MergeLines() {
+	merged := make([]OrderLine, 0, len(o.Lines))
+	positions := make(map[string]int, len(o.Lines))
+	for _, line := range o.Lines {
+		if i, ok := positions[line.ItemID]; ok {
+			merged[i].Quantity += line.Quantity
+			continue
+		}
+		positions[line.ItemID] = len(merged)
+		merged = append(merged, OrderLine{ItemID: line.ItemID, Quantity: line.Quantity})
+	}
+	o.Lines = merged
+}
+
+// CalculateTotals calcula los totales de las líneas y de la orden a partir
+// de los precios unitarios; los impuestos se redondean a la unidad menor de
+// la moneda. Los adaptadores lo llaman después de tomar los precios dentro de
+// la operación que descuenta el stock. Devuelve ErrOrderCurrencyMismatch si
+// los items o el descuento están en monedas distintas.
+func (o *Order) CalculateTotals() error {
+	currency := o.Lines[0].UnitPrice.Currency
+	o.Subtotal = NewMoney(0, currency)
+	for i := range o.Lines {
+		line := &o.Lines[i]
+		line.LineTotal = line.UnitPrice.Times(line.Quantity)
+		subtotal, err := o.Subtotal.Add(line.LineTotal)
+		if err != nil {
+			return ErrOrderCurrencyMismatch
+		}
+		o.Subtotal = subtotal
+	}
+
+	// Un descuento en cero vale en cualquier moneda
+	if o.Discount.IsZero() {
+		o.Discount = NewMoney(0, currency)
+	}
+	discounted, err := o.Subtotal.Sub(o.Discount)
+	if err != nil {
+		return ErrOrderCurrencyMismatch
+	}
+	if discounted.Amount < 0 {
+		return ErrInvalidOrderDiscount
+	}
+
+	o.Taxes = discounted.MulRate(o.TaxRate)
+	o.Total, _ = discounted.Add(o.Taxes)
+	return nil
+}
+
+// SaleMovement es el movimiento de stock que registra la venta de una línea
+func (o *Order) SaleMovement(line OrderLine, stockAfter int) *StockMovement {
+	return &StockMovement{
+		ItemID:     line.ItemID,
+		Type:       MovementSale,
+		Quantity:   line.Quantity,
+		Reason:     "orden " + o.ID,
+		Actor:      o.Cashier,
+		StockAfter: stockAfter,
+		CreatedAt:  o.CreatedAt,
+	}
+}
+
+// ReturnMovement es el movimiento de stock que devuelve una línea al anular la orden
+func (o *Order) ReturnMovement(line OrderLine, stockAfter int) *StockMovement {
+	return &StockMovement{
+		ItemID:     line.ItemID,
+		Type:       MovementReturn,
+		Quantity:   line.Quantity,
+		Reason:     "anulación de la orden " + o.ID,
+		Actor:      o.VoidedBy,
+		StockAfter: stockAfter,
+		CreatedAt:  *o.VoidedAt,
+	}
+}
+
+// Normalize valida la consulta y completa los valores por defecto
+func (q *OrderQuery) Normalize() error {
+	switch q.Status {
+	case "", OrderCompleted, OrderVoided:
+	default:
+		return fmt.Errorf("%w: el estado debe ser %s o %s", ErrInvalidOrderQuery, OrderCompleted, OrderVoided)
+	}
+
+	switch {
+	case q.Limit < 0:
+		return fmt.Errorf("%w: el límite debe ser mayor que cero", ErrInvalidOrderQuery)
+	case q.Limit == 0:
+		q.Limit = DefaultOrderLimit
+	case q.Limit > MaxOrderLimit:
+		q.Limit = MaxOrderLimit
+	}
+	return nil
+}
+
+// OrderRepository define el puerto (interfaz) para el repositorio de órdenes.
+// Los adaptadores que lo implementan también implementan ItemRepository y
+// StockMovementRepository sobre los mismos datos.
+type OrderRepository interface {
+	// PlaceOrder guarda la orden y descuenta el stock de todas sus líneas en
+	// una misma operación atómica. Toma el nombre y el precio de cada item,
+	// calcula los totales con CalculateTotals y registra un movimiento de
+	// venta por línea. Si alguna línea no se puede vender no cambia nada y
+	// devuelve un *OrderStockError con todas las líneas rechazadas.
+	PlaceOrder(ctx context.Context, order *Order) (*Order, error)
+
+	// GetOrder obtiene una orden por su ID
+	GetOrder(ctx context.Context, id string) (*Order, error)
+
+	// ListOrders obtiene las órdenes que cumplen la consulta, ya normalizada,
+	// de la más reciente a la más antigua
+	ListOrders(ctx context.Context, query *OrderQuery) ([]*Order, error)
+
+	// VoidOrder anula la orden y devuelve al stock sus líneas en una misma
+	// operación atómica, con un movimiento de devolución por línea. Devuelve
+	// ErrOrderNotFound si no existe y ErrOrderAlreadyVoided si ya estaba anulada.
+	VoidOrder(ctx context.Context, id, actor, reason string, at time.Time) (*Order, error)
+}
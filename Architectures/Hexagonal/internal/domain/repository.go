@@ -5,18 +5,28 @@ import "context"
 // ItemRepository define el puerto (interfaz) para el repositorio de items
 // Este es el contrato que debe cumplir cualquier adaptador de salida
 type ItemRepository interface {
-	// GetAll obtiene todos los items
-	GetAll(ctx context.Context) ([]*Item, error)
+	// GetAll obtiene una página de los items que cumplen la consulta, ya
+	// normalizada con ItemQuery.Normalize
+	GetAll(ctx context.Context, query *ItemQuery) (*ItemPage, error)
 	
 	// GetByID obtiene un item por su ID
 	GetByID(ctx context.Context, id string) (*Item, error)
 	
-	// Create crea un nuevo item
+	// Create crea un nuevo item con el ID que trae asignado y la versión 1.
+	// Devuelve ErrItemAlreadyExists si el ID ya existe o si otro item de la
+	// misma categoría tiene el mismo nombre (sin distinguir mayúsculas).
+	// La categoría se guarda con el nombre con que está registrada; si no
+	// existe devuelve ErrUnknownItemCategory (ver CategoryRepository)
 	Create(ctx context.Context, item *Item) (*Item, error)
 	
-	// Update actualiza un item existente
+	// Update actualiza un item existente si su versión sigue siendo
+	// item.Version, y la incrementa. La comparación y la escritura son
+	// atómicas. Devuelve ErrVersionMismatch si la versión cambió y
+	// ErrItemAlreadyExists si otro item de la misma categoría tiene el mismo
+	// nombre. La categoría se trata igual que en Create
 	Update(ctx context.Context, id string, item *Item) (*Item, error)
 	
-	// Delete elimina un item por su ID
-	Delete(ctx context.Context, id string) error
+	// Delete elimina un item por su ID si su versión sigue siendo version.
+	// Devuelve ErrVersionMismatch si la versión cambió
+	Delete(ctx context.Context, id string, version int64) error
 }
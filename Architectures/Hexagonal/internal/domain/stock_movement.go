@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Tipos de movimiento de stock
+const (
+	MovementSale       = "sale"
+	MovementRestock    = "restock"
+	MovementAdjustment = "adjustment"
+	MovementReturn     = "return"
+)
+
+const (
+	// SystemActor firma los movimientos que no registra una persona
+	SystemActor = "sistema"
+	// OpeningStockReason es el motivo del movimiento con el stock inicial de un item
+	OpeningStockReason = "stock inicial"
+)
+
+// StockMovement es un asiento del libro de stock de un item. El stock de un
+// item es la suma de sus movimientos.
+type StockMovement struct {
+	ID     int64  `json:"id"`
+	ItemID string `json:"item_id"`
+	Type   string `json:"type"`
+	// Quantity es positiva para ventas, reposiciones y devoluciones; en los
+	// ajustes lleva el signo de la corrección
+	Quantity int    `json:"quantity"`
+	Reason   string `json:"reason"`
+	Actor    string `json:"actor"`
+	// StockAfter es el stock del item después del movimiento
+	StockAfter int       `json:"stock_after"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Validate valida el tipo, la cantidad y los datos de auditoría del movimiento
+func (m *StockMovement) Validate() error {
+	switch m.Type {
+	case MovementSale, MovementRestock, MovementReturn:
+		if m.Quantity <= 0 {
+			return ErrInvalidMovementQuantity
+		}
+	case MovementAdjustment:
+		if m.Quantity == 0 {
+			return ErrInvalidMovementQuantity
+		}
+		if m.Reason == "" {
+			return ErrMovementReasonRequired
+		}
+	default:
+		return ErrInvalidMovementType
+	}
+	if m.Actor == "" {
+		return ErrMovementActorRequired
+	}
+	return nil
+}
+
+// Delta devuelve cuánto cambia el stock del item con el movimiento
+func (m *StockMovement) Delta() int {
+	if m.Type == MovementSale {
+		return -m.Quantity
+	}
+	return m.Quantity
+}
+
+// NewOpeningMovement crea el movimiento que registra el stock con que se
+// crea un item
+func NewOpeningMovement(item *Item) *StockMovement {
+	return &StockMovement{
+		ItemID:     item.ID,
+		Type:       MovementAdjustment,
+		Quantity:   item.Stock,
+		Reason:     OpeningStockReason,
+		Actor:      SystemActor,
+		StockAfter: item.Stock,
+		CreatedAt:  item.CreatedAt,
+	}
+}
+
+// StockMovementRepository define el puerto (interfaz) del libro de stock.
+// Los adaptadores que lo implementan también implementan ItemRepository sobre
+// los mismos datos: Create registra el stock inicial con NewOpeningMovement y
+// Update no modifica el stock.
+type StockMovementRepository interface {
+	// RecordMovement registra el movimiento y aplica su Delta al stock del item
+	// en una misma operación atómica, incrementando su versión. Devuelve
+	// ErrItemNotFound si el item no existe y ErrInsufficientStock si el stock
+	// quedaría negativo.
+	RecordMovement(ctx context.Context, movement *StockMovement) (*StockMovement, error)
+
+	// ListMovements obtiene los movimientos de un item, del más antiguo al más
+	// reciente. Devuelve ErrItemNotFound si el item no existe.
+	ListMovements(ctx context.Context, itemID string) ([]*StockMovement, error)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"mcp-server/internal/config"
+	"mcp-server/internal/interfaces"
+)
+
+// runConfig implements the config check command: it loads the configuration
+// the way serve does and reports every problem instead of starting
+func runConfig(opts *globalOptions, args []string) {
+	_, args = subcommand("config", args, "check")
+	flags := flag.NewFlagSet("config check", flag.ExitOnError)
+	opts.register(flags)
+	flags.Parse(args)
+
+	cfg, err := config.Load(opts.configPath, opts.profile)
+	if err != nil {
+		fail("Configuration error: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		fail("Configuration problems:\n%v", err)
+	}
+	container, err := interfaces.NewContainer(cfg)
+	if err != nil {
+		fail("Configuration error: %v", err)
+	}
+	registered, err := container.ListTools(context.Background())
+	if err != nil {
+		fail("Error listing tools: %v", err)
+	}
+
+	fmt.Printf("Profile:          %s\n", valueOrNone(cfg.Profile))
+	fmt.Printf("GitHub API:       %s\n", cfg.BaseURL)
+	fmt.Printf("Default owner:    %s\n", valueOrNone(cfg.DefaultOwner))
+	fmt.Printf("Repo aliases:     %d\n", len(cfg.RepoAliases))
+	fmt.Printf("Allowed repos:    %s\n", valueOrNone(strings.Join(cfg.AllowRepos, ", ")))
+	fmt.Printf("Denied repos:     %s\n", valueOrNone(strings.Join(cfg.DenyRepos, ", ")))
+	fmt.Printf("Policy file:      %s\n", valueOrNone(cfg.PolicyFile))
+	fmt.Printf("Export directory: %s\n", valueOrNone(cfg.ExportDir))
+	fmt.Printf("Read-only:        %t\n", cfg.ReadOnly)
+	fmt.Printf("Tools:            %d of %d registered\n", len(registered), len(container.Registry.Definitions()))
+	fmt.Println("Configuration OK")
+}
+
+// runAuth implements the auth test command: it checks the token against the
+// GitHub API and reports the user and rate limit
+func runAuth(opts *globalOptions, args []string) {
+	_, args = subcommand("auth", args, "test")
+	flags := flag.NewFlagSet("auth test", flag.ExitOnError)
+	opts.register(flags)
+	flags.Parse(args)
+
+	cfg, container := loadContainer(opts)
+	user, limit, err := container.CheckAuth()
+	if err != nil {
+		fail("Authentication against %s failed: %v", cfg.BaseURL, err)
+	}
+
+	fmt.Printf("Authenticated as %s on %s\n", user.Login, cfg.BaseURL)
+	if limit.Limit > 0 {
+		fmt.Printf("Rate limit: %d of %d requests remaining, resets at %s\n", limit.Remaining, limit.Limit, limit.Reset.Format("15:04:05"))
+	}
+}
+
+// fail prints a message to stderr and exits with status 1
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
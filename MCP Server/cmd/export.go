@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"mcp-server/internal/domain"
+)
+
+// runExport implements the export subcommand: it writes the issues matching
+// the filters to stdout or to the file given with -o
+func runExport(opts *globalOptions, args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	opts.register(flags)
+	owner := flags.String("owner", "", "Repository owner (default: the profile's default owner)")
+	repo := flags.String("repo", "", "Repository name, owner/repo pair or configured alias")
+	format := flags.String("format", domain.ExportFormatCSV, "Export format: csv, jsonl, markdown")
+	state := flags.String("state", "open", "Issue state: open, closed, all")
+	labels := flags.String("labels", "", "Comma-separated labels; issues must have all of them")
+	milestone := flags.String("milestone", "", "Milestone title, or none")
+	assignee := flags.String("assignee", "", "Assignee login, or none")
+	author := flags.String("author", "", "Author login")
+	since := flags.String("since", "", "Only issues updated on or after this date (YYYY-MM-DD)")
+	query := flags.String("query", "", "Extra GitHub search qualifiers")
+	groupBy := flags.String("group-by", domain.ExportGroupByLabel, "Grouping of the Markdown report: label, milestone, none")
+	limit := flags.Int("limit", domain.MaxExportIssues, "Maximum number of issues")
+	output := flags.String("o", "", "Output file (default: stdout)")
+	flags.Parse(args)
+
+	_, container := loadContainer(opts)
+
+	request := &domain.ExportIssuesRequest{
+		Owner:     *owner,
+		Repo:      *repo,
+		Format:    *format,
+		State:     *state,
+		Milestone: *milestone,
+		Assignee:  *assignee,
+		Author:    *author,
+		Since:     *since,
+		Query:     *query,
+		GroupBy:   *groupBy,
+		Limit:     *limit,
+	}
+	for _, label := range strings.Split(*labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			request.Labels = append(request.Labels, label)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Error creating %s: %v", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	buffered := bufio.NewWriter(w)
+
+	result, err := container.ExportService.ExportIssues(request, buffered)
+	if err != nil {
+		log.Fatalf("Error exporting issues: %v", err)
+	}
+	if err := buffered.Flush(); err != nil {
+		log.Fatalf("Error writing the export: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d issues from %s as %s\n", result.Count, result.Repository, result.Format)
+	if result.Truncated {
+		fmt.Fprintln(os.Stderr, "More issues matched than the limit allowed; narrow the filters or raise -limit")
+	}
+}
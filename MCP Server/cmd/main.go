@@ -1,7 +1,11 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"mcp-server/internal/config"
 	"mcp-server/internal/interfaces"
@@ -9,15 +13,69 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+const usageText = `Usage: mcp-server [-config path] [-profile name] [command]
+
+Commands:
+  serve                       Start the MCP server on stdio (default)
+  tools list                  List the tools registered with the configuration
+  tools call <tool> [--name value ...]
+                              Call a tool and print its result
+  export                      Export issues as CSV, JSONL or a Markdown report
+  config check                Validate the configuration and print a summary
+  auth test                   Check the token against the GitHub API
+
+Run "mcp-server <command> -h" for the options of a command.
+`
+
+// globalOptions are the flags accepted before the command; every command
+// also accepts them after its name
+type globalOptions struct {
+	configPath string
+	profile    string
+}
+
+// register adds the global flags to a command's flag set, defaulting to the
+// values given before the command
+func (o *globalOptions) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.configPath, "config", o.configPath, "Path to the YAML config file (default: $MCP_CONFIG)")
+	flags.StringVar(&o.profile, "profile", o.profile, "Config profile to use (default: $MCP_PROFILE or the file's default_profile)")
+}
+
 func main() {
-	// Load configuration
-	cfg := config.NewConfig()
-	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Configuration error: %v", err)
+	var opts globalOptions
+	opts.register(flag.CommandLine)
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usageText) }
+	flag.Parse()
+
+	command, args := "serve", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
 	}
 
-	// Create dependency container
-	container := interfaces.NewContainer()
+	switch command {
+	case "serve":
+		runServe(&opts, args)
+	case "export":
+		runExport(&opts, args)
+	case "tools":
+		runTools(&opts, args)
+	case "config":
+		runConfig(&opts, args)
+	case "auth":
+		runAuth(&opts, args)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usageText)
+		os.Exit(2)
+	}
+}
+
+// runServe implements the serve command: the MCP server on stdio
+func runServe(opts *globalOptions, args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	opts.register(flags)
+	flags.Parse(args)
+
+	cfg, container := loadContainer(opts)
 
 	// Configure MCP server
 	mcpServer := container.SetupMCPServer(cfg.ServerName, cfg.ServerVersion)
@@ -27,3 +85,38 @@ func main() {
 		log.Fatalf("Error starting MCP server: %v", err)
 	}
 }
+
+// subcommand returns the second word of two-word commands such as "tools list"
+func subcommand(command string, args []string, choices ...string) (string, []string) {
+	if len(args) > 0 {
+		for _, choice := range choices {
+			if args[0] == choice {
+				return choice, args[1:]
+			}
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Usage: mcp-server %s <%s>\n", command, strings.Join(choices, "|"))
+	os.Exit(2)
+	return "", nil
+}
+
+// loadContainer loads and validates the configuration and creates the
+// dependency container, exiting on errors
+func loadContainer(opts *globalOptions) (*config.Config, *interfaces.Container) {
+	// Load configuration
+	cfg, err := config.Load(opts.configPath, opts.profile)
+	if err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
+
+	// Create dependency container
+	container, err := interfaces.NewContainer(cfg)
+	if err != nil {
+		log.Fatalf("Error creating container: %v", err)
+	}
+
+	return cfg, container
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+
+	"mcp-server/internal/application/tools"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// runTools implements the tools list and tools call commands
+func runTools(opts *globalOptions, args []string) {
+	command, args := subcommand("tools", args, "list", "call")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if command == "list" {
+		listTools(ctx, opts, args)
+		return
+	}
+	os.Exit(callTool(ctx, opts, args))
+}
+
+// listTools prints the tools registered with the configuration
+func listTools(ctx context.Context, opts *globalOptions, args []string) {
+	flags := flag.NewFlagSet("tools list", flag.ExitOnError)
+	opts.register(flags)
+	output := flags.String("output", "text", "Output format: text, json")
+	flags.Parse(args)
+
+	_, container := loadContainer(opts)
+	registered, err := container.ListTools(ctx)
+	if err != nil {
+		log.Fatalf("Error listing tools: %v", err)
+	}
+
+	if *output == "json" {
+		printJSON(registered)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, tool := range registered {
+		marker := ""
+		if def, ok := container.Registry.Lookup(tool.Name); ok && def.Writes {
+			marker = " (writes)"
+		}
+		fmt.Fprintf(w, "%s\t%s%s\n", tool.Name, tool.Description, marker)
+	}
+	w.Flush()
+}
+
+// callTool calls a tool with the arguments given as --name value pairs and
+// returns the exit code: 1 when the tool reports an error
+func callTool(ctx context.Context, opts *globalOptions, args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, "Usage: mcp-server tools call <tool> [--output text|json] [--args JSON] [--name value ...]")
+		return 2
+	}
+	name := args[0]
+
+	flags, toolArgs, err := splitCallArgs(args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if flags.config != "" {
+		opts.configPath = flags.config
+	}
+	if flags.profile != "" {
+		opts.profile = flags.profile
+	}
+
+	_, container := loadContainer(opts)
+	def, ok := container.Registry.Lookup(name)
+	if !ok {
+		log.Fatalf("Unknown tool %q; run \"mcp-server tools list\" for the available tools", name)
+	}
+
+	arguments := map[string]interface{}{}
+	if flags.args != "" {
+		if err := json.Unmarshal([]byte(flags.args), &arguments); err != nil {
+			log.Fatalf("Invalid --args: %v", err)
+		}
+	}
+	parsed, err := tools.ParseCommandLineArguments(def.Tool, toolArgs)
+	if err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+	for key, value := range parsed {
+		arguments[key] = value
+	}
+
+	result, err := container.CallTool(ctx, name, arguments)
+	if err != nil {
+		log.Fatalf("Error calling %s: %v", name, err)
+	}
+
+	if flags.output == "json" {
+		printJSON(result)
+	} else {
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				fmt.Println(text.Text)
+			}
+		}
+	}
+
+	if result.IsError {
+		return 1
+	}
+	return 0
+}
+
+// callFlags are the flags of tools call that belong to the command
+type callFlags struct {
+	output  string
+	args    string
+	config  string
+	profile string
+}
+
+// splitCallArgs separates the flags of tools call from the tool arguments.
+// --output, --args, --config and --profile belong to the command; every
+// other flag, including --format, is passed to the tool.
+func splitCallArgs(args []string) (callFlags, []string, error) {
+	flags := callFlags{output: "text"}
+	toolArgs := []string{}
+
+	for i := 0; i < len(args); i++ {
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		var target *string
+		switch flagName {
+		case "output":
+			target = &flags.output
+		case "args":
+			target = &flags.args
+		case "config":
+			target = &flags.config
+		case "profile":
+			target = &flags.profile
+		default:
+			toolArgs = append(toolArgs, args[i])
+			continue
+		}
+
+		if !hasValue {
+			if i+1 == len(args) {
+				return callFlags{}, nil, fmt.Errorf("missing value for --%s", flagName)
+			}
+			i++
+			value = args[i]
+		}
+		*target = value
+	}
+
+	if flags.output != "text" && flags.output != "json" {
+		return callFlags{}, nil, fmt.Errorf("invalid --output %q: use text or json", flags.output)
+	}
+
+	return flags, toolArgs, nil
+}
+
+// printJSON writes value to stdout as indented JSON
+func printJSON(value interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		log.Fatalf("Error encoding JSON: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"mcp-server/internal/application/tools"
+)
+
+func TestSplitCallArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		flags    callFlags
+		toolArgs []string
+		err      bool
+	}{
+		{"defaults", nil, callFlags{output: "text"}, []string{}, false},
+		{
+			"tool format is passed on",
+			[]string{"--repo", "api", "--format", "csv", "--output", "json"},
+			callFlags{output: "json"},
+			[]string{"--repo", "api", "--format", "csv"},
+			false,
+		},
+		{
+			"command flags with =",
+			[]string{"--format=jsonl", "--output=json", "--args={\"limit\": 5}", "--config=work.yaml", "--profile=work"},
+			callFlags{output: "json", args: `{"limit": 5}`, config: "work.yaml", profile: "work"},
+			[]string{"--format=jsonl"},
+			false,
+		},
+		{"missing value", []string{"--repo", "api", "--output"}, callFlags{}, nil, true},
+		{"invalid output", []string{"--output", "csv"}, callFlags{}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		flags, toolArgs, err := splitCallArgs(tc.args)
+		if (err != nil) != tc.err {
+			t.Errorf("%s: got error %v, want error %v", tc.name, err, tc.err)
+			continue
+		}
+		if flags != tc.flags || !reflect.DeepEqual(toolArgs, tc.toolArgs) {
+			t.Errorf("%s: got %+v %q, want %+v %q", tc.name, flags, toolArgs, tc.flags, tc.toolArgs)
+		}
+	}
+}
+
+func TestCallArgsReachExportIssues(t *testing.T) {
+	tool := (&tools.ToolFactory{}).CreateExportIssuesTool()
+
+	_, toolArgs, err := splitCallArgs([]string{"--repo", "api", "--format", "csv", "--output", "json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	arguments, err := tools.ParseCommandLineArguments(tool, toolArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tools.ValidateArguments(tool, arguments); err != nil {
+		t.Fatalf("export_issues arguments are invalid: %v", err)
+	}
+	if arguments["format"] != "csv" {
+		t.Errorf("format = %v, want csv", arguments["format"])
+	}
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// reloadInterval is how often the policy file is checked for changes
+const reloadInterval = 2 * time.Second
+
+// PrincipalFunc returns the identity the server acts on behalf of
+type PrincipalFunc func() (string, error)
+
+// Enforcer holds the active policy and reloads it when its file changes
+type Enforcer struct {
+	mu        sync.Mutex
+	base      RepositoryRules
+	filename  string
+	policy    *Policy
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+
+	principalFn PrincipalFunc
+	principal   string
+}
+
+// NewEnforcer creates an Enforcer from the base repository rules and an
+// optional policy file. The file is re-read whenever it changes on disk.
+func NewEnforcer(base RepositoryRules, filename string, principalFn PrincipalFunc) (*Enforcer, error) {
+	e := &Enforcer{
+		base:        base,
+		filename:    filename,
+		policy:      (&Policy{}).merge(base),
+		principalFn: principalFn,
+	}
+
+	if filename != "" {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.load(info); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// Policy returns the current policy, reloading the file if it changed
+func (e *Enforcer) Policy() *Policy {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.filename != "" && time.Since(e.checkedAt) >= reloadInterval {
+		e.checkedAt = time.Now()
+		info, err := os.Stat(e.filename)
+		switch {
+		case err != nil:
+			log.Printf("policy: keeping previous policy, stat failed: %v", err)
+		case !info.ModTime().Equal(e.modTime) || info.Size() != e.size:
+			if err := e.load(info); err != nil {
+				log.Printf("policy: keeping previous policy, reload failed: %v", err)
+			} else {
+				log.Printf("policy: reloaded %s", e.filename)
+			}
+		}
+	}
+
+	return e.policy
+}
+
+// Principal returns the identity used for per-principal rules.
+// A failed lookup is not cached so it is retried on the next call.
+func (e *Enforcer) Principal() (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.principal != "" || e.principalFn == nil {
+		return e.principal, nil
+	}
+
+	principal, err := e.principalFn()
+	if err != nil {
+		return "", err
+	}
+	e.principal = principal
+	return principal, nil
+}
+
+// load reads the policy file; the caller must hold the lock
+func (e *Enforcer) load(info os.FileInfo) error {
+	p, err := LoadPolicy(e.filename)
+	if err != nil {
+		return err
+	}
+
+	e.policy = p.merge(e.base)
+	e.modTime = info.ModTime()
+	e.size = info.Size()
+	e.checkedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,642 @@
+package policy
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"mcp-server/internal/application/services"
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// IssueService enforces the policy in front of another IssueServiceInterface
+type IssueService struct {
+	services.IssueServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewIssueService wraps next so every call is checked against the policy
+func NewIssueService(next services.IssueServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *IssueService {
+	return &IssueService{
+		IssueServiceInterface: next,
+		enforcer:              enforcer,
+		resolver:              resolver,
+	}
+}
+
+// GetIssues checks the repository rules and caps the number of results
+func (s *IssueService) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error) {
+	p, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.IssueServiceInterface.GetIssues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := p.ResultLimit(principal); limit > 0 && len(response.Issues) > limit {
+		response.Issues = response.Issues[:limit]
+		response.Count = limit
+		response.Truncated = true
+	}
+
+	return response, nil
+}
+
+// GetIssue checks the repository rules
+func (s *IssueService) GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.IssueServiceInterface.GetIssue(req)
+}
+
+// BulkIssuesService enforces the policy in front of another BulkIssuesServiceInterface
+type BulkIssuesService struct {
+	services.BulkIssuesServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewBulkIssuesService wraps next so every call is checked against the policy
+func NewBulkIssuesService(next services.BulkIssuesServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *BulkIssuesService {
+	return &BulkIssuesService{
+		BulkIssuesServiceInterface: next,
+		enforcer:                   enforcer,
+		resolver:                   resolver,
+	}
+}
+
+// BulkUpdateIssues checks the repository rules
+func (s *BulkIssuesService) BulkUpdateIssues(ctx context.Context, req *domain.BulkUpdateIssuesRequest, progress services.ProgressFunc) (*domain.BulkUpdateIssuesResponse, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.BulkIssuesServiceInterface.BulkUpdateIssues(ctx, req, progress)
+}
+
+// ActionsService enforces the policy in front of another ActionsServiceInterface
+type ActionsService struct {
+	services.ActionsServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewActionsService wraps next so every call is checked against the policy
+func NewActionsService(next services.ActionsServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *ActionsService {
+	return &ActionsService{
+		ActionsServiceInterface: next,
+		enforcer:                enforcer,
+		resolver:                resolver,
+	}
+}
+
+// ListWorkflowRuns checks the repository rules and caps the number of runs
+func (s *ActionsService) ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error) {
+	p, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.ActionsServiceInterface.ListWorkflowRuns(req)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := p.ResultLimit(principal); limit > 0 && len(response.Runs) > limit {
+		response.Runs = response.Runs[:limit]
+		response.Count = limit
+	}
+
+	return response, nil
+}
+
+// GetWorkflowRun checks the repository rules
+func (s *ActionsService) GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.GetWorkflowRunResponse, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.ActionsServiceInterface.GetWorkflowRun(req)
+}
+
+// GetJobLogs checks the repository rules
+func (s *ActionsService) GetJobLogs(req *domain.GetJobLogsRequest) (*domain.GetJobLogsResponse, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.ActionsServiceInterface.GetJobLogs(req)
+}
+
+// RerunFailedJobs checks the repository rules
+func (s *ActionsService) RerunFailedJobs(req *domain.RerunFailedJobsRequest) error {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return err
+	}
+	return s.ActionsServiceInterface.RerunFailedJobs(req)
+}
+
+// ContentsService enforces the policy in front of another ContentsServiceInterface
+type ContentsService struct {
+	services.ContentsServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewContentsService wraps next so every call is checked against the policy
+func NewContentsService(next services.ContentsServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *ContentsService {
+	return &ContentsService{
+		ContentsServiceInterface: next,
+		enforcer:                 enforcer,
+		resolver:                 resolver,
+	}
+}
+
+// GetFileContents checks the repository rules
+func (s *ContentsService) GetFileContents(req *domain.GetFileContentsRequest) (*domain.FileContents, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.ContentsServiceInterface.GetFileContents(req)
+}
+
+// ListDirectory checks the repository rules
+func (s *ContentsService) ListDirectory(req *domain.ListDirectoryRequest) (*domain.ListDirectoryResponse, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.ContentsServiceInterface.ListDirectory(req)
+}
+
+// GetRepoTree checks the repository rules
+func (s *ContentsService) GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.ContentsServiceInterface.GetRepoTree(req)
+}
+
+// SearchCode checks the repository rules of a scoped search and drops hits
+// from repositories the policy does not allow
+func (s *ContentsService) SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error) {
+	if req.Repo != "" {
+		if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := s.ContentsServiceInterface.SearchCode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	p := s.enforcer.Policy()
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := response.Items[:0]
+	for _, item := range response.Items {
+		owner, repo, _ := strings.Cut(item.Repository, "/")
+		if p.CheckRepository(owner, repo) == nil {
+			allowed = append(allowed, item)
+		}
+	}
+	if limit := p.ResultLimit(principal); limit > 0 && len(allowed) > limit {
+		allowed = allowed[:limit]
+	}
+	response.Items = allowed
+	response.Count = len(allowed)
+
+	return response, nil
+}
+
+// ProjectsService enforces the policy in front of another ProjectsServiceInterface
+type ProjectsService struct {
+	services.ProjectsServiceInterface
+	enforcer *Enforcer
+}
+
+// NewProjectsService wraps next so listed and updated items are checked against the policy
+func NewProjectsService(next services.ProjectsServiceInterface, enforcer *Enforcer) *ProjectsService {
+	return &ProjectsService{
+		ProjectsServiceInterface: next,
+		enforcer:                 enforcer,
+	}
+}
+
+// ListProjectItems drops items from repositories the policy does not allow
+// and caps the number of items; draft issues have no repository and are kept
+func (s *ProjectsService) ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error) {
+	response, err := s.ProjectsServiceInterface.ListProjectItems(req)
+	if err != nil {
+		return nil, err
+	}
+
+	p := s.enforcer.Policy()
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := response.Items[:0]
+	for _, item := range response.Items {
+		owner, repo, found := strings.Cut(item.Repository, "/")
+		if !found || p.CheckRepository(owner, repo) == nil {
+			allowed = append(allowed, item)
+		}
+	}
+	if limit := p.ResultLimit(principal); limit > 0 && len(allowed) > limit {
+		allowed = allowed[:limit]
+	}
+	response.Items = allowed
+	response.Count = len(allowed)
+
+	return response, nil
+}
+
+// MoveProjectItem checks the repository of the item before its status is changed
+func (s *ProjectsService) MoveProjectItem(req *domain.SetProjectFieldRequest) error {
+	if err := s.checkItem(req.ItemID); err != nil {
+		return err
+	}
+	return s.ProjectsServiceInterface.MoveProjectItem(req)
+}
+
+// SetProjectField checks the repository of the item before a field is changed
+func (s *ProjectsService) SetProjectField(req *domain.SetProjectFieldRequest) error {
+	if err := s.checkItem(req.ItemID); err != nil {
+		return err
+	}
+	return s.ProjectsServiceInterface.SetProjectField(req)
+}
+
+// checkItem looks up the content of a project item and checks its repository
+// against the policy; draft issues have no repository and are allowed
+func (s *ProjectsService) checkItem(itemID string) error {
+	item, err := s.ProjectsServiceInterface.GetProjectItem(itemID)
+	if err != nil {
+		return err
+	}
+	owner, repo, found := strings.Cut(item.Repository, "/")
+	if !found {
+		return nil
+	}
+	return s.enforcer.Policy().CheckRepository(owner, repo)
+}
+
+// NotificationsService enforces the policy in front of another NotificationsServiceInterface
+type NotificationsService struct {
+	services.NotificationsServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewNotificationsService wraps next so threads are checked against the policy
+func NewNotificationsService(next services.NotificationsServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *NotificationsService {
+	return &NotificationsService{
+		NotificationsServiceInterface: next,
+		enforcer:                      enforcer,
+		resolver:                      resolver,
+	}
+}
+
+// ListNotifications checks the repository rules of a scoped listing, drops
+// notifications from repositories the policy does not allow and caps the rest
+func (s *NotificationsService) ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error) {
+	if req.Repo != "" {
+		if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := s.NotificationsServiceInterface.ListNotifications(req)
+	if err != nil {
+		return nil, err
+	}
+
+	p := s.enforcer.Policy()
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := response.Notifications[:0]
+	for _, notification := range response.Notifications {
+		owner, repo, _ := strings.Cut(notification.Repository.FullName, "/")
+		if p.CheckRepository(owner, repo) == nil {
+			allowed = append(allowed, notification)
+		}
+	}
+	if limit := p.ResultLimit(principal); limit > 0 && len(allowed) > limit {
+		allowed = allowed[:limit]
+	}
+	response.Notifications = allowed
+	response.Count = len(allowed)
+
+	return response, nil
+}
+
+// GetNotificationThread checks the repository rules of the thread
+func (s *NotificationsService) GetNotificationThread(req *domain.GetNotificationThreadRequest) (*domain.NotificationThread, error) {
+	thread, err := s.NotificationsServiceInterface.GetNotificationThread(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkThread(&thread.Notification); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// MarkNotificationRead looks up the thread first so the repository rules apply
+func (s *NotificationsService) MarkNotificationRead(req *domain.MarkNotificationReadRequest) error {
+	thread, err := s.NotificationsServiceInterface.GetNotificationThread(&domain.GetNotificationThreadRequest{ThreadID: req.ThreadID})
+	if err != nil {
+		return err
+	}
+	if err := s.checkThread(&thread.Notification); err != nil {
+		return err
+	}
+	return s.NotificationsServiceInterface.MarkNotificationRead(req)
+}
+
+// checkThread checks the repository of a notification against the policy
+func (s *NotificationsService) checkThread(notification *domain.Notification) error {
+	owner, repo, _ := strings.Cut(notification.Repository.FullName, "/")
+	return s.enforcer.Policy().CheckRepository(owner, repo)
+}
+
+// LabelsService enforces the policy in front of another LabelsServiceInterface
+type LabelsService struct {
+	services.LabelsServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewLabelsService wraps next so every repository is checked against the policy
+func NewLabelsService(next services.LabelsServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *LabelsService {
+	return &LabelsService{
+		LabelsServiceInterface: next,
+		enforcer:               enforcer,
+		resolver:               resolver,
+	}
+}
+
+// ListLabels checks the repository rules
+func (s *LabelsService) ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.LabelsServiceInterface.ListLabels(req)
+}
+
+// CreateLabel checks the repository rules
+func (s *LabelsService) CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.LabelsServiceInterface.CreateLabel(req)
+}
+
+// UpdateLabel checks the repository rules
+func (s *LabelsService) UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.LabelsServiceInterface.UpdateLabel(req)
+}
+
+// DeleteLabel checks the repository rules
+func (s *LabelsService) DeleteLabel(req *domain.DeleteLabelRequest) error {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return err
+	}
+	return s.LabelsServiceInterface.DeleteLabel(req)
+}
+
+// SyncLabels checks every target repository before anything is compared or
+// changed, so a spec naming a denied repository is rejected as a whole
+func (s *LabelsService) SyncLabels(req *domain.SyncLabelsRequest) (*domain.SyncLabelsResponse, error) {
+	targets := make([]string, 0, len(req.Spec.Repositories))
+	for _, target := range req.Spec.Repositories {
+		owner, repo := "", target
+		if _, err := checkRepository(s.enforcer, s.resolver, &owner, &repo); err != nil {
+			return nil, err
+		}
+		targets = append(targets, owner+"/"+repo)
+	}
+	req.Spec.Repositories = targets
+
+	return s.LabelsServiceInterface.SyncLabels(req)
+}
+
+// MilestonesService enforces the policy in front of another MilestonesServiceInterface
+type MilestonesService struct {
+	services.MilestonesServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewMilestonesService wraps next so every call is checked against the policy
+func NewMilestonesService(next services.MilestonesServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *MilestonesService {
+	return &MilestonesService{
+		MilestonesServiceInterface: next,
+		enforcer:                   enforcer,
+		resolver:                   resolver,
+	}
+}
+
+// ListMilestones checks the repository rules and caps the number of milestones
+func (s *MilestonesService) ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error) {
+	p, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	milestones, err := s.MilestonesServiceInterface.ListMilestones(req)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := p.ResultLimit(principal); limit > 0 && len(milestones) > limit {
+		milestones = milestones[:limit]
+	}
+
+	return milestones, nil
+}
+
+// CreateMilestone checks the repository rules
+func (s *MilestonesService) CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.MilestonesServiceInterface.CreateMilestone(req)
+}
+
+// UpdateMilestone checks the repository rules
+func (s *MilestonesService) UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error) {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return nil, err
+	}
+	return s.MilestonesServiceInterface.UpdateMilestone(req)
+}
+
+// DeleteMilestone checks the repository rules
+func (s *MilestonesService) DeleteMilestone(req *domain.DeleteMilestoneRequest) error {
+	if _, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo); err != nil {
+		return err
+	}
+	return s.MilestonesServiceInterface.DeleteMilestone(req)
+}
+
+// ToolMiddleware rejects tool calls the current principal is not allowed to make
+func ToolMiddleware(enforcer *Enforcer) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			p := enforcer.Policy()
+
+			principal, err := principalFor(enforcer, p)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error resolving principal", err), nil
+			}
+
+			if err := p.CheckTool(principal, req.Params.Name); err != nil {
+				return mcp.NewToolResultErrorFromErr("Tool call rejected", err), nil
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// checkRepository resolves owner and repo in place and checks them against
+// the current policy, which is returned for further checks
+func checkRepository(enforcer *Enforcer, resolver services.RepositoryResolver, owner, repo *string) (*Policy, error) {
+	if resolver != nil {
+		*owner, *repo = resolver.ResolveRepository(*owner, *repo)
+	}
+
+	p := enforcer.Policy()
+	if err := p.CheckRepository(*owner, *repo); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// principalFor only resolves the principal when the policy has per-principal rules
+func principalFor(enforcer *Enforcer, p *Policy) (string, error) {
+	if len(p.Principals) == 0 {
+		return "", nil
+	}
+	return enforcer.Principal()
+}
+
+// ExportService enforces the policy in front of another ExportServiceInterface
+type ExportService struct {
+	services.ExportServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewExportService wraps next so every call is checked against the policy
+func NewExportService(next services.ExportServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *ExportService {
+	return &ExportService{
+		ExportServiceInterface: next,
+		enforcer:               enforcer,
+		resolver:               resolver,
+	}
+}
+
+// ExportIssues checks the repository rules and caps the number of issues
+func (s *ExportService) ExportIssues(req *domain.ExportIssuesRequest, w io.Writer) (*domain.ExportIssuesResult, error) {
+	if err := s.checkExport(req); err != nil {
+		return nil, err
+	}
+	return s.ExportServiceInterface.ExportIssues(req, w)
+}
+
+// ExportIssuesToFile checks the repository rules and caps the number of issues
+func (s *ExportService) ExportIssuesToFile(req *domain.ExportIssuesRequest, filename string) (*domain.ExportIssuesResult, error) {
+	if err := s.checkExport(req); err != nil {
+		return nil, err
+	}
+	return s.ExportServiceInterface.ExportIssuesToFile(req, filename)
+}
+
+// checkExport applies the repository rules and the principal's result limit
+func (s *ExportService) checkExport(req *domain.ExportIssuesRequest) error {
+	p, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo)
+	if err != nil {
+		return err
+	}
+
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return err
+	}
+
+	if limit := p.ResultLimit(principal); limit > 0 && (req.Limit == 0 || req.Limit > limit) {
+		req.Limit = limit
+	}
+
+	return nil
+}
+
+// EventsService enforces the policy in front of another EventsServiceInterface
+type EventsService struct {
+	services.EventsServiceInterface
+	enforcer *Enforcer
+	resolver services.RepositoryResolver
+}
+
+// NewEventsService wraps next so every call is checked against the policy
+func NewEventsService(next services.EventsServiceInterface, enforcer *Enforcer, resolver services.RepositoryResolver) *EventsService {
+	return &EventsService{
+		EventsServiceInterface: next,
+		enforcer:               enforcer,
+		resolver:               resolver,
+	}
+}
+
+// GetRepoEventsSince checks the repository rules and caps the number of changes
+func (s *EventsService) GetRepoEventsSince(req *domain.GetRepoEventsRequest) (*domain.GetRepoEventsResponse, error) {
+	p, err := checkRepository(s.enforcer, s.resolver, &req.Owner, &req.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := principalFor(s.enforcer, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capping the request rather than the result keeps the checkpoint in
+	// step with the changes that are returned
+	if limit := p.ResultLimit(principal); limit > 0 && (req.Limit == 0 || req.Limit > limit) {
+		req.Limit = limit
+	}
+
+	return s.EventsServiceInterface.GetRepoEventsSince(req)
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"testing"
+
+	"mcp-server/internal/application/services"
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// stubProjectsService serves items by ID and records the updates it receives
+type stubProjectsService struct {
+	services.ProjectsServiceInterface
+	items   map[string]domain.ProjectBoardItem
+	updated []string
+}
+
+func (s *stubProjectsService) GetProjectItem(itemID string) (*domain.ProjectBoardItem, error) {
+	item, ok := s.items[itemID]
+	if !ok {
+		return nil, errors.NewNotFoundError("project item " + itemID)
+	}
+	return &item, nil
+}
+
+func (s *stubProjectsService) MoveProjectItem(req *domain.SetProjectFieldRequest) error {
+	s.updated = append(s.updated, req.ItemID)
+	return nil
+}
+
+func (s *stubProjectsService) SetProjectField(req *domain.SetProjectFieldRequest) error {
+	s.updated = append(s.updated, req.ItemID)
+	return nil
+}
+
+func TestProjectsServiceChecksItemRepository(t *testing.T) {
+	enforcer, err := NewEnforcer(RepositoryRules{Allow: []string{"acme/*"}, Deny: []string{"acme/secret"}}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := &stubProjectsService{items: map[string]domain.ProjectBoardItem{
+		"PVTI_api":    {ID: "PVTI_api", Type: "ISSUE", Repository: "acme/api"},
+		"PVTI_secret": {ID: "PVTI_secret", Type: "ISSUE", Repository: "acme/secret"},
+		"PVTI_other":  {ID: "PVTI_other", Type: "PULL_REQUEST", Repository: "globex/web"},
+		"PVTI_draft":  {ID: "PVTI_draft", Type: "DRAFT_ISSUE"},
+	}}
+	guard := NewProjectsService(next, enforcer)
+
+	testCases := []struct {
+		itemID string
+		code   string
+	}{
+		{"PVTI_api", ""},
+		{"PVTI_draft", ""},
+		{"PVTI_secret", errors.ErrCodeForbidden},
+		{"PVTI_other", errors.ErrCodeForbidden},
+		{"PVTI_missing", errors.ErrCodeNotFound},
+	}
+
+	for _, tc := range testCases {
+		req := &domain.SetProjectFieldRequest{Owner: "acme", Number: 1, ItemID: tc.itemID, Field: "Priority", Value: "High"}
+		if code := errorCode(t, guard.SetProjectField(req)); code != tc.code {
+			t.Errorf("SetProjectField %s: got %q, want %q", tc.itemID, code, tc.code)
+		}
+		req = &domain.SetProjectFieldRequest{Owner: "acme", Number: 1, ItemID: tc.itemID, Value: "Done"}
+		if code := errorCode(t, guard.MoveProjectItem(req)); code != tc.code {
+			t.Errorf("MoveProjectItem %s: got %q, want %q", tc.itemID, code, tc.code)
+		}
+	}
+
+	want := []string{"PVTI_api", "PVTI_api", "PVTI_draft", "PVTI_draft"}
+	if len(next.updated) != len(want) {
+		t.Fatalf("updated %v, want %v", next.updated, want)
+	}
+	for i := range want {
+		if next.updated[i] != want[i] {
+			t.Errorf("updated %v, want %v", next.updated, want)
+			break
+		}
+	}
+}
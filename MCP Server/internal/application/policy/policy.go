@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Wildcard matches any principal in the principals section
+const Wildcard = "*"
+
+// Policy describes which repositories and tools may be used
+type Policy struct {
+	Repositories RepositoryRules          `yaml:"repositories"`
+	MaxResults   int                      `yaml:"max_results"`
+	Principals   map[string]PrincipalRule `yaml:"principals"`
+
+	// baseAllow is the allow list of the configuration profile. A repository
+	// must pass both it and Repositories.Allow, so the policy file can only
+	// narrow what the profile allows.
+	baseAllow []string
+}
+
+// RepositoryRules holds "owner/repo" glob patterns; deny wins over allow
+type RepositoryRules struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// PrincipalRule restricts what a single principal may do
+type PrincipalRule struct {
+	Tools      []string `yaml:"tools"`
+	MaxResults int      `yaml:"max_results"`
+}
+
+// ParsePolicy decodes and validates a YAML policy document
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("parsing policy: %w", err)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// LoadPolicy reads a policy from a file
+func LoadPolicy(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy file: %w", err)
+	}
+	return ParsePolicy(data)
+}
+
+// validate checks patterns and limits
+func (p *Policy) validate() error {
+	patterns := append(append([]string{}, p.Repositories.Allow...), p.Repositories.Deny...)
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid repository pattern %q: %v", pattern, err)
+		}
+	}
+
+	if p.MaxResults < 0 {
+		return fmt.Errorf("max_results must not be negative")
+	}
+
+	for name, rule := range p.Principals {
+		if rule.MaxResults < 0 {
+			return fmt.Errorf("principal %q: max_results must not be negative", name)
+		}
+		for _, tool := range rule.Tools {
+			if _, err := path.Match(tool, ""); err != nil {
+				return fmt.Errorf("principal %q: invalid tool pattern %q: %v", name, tool, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// merge returns a copy of the policy combined with the profile's repository
+// rules. Deny lists add up; allow lists are both enforced, so the result
+// never allows more than either of them.
+func (p *Policy) merge(base RepositoryRules) *Policy {
+	merged := *p
+	merged.Repositories = RepositoryRules{
+		Allow: append([]string{}, p.Repositories.Allow...),
+		Deny:  append(append([]string{}, base.Deny...), p.Repositories.Deny...),
+	}
+	merged.baseAllow = append([]string{}, base.Allow...)
+	return &merged
+}
+
+// CheckRepository returns a FORBIDDEN error unless owner/repo is allowed.
+// Malformed names are rejected before any rule is evaluated, since a name
+// like "api/../secret" would match no pattern yet reach another repository.
+func (p *Policy) CheckRepository(owner, repo string) error {
+	if !domain.ValidOwnerName(owner) || !domain.ValidRepoName(repo) {
+		return errors.NewValidationError(fmt.Sprintf("invalid repository name %q", owner+"/"+repo))
+	}
+
+	fullName := strings.ToLower(owner + "/" + repo)
+
+	for _, pattern := range p.Repositories.Deny {
+		if matches(pattern, fullName) {
+			return errors.NewForbiddenError(fmt.Sprintf("repository %s/%s is denied by policy", owner, repo))
+		}
+	}
+
+	if !allowed(p.baseAllow, fullName) || !allowed(p.Repositories.Allow, fullName) {
+		return errors.NewForbiddenError(fmt.Sprintf("repository %s/%s is not in the allow list", owner, repo))
+	}
+
+	return nil
+}
+
+// allowed reports whether fullName matches one of the patterns; an empty
+// list allows every repository
+func allowed(patterns []string, fullName string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matches(pattern, fullName) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTool returns a FORBIDDEN error unless principal may call the tool
+func (p *Policy) CheckTool(principal, tool string) error {
+	if len(p.Principals) == 0 {
+		return nil
+	}
+
+	rule, ok := p.rule(principal)
+	if !ok {
+		return errors.NewForbiddenError(fmt.Sprintf("principal %q has no policy", principal))
+	}
+
+	for _, pattern := range rule.Tools {
+		if pattern == Wildcard || matches(pattern, tool) {
+			return nil
+		}
+	}
+
+	return errors.NewForbiddenError(fmt.Sprintf("principal %q may not call %s", principal, tool))
+}
+
+// ResultLimit returns the maximum number of results per call; 0 means unlimited
+func (p *Policy) ResultLimit(principal string) int {
+	limit := p.MaxResults
+	if rule, ok := p.rule(principal); ok && rule.MaxResults > 0 {
+		if limit == 0 || rule.MaxResults < limit {
+			limit = rule.MaxResults
+		}
+	}
+	return limit
+}
+
+// rule returns the principal's rule, falling back to the wildcard rule
+func (p *Policy) rule(principal string) (PrincipalRule, bool) {
+	if rule, ok := p.Principals[principal]; ok {
+		return rule, true
+	}
+	rule, ok := p.Principals[Wildcard]
+	return rule, ok
+}
+
+// matches reports whether name matches the glob pattern, ignoring case
+func matches(pattern, name string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return err == nil && ok
+}
@@ -0,0 +1,208 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mcp-server/pkg/errors"
+)
+
+// errorCode returns the AppError code of err, or "" if it is nil
+func errorCode(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		t.Fatalf("got %T, want *errors.AppError", err)
+	}
+	return appErr.Code
+}
+
+func TestCheckRepository(t *testing.T) {
+	p := (&Policy{Repositories: RepositoryRules{
+		Allow: []string{"acme/*", "globex/web"},
+		Deny:  []string{"acme/secrets-*", "*/legacy"},
+	}}).merge(RepositoryRules{})
+
+	testCases := []struct {
+		owner, repo string
+		code        string
+	}{
+		{"acme", "api", ""},
+		{"ACME", "Api", ""},
+		{"globex", "web", ""},
+		{"globex", "api", errors.ErrCodeForbidden},
+		{"acme", "secrets-prod", errors.ErrCodeForbidden},
+		{"acme", "legacy", errors.ErrCodeForbidden},
+		{"initech", "legacy", errors.ErrCodeForbidden},
+	}
+
+	for _, tc := range testCases {
+		if code := errorCode(t, p.CheckRepository(tc.owner, tc.repo)); code != tc.code {
+			t.Errorf("%s/%s: got %q, want %q", tc.owner, tc.repo, code, tc.code)
+		}
+	}
+}
+
+func TestCheckRepositoryRejectsMalformedNames(t *testing.T) {
+	// Without an allow list every well-formed name passes, so only the name
+	// check stands between these and another repository
+	p := (&Policy{Repositories: RepositoryRules{Deny: []string{"acme/secret"}}}).merge(RepositoryRules{})
+
+	testCases := []struct{ owner, repo string }{
+		{"acme", "api/../secret"},
+		{"acme", ".."},
+		{"acme", "."},
+		{"acme/api", "secret"},
+		{"..", "secret"},
+		{"acme", "api%2F..%2Fsecret"},
+		{"acme", "api?x=1"},
+		{"acme", ""},
+		{"", "api"},
+	}
+
+	for _, tc := range testCases {
+		if code := errorCode(t, p.CheckRepository(tc.owner, tc.repo)); code != errors.ErrCodeValidation {
+			t.Errorf("%q/%q: got %q, want %q", tc.owner, tc.repo, code, errors.ErrCodeValidation)
+		}
+	}
+
+	if err := p.CheckRepository("acme", "api.go_v2-x"); err != nil {
+		t.Errorf("acme/api.go_v2-x: unexpected error %v", err)
+	}
+}
+
+func TestMergeNarrowsAllowList(t *testing.T) {
+	profile := RepositoryRules{Allow: []string{"acme/*"}, Deny: []string{"acme/secrets-*"}}
+
+	testCases := []struct {
+		name   string
+		file   RepositoryRules
+		owner  string
+		repo   string
+		denied bool
+	}{
+		{"profile allows", RepositoryRules{}, "acme", "api", false},
+		{"profile denies", RepositoryRules{}, "acme", "secrets-prod", true},
+		{"outside profile", RepositoryRules{}, "globex", "api", true},
+		{"file cannot widen", RepositoryRules{Allow: []string{"globex/*"}}, "globex", "api", true},
+		{"file narrows", RepositoryRules{Allow: []string{"acme/api"}}, "acme", "web", true},
+		{"both allow", RepositoryRules{Allow: []string{"acme/api"}}, "acme", "api", false},
+		{"file denies", RepositoryRules{Deny: []string{"acme/api"}}, "acme", "api", true},
+		{"file allow cannot undo profile deny", RepositoryRules{Allow: []string{"acme/secrets-prod"}}, "acme", "secrets-prod", true},
+	}
+
+	for _, tc := range testCases {
+		p := (&Policy{Repositories: tc.file}).merge(profile)
+		if denied := p.CheckRepository(tc.owner, tc.repo) != nil; denied != tc.denied {
+			t.Errorf("%s: denied = %v, want %v", tc.name, denied, tc.denied)
+		}
+	}
+}
+
+func TestCheckTool(t *testing.T) {
+	p := &Policy{Principals: map[string]PrincipalRule{
+		"ci-bot": {Tools: []string{"get_issue*", "list_workflow_runs"}},
+		"octo":   {Tools: []string{"*"}},
+	}}
+
+	testCases := []struct {
+		principal, tool string
+		allowed         bool
+	}{
+		{"ci-bot", "get_issues", true},
+		{"ci-bot", "get_issue", true},
+		{"ci-bot", "list_workflow_runs", true},
+		{"ci-bot", "rerun_failed_jobs", false},
+		{"octo", "rerun_failed_jobs", true},
+		{"stranger", "get_issues", false},
+	}
+
+	for _, tc := range testCases {
+		if allowed := p.CheckTool(tc.principal, tc.tool) == nil; allowed != tc.allowed {
+			t.Errorf("%s %s: allowed = %v, want %v", tc.principal, tc.tool, allowed, tc.allowed)
+		}
+	}
+
+	p.Principals[Wildcard] = PrincipalRule{Tools: []string{"get_*"}}
+	if err := p.CheckTool("stranger", "get_issues"); err != nil {
+		t.Errorf("wildcard rule: unexpected error %v", err)
+	}
+	if err := p.CheckTool("stranger", "rerun_failed_jobs"); err == nil {
+		t.Error("wildcard rule: expected rerun_failed_jobs to be denied")
+	}
+
+	if err := (&Policy{}).CheckTool("anyone", "rerun_failed_jobs"); err != nil {
+		t.Errorf("no principals: unexpected error %v", err)
+	}
+}
+
+func TestResultLimit(t *testing.T) {
+	p := &Policy{MaxResults: 100, Principals: map[string]PrincipalRule{
+		"ci-bot": {MaxResults: 20},
+		"octo":   {MaxResults: 500},
+		"dev":    {},
+	}}
+
+	for principal, expected := range map[string]int{"ci-bot": 20, "octo": 100, "dev": 100, "stranger": 100} {
+		if limit := p.ResultLimit(principal); limit != expected {
+			t.Errorf("%s: got %d, want %d", principal, limit, expected)
+		}
+	}
+
+	unlimited := &Policy{Principals: map[string]PrincipalRule{"ci-bot": {MaxResults: 20}}}
+	if limit := unlimited.ResultLimit("ci-bot"); limit != 20 {
+		t.Errorf("no global limit: got %d, want 20", limit)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	testCases := []struct {
+		name  string
+		yaml  string
+		error string
+	}{
+		{"empty", "", ""},
+		{"valid", "repositories:\n  allow: [\"acme/*\"]\nmax_results: 10\n", ""},
+		{"bad pattern", "repositories:\n  deny: [\"acme/[\"]\n", `invalid repository pattern "acme/["`},
+		{"negative limit", "max_results: -1\n", "max_results must not be negative"},
+		{"bad tool pattern", "principals:\n  bot:\n    tools: [\"[\"]\n", `principal "bot": invalid tool pattern`},
+		{"unknown field", "repos: []\n", "field repos not found"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParsePolicy([]byte(tc.yaml))
+		switch {
+		case tc.error == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)):
+			t.Errorf("%s: got %v, want %q", tc.name, err, tc.error)
+		}
+	}
+}
+
+func TestEnforcerCombinesProfileAndFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(filename, []byte("repositories:\n  allow: [\"acme/api\", \"globex/*\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	enforcer, err := NewEnforcer(RepositoryRules{Allow: []string{"acme/*"}}, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := enforcer.Policy()
+	if err := p.CheckRepository("acme", "api"); err != nil {
+		t.Errorf("acme/api: unexpected error %v", err)
+	}
+	for _, repo := range [][2]string{{"acme", "web"}, {"globex", "api"}} {
+		if err := p.CheckRepository(repo[0], repo[1]); err == nil {
+			t.Errorf("%s/%s: expected FORBIDDEN", repo[0], repo[1])
+		}
+	}
+}
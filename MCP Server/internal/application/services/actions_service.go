@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// Log budget defaults, in tokens. A token is estimated as bytesPerToken bytes.
+const (
+	DefaultLogTokens = 4000
+	MaxLogTokens     = 50000
+	bytesPerToken    = 4
+)
+
+// validRunStatuses are the status filters accepted by the workflow runs endpoint
+var validRunStatuses = map[string]bool{
+	"completed": true, "action_required": true, "cancelled": true, "failure": true,
+	"neutral": true, "skipped": true, "stale": true, "success": true, "timed_out": true,
+	"in_progress": true, "queued": true, "requested": true, "waiting": true, "pending": true,
+}
+
+// ActionsServiceInterface defines the GitHub Actions service interface
+type ActionsServiceInterface interface {
+	ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error)
+	GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.GetWorkflowRunResponse, error)
+	GetJobLogs(req *domain.GetJobLogsRequest) (*domain.GetJobLogsResponse, error)
+	RerunFailedJobs(req *domain.RerunFailedJobsRequest) error
+	FormatWorkflowRunsForMCP(runs []domain.WorkflowRun) []string
+	FormatWorkflowJobsForMCP(jobs []domain.WorkflowJob) []string
+}
+
+// ActionsService implements business logic for GitHub Actions
+type ActionsService struct {
+	repo     repositories.ActionsRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewActionsService creates a new ActionsService instance.
+// In read-only mode runs and logs can be read but not re-run.
+func NewActionsService(repo repositories.ActionsRepositoryInterface, resolver RepositoryResolver, readOnly bool) *ActionsService {
+	return &ActionsService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// ListWorkflowRuns fetches the workflow runs of a repository
+func (s *ActionsService) ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+
+	if req.Status != "" && !validRunStatuses[req.Status] {
+		return nil, errors.NewValidationError(fmt.Sprintf("the 'status' parameter %q is not a valid workflow run status", req.Status))
+	}
+
+	if req.PerPage < 0 || req.PerPage > 100 {
+		return nil, errors.NewValidationError("the 'per_page' parameter must be between 1 and 100")
+	}
+	if req.PerPage == 0 {
+		req.PerPage = 20
+	}
+
+	return s.repo.ListWorkflowRuns(req)
+}
+
+// GetWorkflowRun fetches a workflow run together with its jobs
+func (s *ActionsService) GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.GetWorkflowRunResponse, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.RunID <= 0 {
+		return nil, errors.NewValidationError("the 'run_id' parameter is required")
+	}
+
+	run, err := s.repo.GetWorkflowRun(req)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.repo.ListWorkflowJobs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GetWorkflowRunResponse{
+		Run:  *run,
+		Jobs: jobs,
+	}, nil
+}
+
+// GetJobLogs downloads job logs and keeps the tail that fits the token budget
+func (s *ActionsService) GetJobLogs(req *domain.GetJobLogsRequest) (*domain.GetJobLogsResponse, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.JobID <= 0 {
+		return nil, errors.NewValidationError("the 'job_id' parameter is required")
+	}
+	if req.MaxTokens < 0 || req.MaxTokens > MaxLogTokens {
+		return nil, errors.NewValidationError(fmt.Sprintf("the 'max_tokens' parameter must be between 1 and %d", MaxLogTokens))
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = DefaultLogTokens
+	}
+
+	limit := req.MaxTokens * bytesPerToken
+	logs, total, err := s.repo.GetJobLogs(req, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	tail, truncated := tailTruncate(logs, total, limit)
+
+	return &domain.GetJobLogsResponse{
+		JobID:      req.JobID,
+		Logs:       string(tail),
+		TotalBytes: total,
+		Truncated:  truncated,
+	}, nil
+}
+
+// RerunFailedJobs re-runs the failed jobs of a workflow run
+func (s *ActionsService) RerunFailedJobs(req *domain.RerunFailedJobsRequest) error {
+	if s.readOnly {
+		return errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return err
+	}
+	if req.RunID <= 0 {
+		return errors.NewValidationError("the 'run_id' parameter is required")
+	}
+
+	return s.repo.RerunFailedJobs(req)
+}
+
+// FormatWorkflowRunsForMCP formats workflow runs for MCP output
+func (s *ActionsService) FormatWorkflowRunsForMCP(runs []domain.WorkflowRun) []string {
+	var formatted []string
+
+	for _, run := range runs {
+		// Format: [ID] Name #RunNumber [status/conclusion] branch (event)
+		formattedRun := fmt.Sprintf("[%d] %s #%d [%s] %s (%s)",
+			run.ID, run.Name, run.RunNumber, runState(run.Status, run.Conclusion), run.HeadBranch, run.Event)
+		formatted = append(formatted, formattedRun)
+	}
+
+	return formatted
+}
+
+// FormatWorkflowJobsForMCP formats workflow jobs and their failed steps for MCP output
+func (s *ActionsService) FormatWorkflowJobsForMCP(jobs []domain.WorkflowJob) []string {
+	var formatted []string
+
+	for _, job := range jobs {
+		// Format: job [ID] Name [status/conclusion]
+		formattedJob := fmt.Sprintf("job [%d] %s [%s]", job.ID, job.Name, runState(job.Status, job.Conclusion))
+		for _, step := range job.Steps {
+			if step.Conclusion == "failure" {
+				formattedJob += fmt.Sprintf("\n  failed step %d: %s", step.Number, step.Name)
+			}
+		}
+		formatted = append(formatted, formattedJob)
+	}
+
+	return formatted
+}
+
+// runState combines a status and conclusion, e.g. "completed/failure"
+func runState(status, conclusion string) string {
+	if conclusion == "" {
+		return status
+	}
+	return status + "/" + conclusion
+}
+
+// tailTruncate keeps the last limit bytes of logs, starting at a line
+// boundary, and prefixes an explicit truncation marker. logs may already be
+// only the tail of the download; total is the size of the whole log.
+func tailTruncate(logs []byte, total, limit int) ([]byte, bool) {
+	if total <= limit && len(logs) <= limit {
+		return logs, false
+	}
+
+	tail := logs[max(len(logs)-limit, 0):]
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+		tail = tail[i+1:]
+	}
+
+	marker := fmt.Sprintf("... [truncated %d bytes, showing last %d] ...\n", total-len(tail), len(tail))
+	return append([]byte(marker), tail...), true
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// stubActionsRepository records the calls the service makes
+type stubActionsRepository struct {
+	logs     string
+	maxBytes int
+	listed   *domain.ListWorkflowRunsRequest
+	reruns   int
+}
+
+func (r *stubActionsRepository) ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error) {
+	r.listed = req
+	return &domain.ListWorkflowRunsResponse{}, nil
+}
+
+func (r *stubActionsRepository) GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.WorkflowRun, error) {
+	return &domain.WorkflowRun{ID: req.RunID}, nil
+}
+
+func (r *stubActionsRepository) ListWorkflowJobs(req *domain.GetWorkflowRunRequest) ([]domain.WorkflowJob, error) {
+	return nil, nil
+}
+
+// GetJobLogs returns the last maxBytes bytes of logs, like the HTTP client
+func (r *stubActionsRepository) GetJobLogs(req *domain.GetJobLogsRequest, maxBytes int) ([]byte, int, error) {
+	r.maxBytes = maxBytes
+	return []byte(r.logs[max(len(r.logs)-maxBytes, 0):]), len(r.logs), nil
+}
+
+func (r *stubActionsRepository) RerunFailedJobs(req *domain.RerunFailedJobsRequest) error {
+	r.reruns++
+	return nil
+}
+
+// appErrorCode returns the AppError code of err, or "" if it is nil
+func appErrorCode(t *testing.T, err error) string {
+	t.Helper()
+	if err == nil {
+		return ""
+	}
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		t.Fatalf("got %T, want *errors.AppError", err)
+	}
+	return appErr.Code
+}
+
+func TestTailTruncate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		logs      string
+		total     int
+		limit     int
+		expected  string
+		truncated bool
+	}{
+		{"fits", "a\nb\n", 4, 10, "a\nb\n", false},
+		{"exact", "a\nb\n", 4, 4, "a\nb\n", false},
+		{"line boundary", "first\nsecond\nthird\n", 19, 10, "... [truncated 13 bytes, showing last 6] ...\nthird\n", true},
+		{"no newline in tail", "abcdefghij", 10, 4, "... [truncated 6 bytes, showing last 4] ...\nghij", true},
+		{"newline is last byte", "abcdef\n", 7, 3, "... [truncated 4 bytes, showing last 3] ...\nef\n", true},
+		{"already tailed", "second\nthird\n", 1000, 10, "... [truncated 994 bytes, showing last 6] ...\nthird\n", true},
+	}
+
+	for _, tc := range testCases {
+		got, truncated := tailTruncate([]byte(tc.logs), tc.total, tc.limit)
+		if string(got) != tc.expected || truncated != tc.truncated {
+			t.Errorf("%s: got %q (%v), want %q (%v)", tc.name, got, truncated, tc.expected, tc.truncated)
+		}
+	}
+}
+
+func TestListWorkflowRunsValidatesStatus(t *testing.T) {
+	testCases := []struct {
+		status  string
+		perPage int
+		code    string
+	}{
+		{"", 0, ""},
+		{"failure", 10, ""},
+		{"in_progress", 100, ""},
+		{"failed", 0, errors.ErrCodeValidation},
+		{"FAILURE", 0, errors.ErrCodeValidation},
+		{"success", -1, errors.ErrCodeValidation},
+		{"success", 101, errors.ErrCodeValidation},
+	}
+
+	for _, tc := range testCases {
+		repo := &stubActionsRepository{}
+		service := NewActionsService(repo, nil, false)
+		_, err := service.ListWorkflowRuns(&domain.ListWorkflowRunsRequest{Owner: "acme", Repo: "api", Status: tc.status, PerPage: tc.perPage})
+		if code := appErrorCode(t, err); code != tc.code {
+			t.Errorf("status %q per_page %d: got %q, want %q", tc.status, tc.perPage, code, tc.code)
+		}
+		if tc.code == "" && tc.perPage == 0 && repo.listed.PerPage != 20 {
+			t.Errorf("status %q: default per_page = %d, want 20", tc.status, repo.listed.PerPage)
+		}
+	}
+}
+
+func TestGetJobLogsKeepsTail(t *testing.T) {
+	repo := &stubActionsRepository{logs: strings.Repeat("step ok\n", 100) + "error: build failed\n"}
+	service := NewActionsService(repo, nil, false)
+
+	response, err := service.GetJobLogs(&domain.GetJobLogsRequest{Owner: "acme", Repo: "api", JobID: 1, MaxTokens: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.maxBytes != 40 {
+		t.Errorf("requested %d bytes, want 40", repo.maxBytes)
+	}
+	if !response.Truncated || response.TotalBytes != len(repo.logs) || !strings.HasSuffix(response.Logs, "error: build failed\n") {
+		t.Errorf("got %+v", response)
+	}
+
+	for _, maxTokens := range []int64{-1, MaxLogTokens + 1} {
+		_, err := service.GetJobLogs(&domain.GetJobLogsRequest{Owner: "acme", Repo: "api", JobID: 1, MaxTokens: int(maxTokens)})
+		if code := appErrorCode(t, err); code != errors.ErrCodeValidation {
+			t.Errorf("max_tokens %d: got %q, want %q", maxTokens, code, errors.ErrCodeValidation)
+		}
+	}
+}
+
+func TestRerunFailedJobsRefusesInReadOnlyMode(t *testing.T) {
+	repo := &stubActionsRepository{}
+
+	err := NewActionsService(repo, nil, true).RerunFailedJobs(&domain.RerunFailedJobsRequest{Owner: "acme", Repo: "api", RunID: 1})
+	if code := appErrorCode(t, err); code != errors.ErrCodeForbidden || repo.reruns != 0 {
+		t.Fatalf("read-only: got %q with %d reruns, want FORBIDDEN and none", code, repo.reruns)
+	}
+
+	if err := NewActionsService(repo, nil, false).RerunFailedJobs(&domain.RerunFailedJobsRequest{Owner: "acme", Repo: "api", RunID: 1}); err != nil || repo.reruns != 1 {
+		t.Fatalf("writable: got %v with %d reruns", err, repo.reruns)
+	}
+}
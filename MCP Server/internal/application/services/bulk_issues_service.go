@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+const (
+	// maxBulkIssues bounds how many issues one bulk update touches
+	maxBulkIssues = 100
+	// defaultBulkConcurrency is how many issues are updated at once by default
+	defaultBulkConcurrency = 4
+	// maxBulkConcurrency keeps bulk updates clear of GitHub's secondary rate limits
+	maxBulkConcurrency = 10
+)
+
+// ProgressFunc reports that done of total items have been processed
+type ProgressFunc func(done, total int, message string)
+
+// BulkIssuesServiceInterface defines the bulk issue update service interface
+type BulkIssuesServiceInterface interface {
+	BulkUpdateIssues(ctx context.Context, req *domain.BulkUpdateIssuesRequest, progress ProgressFunc) (*domain.BulkUpdateIssuesResponse, error)
+	FormatBulkUpdateForMCP(response *domain.BulkUpdateIssuesResponse) string
+}
+
+// BulkIssuesService applies one action to many issues of a repository
+type BulkIssuesService struct {
+	repo     repositories.GitHubRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewBulkIssuesService creates a new BulkIssuesService instance.
+// In read-only mode only dry runs are allowed.
+func NewBulkIssuesService(repo repositories.GitHubRepositoryInterface, resolver RepositoryResolver, readOnly bool) *BulkIssuesService {
+	return &BulkIssuesService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// BulkUpdateIssues selects the issues, works out the change for each one and,
+// unless it is a dry run, applies the changes with bounded concurrency.
+// Failures are reported per issue; once the rate limit is hit or ctx is
+// cancelled the remaining issues are skipped.
+func (s *BulkIssuesService) BulkUpdateIssues(ctx context.Context, req *domain.BulkUpdateIssuesRequest, progress ProgressFunc) (*domain.BulkUpdateIssuesResponse, error) {
+	if s.readOnly && !req.DryRun {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode; use dry_run to preview the changes")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if err := validateBulkUpdateRequest(req); err != nil {
+		return nil, err
+	}
+
+	issues, results, err := s.selectIssues(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.BulkUpdateIssuesResponse{
+		Repository: req.Owner + "/" + req.Repo,
+		Action:     req.Action,
+		DryRun:     req.DryRun,
+		Results:    results,
+	}
+
+	calls := 0
+	for i := range issues {
+		if response.Results[i].Status != "" {
+			continue
+		}
+		change, changed := planBulkChange(req, &issues[i])
+		response.Results[i].Change = change.describe(req.Action)
+		if changed {
+			response.Results[i].Status = domain.BulkStatusPlanned
+			calls += change.calls(req.Action)
+		} else {
+			response.Results[i].Status = domain.BulkStatusUnchanged
+		}
+	}
+
+	if limit := s.repo.RateLimit(); limit.Limit > 0 && limit.Remaining < calls {
+		response.Warning = fmt.Sprintf("the update needs %d requests but only %d remain until %s; later issues will be skipped",
+			calls, limit.Remaining, limit.Reset.Format("15:04:05"))
+	}
+
+	if req.DryRun {
+		return response, nil
+	}
+
+	s.applyChanges(ctx, req, issues, response, progress)
+	return response, nil
+}
+
+// selectIssues fetches the issues named in the request or matching its query.
+// Issues that cannot be fetched get a failed result straight away.
+func (s *BulkIssuesService) selectIssues(req *domain.BulkUpdateIssuesRequest) ([]domain.Issue, []domain.BulkIssueResult, error) {
+	if req.Query != "" {
+		query, err := scopedIssueQuery(req.Owner, req.Repo, req.Query)
+		if err != nil {
+			return nil, nil, err
+		}
+		found, err := s.repo.SearchIssues(&domain.SearchIssuesRequest{
+			Query:   query,
+			PerPage: maxBulkIssues,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if found.TotalCount > maxBulkIssues {
+			return nil, nil, errors.NewValidationError(fmt.Sprintf("the query matches %d issues; at most %d can be updated at once, narrow it down", found.TotalCount, maxBulkIssues))
+		}
+
+		// Only issues of the target repository are ever touched
+		issues := make([]domain.Issue, 0, len(found.Issues))
+		results := make([]domain.BulkIssueResult, 0, len(found.Issues))
+		for _, issue := range found.Issues {
+			if !inRepository(&issue, req.Owner, req.Repo) {
+				continue
+			}
+			issues = append(issues, issue)
+			results = append(results, domain.BulkIssueResult{Number: issue.Number, Title: issue.Title})
+		}
+		return issues, results, nil
+	}
+
+	issues := make([]domain.Issue, 0, len(req.Issues))
+	results := make([]domain.BulkIssueResult, 0, len(req.Issues))
+	seen := make(map[int]bool, len(req.Issues))
+
+	for _, number := range req.Issues {
+		if seen[number] {
+			continue
+		}
+		seen[number] = true
+
+		issue, err := s.repo.GetIssue(&domain.GetIssueRequest{Owner: req.Owner, Repo: req.Repo, Number: number})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrCodeRateLimited {
+				return nil, nil, err
+			}
+			issues = append(issues, domain.Issue{Number: number})
+			results = append(results, domain.BulkIssueResult{Number: number, Status: domain.BulkStatusFailed, Error: err.Error()})
+			continue
+		}
+		issues = append(issues, *issue)
+		results = append(results, domain.BulkIssueResult{Number: issue.Number, Title: issue.Title})
+	}
+
+	return issues, results, nil
+}
+
+// applyChanges runs the planned changes on a bounded number of workers
+func (s *BulkIssuesService) applyChanges(ctx context.Context, req *domain.BulkUpdateIssuesRequest, issues []domain.Issue, response *domain.BulkUpdateIssuesResponse, progress ProgressFunc) {
+	total := response.Count(domain.BulkStatusPlanned)
+	concurrency := req.Concurrency
+	if concurrency == 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		done        int
+		rateLimited atomic.Bool
+	)
+	workers := make(chan struct{}, concurrency)
+
+	for i := range response.Results {
+		result := &response.Results[i]
+		if result.Status != domain.BulkStatusPlanned {
+			continue
+		}
+
+		acquired := false
+		select {
+		case workers <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		if !acquired || ctx.Err() != nil || rateLimited.Load() {
+			if acquired {
+				<-workers
+			}
+			result.Status = domain.BulkStatusSkipped
+			result.Error = "not attempted: the rate limit was exceeded"
+			if ctx.Err() != nil {
+				result.Error = "not attempted: the request was cancelled"
+			}
+
+			// Skipped issues count as processed so progress still reaches total
+			mu.Lock()
+			done++
+			if progress != nil {
+				progress(done, total, fmt.Sprintf("#%d skipped: %s", result.Number, result.Error))
+			}
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(issue *domain.Issue, result *domain.BulkIssueResult) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			change, _ := planBulkChange(req, issue)
+			err := s.applyChange(req, issue.Number, change)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			message := fmt.Sprintf("#%d updated", issue.Number)
+			if err != nil {
+				result.Status = domain.BulkStatusFailed
+				result.Error = err.Error()
+				message = fmt.Sprintf("#%d failed: %v", issue.Number, err)
+				if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrCodeRateLimited {
+					rateLimited.Store(true)
+				}
+			} else {
+				result.Status = domain.BulkStatusUpdated
+			}
+
+			done++
+			if progress != nil {
+				progress(done, total, message)
+			}
+		}(&issues[i], result)
+	}
+
+	wg.Wait()
+}
+
+// applyChange makes the API calls for one issue
+func (s *BulkIssuesService) applyChange(req *domain.BulkUpdateIssuesRequest, number int, change bulkChange) error {
+	switch req.Action {
+	case domain.BulkActionLabel:
+		if len(change.addLabels) > 0 {
+			if err := s.repo.AddIssueLabels(req.Owner, req.Repo, number, change.addLabels); err != nil {
+				return err
+			}
+		}
+		for _, label := range change.removeLabels {
+			if err := s.repo.RemoveIssueLabel(req.Owner, req.Repo, number, label); err != nil {
+				return err
+			}
+		}
+		return nil
+	case domain.BulkActionClose:
+		_, err := s.repo.UpdateIssue(&domain.UpdateIssueRequest{Owner: req.Owner, Repo: req.Repo, Number: number, State: "closed", StateReason: req.StateReason})
+		return err
+	case domain.BulkActionReassign:
+		_, err := s.repo.UpdateIssue(&domain.UpdateIssueRequest{Owner: req.Owner, Repo: req.Repo, Number: number, Assignees: req.Assignees})
+		return err
+	case domain.BulkActionMilestone:
+		_, err := s.repo.UpdateIssue(&domain.UpdateIssueRequest{Owner: req.Owner, Repo: req.Repo, Number: number, Milestone: req.Milestone})
+		return err
+	}
+	return nil
+}
+
+// bulkChange is what an action changes on one issue
+type bulkChange struct {
+	addLabels    []string
+	removeLabels []string
+	from, to     string
+}
+
+// calls returns the number of API requests the change takes
+func (c bulkChange) calls(action string) int {
+	if action == domain.BulkActionLabel {
+		return min(len(c.addLabels), 1) + len(c.removeLabels)
+	}
+	return 1
+}
+
+// describe renders the change for the preview and the report
+func (c bulkChange) describe(action string) string {
+	if action != domain.BulkActionLabel {
+		if c.from == c.to {
+			return c.to
+		}
+		return c.from + " -> " + c.to
+	}
+
+	var parts []string
+	for _, label := range c.addLabels {
+		parts = append(parts, "+"+label)
+	}
+	for _, label := range c.removeLabels {
+		parts = append(parts, "-"+label)
+	}
+	if len(parts) == 0 {
+		return "labels already match"
+	}
+	return strings.Join(parts, " ")
+}
+
+// planBulkChange works out the change for an issue and whether there is one
+func planBulkChange(req *domain.BulkUpdateIssuesRequest, issue *domain.Issue) (bulkChange, bool) {
+	var change bulkChange
+
+	switch req.Action {
+	case domain.BulkActionLabel:
+		current := make(map[string]bool, len(issue.Labels))
+		for _, label := range issue.Labels {
+			current[strings.ToLower(label.Name)] = true
+		}
+		for _, label := range req.AddLabels {
+			if !current[strings.ToLower(label)] {
+				change.addLabels = append(change.addLabels, label)
+			}
+		}
+		for _, label := range req.RemoveLabels {
+			if current[strings.ToLower(label)] {
+				change.removeLabels = append(change.removeLabels, label)
+			}
+		}
+		return change, len(change.addLabels)+len(change.removeLabels) > 0
+
+	case domain.BulkActionClose:
+		change.from = issue.State
+		change.to = "closed"
+		if req.StateReason != "" {
+			change.to += " as " + req.StateReason
+		}
+		return change, issue.State != "closed"
+
+	case domain.BulkActionReassign:
+		current := make([]string, 0, len(issue.Assignees))
+		for _, user := range issue.Assignees {
+			current = append(current, user.Login)
+		}
+		desired := append([]string(nil), req.Assignees...)
+		sort.Strings(current)
+		sort.Strings(desired)
+		change.from = "assignees: " + joinOrNone(current)
+		change.to = "assignees: " + joinOrNone(desired)
+		return change, !strings.EqualFold(strings.Join(current, ","), strings.Join(desired, ","))
+
+	case domain.BulkActionMilestone:
+		current := 0
+		change.from = "milestone: none"
+		if issue.Milestone != nil {
+			current = issue.Milestone.Number
+			change.from = fmt.Sprintf("milestone: #%d %s", issue.Milestone.Number, issue.Milestone.Title)
+		}
+		change.to = "milestone: none"
+		if *req.Milestone != 0 {
+			change.to = fmt.Sprintf("milestone: #%d", *req.Milestone)
+		}
+		return change, current != *req.Milestone
+	}
+
+	return change, false
+}
+
+// joinOrNone joins values or returns "none" for an empty list
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+// validateBulkUpdateRequest checks the issue selection and the action arguments
+func validateBulkUpdateRequest(req *domain.BulkUpdateIssuesRequest) error {
+	if (len(req.Issues) == 0) == (req.Query == "") {
+		return errors.NewValidationError("exactly one of 'issues' or 'query' is required")
+	}
+	if len(req.Issues) > maxBulkIssues {
+		return errors.NewValidationError(fmt.Sprintf("at most %d issues can be updated at once", maxBulkIssues))
+	}
+	for _, number := range req.Issues {
+		if number <= 0 {
+			return errors.NewValidationError(fmt.Sprintf("the issue number %d must be positive", number))
+		}
+	}
+
+	if req.Concurrency < 0 || req.Concurrency > maxBulkConcurrency {
+		return errors.NewValidationError(fmt.Sprintf("the 'concurrency' parameter must be between 1 and %d", maxBulkConcurrency))
+	}
+
+	switch req.Action {
+	case domain.BulkActionLabel:
+		if len(req.AddLabels) == 0 && len(req.RemoveLabels) == 0 {
+			return errors.NewValidationError("the label action requires 'add_labels' or 'remove_labels'")
+		}
+	case domain.BulkActionClose:
+		if req.StateReason != "" && req.StateReason != "completed" && req.StateReason != "not_planned" {
+			return errors.NewValidationError("the 'state_reason' parameter must be 'completed' or 'not_planned'")
+		}
+	case domain.BulkActionReassign:
+		if req.Assignees == nil {
+			return errors.NewValidationError("the reassign action requires 'assignees' (use 'none' to unassign everyone)")
+		}
+		if len(req.Assignees) > 10 {
+			return errors.NewValidationError("an issue can have at most 10 assignees")
+		}
+	case domain.BulkActionMilestone:
+		if req.Milestone == nil || *req.Milestone < 0 {
+			return errors.NewValidationError("the milestone action requires 'milestone' (a milestone number, or 'none' to clear it)")
+		}
+	case "":
+		return errors.NewValidationError("the 'action' parameter is required")
+	default:
+		return errors.NewValidationError(fmt.Sprintf("the 'action' parameter %q must be one of: label, close, reassign, milestone", req.Action))
+	}
+
+	return nil
+}
+
+// FormatBulkUpdateForMCP formats the per-issue report of a bulk update
+func (s *BulkIssuesService) FormatBulkUpdateForMCP(response *domain.BulkUpdateIssuesResponse) string {
+	var b strings.Builder
+
+	if response.DryRun {
+		fmt.Fprintf(&b, "Dry run of %s on %s: %d issues would be changed, %d unchanged, %d failed\n",
+			response.Action, response.Repository, response.Count(domain.BulkStatusPlanned),
+			response.Count(domain.BulkStatusUnchanged), response.Count(domain.BulkStatusFailed))
+	} else {
+		fmt.Fprintf(&b, "Bulk %s on %s: %d updated, %d failed, %d skipped, %d unchanged\n",
+			response.Action, response.Repository, response.Count(domain.BulkStatusUpdated),
+			response.Count(domain.BulkStatusFailed), response.Count(domain.BulkStatusSkipped),
+			response.Count(domain.BulkStatusUnchanged))
+	}
+	if response.Warning != "" {
+		fmt.Fprintf(&b, "Warning: %s\n", response.Warning)
+	}
+	b.WriteString("\n")
+
+	for _, result := range response.Results {
+		// Format: #number [status] title: change (error)
+		line := fmt.Sprintf("#%d [%s] %s", result.Number, result.Status, result.Title)
+		if result.Change != "" {
+			line += ": " + result.Change
+		}
+		if result.Error != "" {
+			line += " (" + result.Error + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	if response.DryRun && response.Count(domain.BulkStatusPlanned) > 0 {
+		b.WriteString("\nCall again with dry_run=false to apply the changes\n")
+	}
+
+	return b.String()
+}
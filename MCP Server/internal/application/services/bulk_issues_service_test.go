@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"mcp-server/internal/domain"
+)
+
+func TestApplyChangesReportsProgressForSkippedIssues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := &domain.BulkUpdateIssuesRequest{Owner: "acme", Repo: "api", Action: domain.BulkActionClose}
+	issues := []domain.Issue{{Number: 1}, {Number: 2}, {Number: 3}}
+	response := &domain.BulkUpdateIssuesResponse{Results: []domain.BulkIssueResult{
+		{Number: 1, Status: domain.BulkStatusPlanned},
+		{Number: 2, Status: domain.BulkStatusUnchanged},
+		{Number: 3, Status: domain.BulkStatusPlanned},
+	}}
+
+	var reports [][2]int
+	NewBulkIssuesService(nil, nil, false).applyChanges(ctx, req, issues, response, func(done, total int, message string) {
+		reports = append(reports, [2]int{done, total})
+	})
+
+	if len(reports) != 2 || reports[1] != [2]int{2, 2} {
+		t.Errorf("got progress %v, want it to reach 2 of 2", reports)
+	}
+	if response.Count(domain.BulkStatusSkipped) != 2 {
+		t.Errorf("got results %+v, want both planned issues skipped", response.Results)
+	}
+}
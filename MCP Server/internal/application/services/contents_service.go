@@ -0,0 +1,272 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// Content limits
+const (
+	DefaultFileBytes   = 64 * 1024
+	MaxFileBytes       = 1024 * 1024
+	DefaultTreeEntries = 1000
+	binarySniffLength  = 8000
+)
+
+// ContentsServiceInterface defines the repository contents service interface
+type ContentsServiceInterface interface {
+	GetFileContents(req *domain.GetFileContentsRequest) (*domain.FileContents, error)
+	ListDirectory(req *domain.ListDirectoryRequest) (*domain.ListDirectoryResponse, error)
+	GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error)
+	SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error)
+	FormatEntriesForMCP(entries []domain.ContentEntry) []string
+	FormatTreeForMCP(entries []domain.TreeEntry) []string
+	FormatSearchResultsForMCP(items []domain.CodeSearchResult) []string
+}
+
+// ContentsService implements business logic for repository contents
+type ContentsService struct {
+	repo     repositories.ContentsRepositoryInterface
+	resolver RepositoryResolver
+}
+
+// NewContentsService creates a new ContentsService instance
+func NewContentsService(repo repositories.ContentsRepositoryInterface, resolver RepositoryResolver) *ContentsService {
+	return &ContentsService{
+		repo:     repo,
+		resolver: resolver,
+	}
+}
+
+// GetFileContents fetches a file, detects binary content and truncates large files
+func (s *ContentsService) GetFileContents(req *domain.GetFileContentsRequest) (*domain.FileContents, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if strings.Trim(req.Path, "/") == "" {
+		return nil, errors.NewValidationError("the 'path' parameter is required")
+	}
+	if err := validateContentPath(req.Path); err != nil {
+		return nil, err
+	}
+	if req.MaxBytes < 0 || req.MaxBytes > MaxFileBytes {
+		return nil, errors.NewValidationError(fmt.Sprintf("the 'max_bytes' parameter must be between 1 and %d", MaxFileBytes))
+	}
+	if req.MaxBytes == 0 {
+		req.MaxBytes = DefaultFileBytes
+	}
+
+	entry, data, err := s.repo.GetFileContents(req)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &domain.FileContents{Entry: *entry}
+	if isBinary(data) {
+		file.Binary = true
+		return file, nil
+	}
+
+	// Large files are only partly downloaded; the entry has the full size
+	content, truncated := headTruncate(data, entry.Size, req.MaxBytes)
+	file.Content = string(content)
+	file.Truncated = truncated
+
+	return file, nil
+}
+
+// ListDirectory lists the entries of a directory, directories first
+func (s *ContentsService) ListDirectory(req *domain.ListDirectoryRequest) (*domain.ListDirectoryResponse, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if err := validateContentPath(req.Path); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListDirectory(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]domain.ContentEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "dir" {
+			sorted = append(sorted, entry)
+		}
+	}
+	for _, entry := range entries {
+		if entry.Type != "dir" {
+			sorted = append(sorted, entry)
+		}
+	}
+
+	return &domain.ListDirectoryResponse{
+		Entries: sorted,
+		Count:   len(sorted),
+	}, nil
+}
+
+// GetRepoTree fetches the tree of a ref, defaulting to HEAD, capped at MaxEntries
+func (s *ContentsService) GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.MaxEntries < 0 {
+		return nil, errors.NewValidationError("the 'max_entries' parameter must be positive")
+	}
+	if req.MaxEntries == 0 {
+		req.MaxEntries = DefaultTreeEntries
+	}
+	if req.Ref == "" {
+		req.Ref = "HEAD"
+	}
+
+	tree, err := s.repo.GetRepoTree(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tree.Entries) > req.MaxEntries {
+		tree.Entries = tree.Entries[:req.MaxEntries]
+		tree.Truncated = true
+	}
+
+	return tree, nil
+}
+
+// SearchCode searches code, scoping the query to a repository when one is given
+func (s *ContentsService) SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.NewValidationError("the 'query' parameter is required")
+	}
+	if req.PerPage < 0 || req.PerPage > 100 {
+		return nil, errors.NewValidationError("the 'per_page' parameter must be between 1 and 100")
+	}
+	if req.PerPage == 0 {
+		req.PerPage = 20
+	}
+
+	if req.Repo != "" {
+		req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+		if err := validateRepository(req.Owner, req.Repo); err != nil {
+			return nil, err
+		}
+		req.Query = fmt.Sprintf("%s repo:%s/%s", req.Query, req.Owner, req.Repo)
+	} else if req.Owner != "" {
+		req.Query = fmt.Sprintf("%s user:%s", req.Query, req.Owner)
+	}
+
+	return s.repo.SearchCode(req)
+}
+
+// FormatEntriesForMCP formats directory entries for MCP output
+func (s *ContentsService) FormatEntriesForMCP(entries []domain.ContentEntry) []string {
+	var formatted []string
+
+	for _, entry := range entries {
+		// Format: [type] path (size bytes)
+		formattedEntry := fmt.Sprintf("[%s] %s", entry.Type, entry.Path)
+		if entry.Type == "file" {
+			formattedEntry += fmt.Sprintf(" (%d bytes)", entry.Size)
+		}
+		formatted = append(formatted, formattedEntry)
+	}
+
+	return formatted
+}
+
+// FormatTreeForMCP formats tree entries for MCP output, one path per line
+func (s *ContentsService) FormatTreeForMCP(entries []domain.TreeEntry) []string {
+	var formatted []string
+
+	for _, entry := range entries {
+		path := entry.Path
+		if entry.Type == "tree" {
+			path += "/"
+		}
+		formatted = append(formatted, path)
+	}
+
+	return formatted
+}
+
+// FormatSearchResultsForMCP formats code search hits for MCP output
+func (s *ContentsService) FormatSearchResultsForMCP(items []domain.CodeSearchResult) []string {
+	var formatted []string
+
+	for _, item := range items {
+		// Format: owner/repo:path followed by matching fragments
+		formattedItem := fmt.Sprintf("%s:%s", item.Repository, item.Path)
+		for _, fragment := range item.Fragments {
+			formattedItem += "\n  " + strings.ReplaceAll(strings.TrimSpace(fragment), "\n", "\n  ")
+		}
+		formatted = append(formatted, formattedItem)
+	}
+
+	return formatted
+}
+
+// validateContentPath rejects empty, "." and ".." segments. Each segment is
+// escaped on its own, so ".." would survive into the URL and, once the server
+// normalizes it, reach a path outside the repository the policy checked.
+// Leading and trailing slashes are allowed and ignored.
+func validateContentPath(path string) error {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.NewValidationError(fmt.Sprintf("invalid path %q: empty, '.' and '..' segments are not allowed", path))
+		}
+	}
+	return nil
+}
+
+// isBinary reports whether data looks like a binary file: a NUL byte in the
+// first bytes or content that is not valid UTF-8
+func isBinary(data []byte) bool {
+	sniff := data
+	cut := len(sniff) > binarySniffLength
+	if cut {
+		sniff = sniff[:binarySniffLength]
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return true
+	}
+
+	// A multi-byte rune may have been cut at the sniff boundary
+	for i := 0; cut && i < utf8.UTFMax-1 && len(sniff) > 0 && !utf8.Valid(sniff); i++ {
+		sniff = sniff[:len(sniff)-1]
+	}
+	return !utf8.Valid(sniff)
+}
+
+// headTruncate keeps the first limit bytes of data, ending at a line
+// boundary, and appends an explicit truncation marker. size is the size of
+// the whole file, which may be more than the bytes downloaded.
+func headTruncate(data []byte, size, limit int) ([]byte, bool) {
+	size = max(size, len(data))
+	if size <= limit {
+		return data, false
+	}
+	limit = min(limit, len(data))
+
+	head := data[:limit]
+	if i := bytes.LastIndexByte(head, '\n'); i > 0 {
+		head = head[:i+1]
+	}
+
+	marker := fmt.Sprintf("\n... [truncated: showing first %d of %d bytes] ...\n", len(head), size)
+	return append(append([]byte{}, head...), marker...), true
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// stubContentsRepository serves a single file and records the requested paths
+type stubContentsRepository struct {
+	data  []byte
+	paths []string
+}
+
+func (r *stubContentsRepository) GetFileContents(req *domain.GetFileContentsRequest) (*domain.ContentEntry, []byte, error) {
+	r.paths = append(r.paths, req.Path)
+	return &domain.ContentEntry{Type: "file", Path: req.Path, Size: len(r.data)}, r.data, nil
+}
+
+func (r *stubContentsRepository) ListDirectory(req *domain.ListDirectoryRequest) ([]domain.ContentEntry, error) {
+	r.paths = append(r.paths, req.Path)
+	return nil, nil
+}
+
+func (r *stubContentsRepository) GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error) {
+	return &domain.RepoTree{}, nil
+}
+
+func (r *stubContentsRepository) SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error) {
+	return &domain.SearchCodeResponse{}, nil
+}
+
+func TestContentsServiceRejectsPathTraversal(t *testing.T) {
+	testCases := []struct {
+		path  string
+		valid bool
+	}{
+		{"README.md", true},
+		{"/docs/guide.md", true},
+		{"docs/", true},
+		{".github/workflows/ci.yml", true},
+		{"docs/..md", true},
+		{"../../../other/repo/contents/x", false},
+		{"docs/../../secret/contents/x", false},
+		{"docs/./guide.md", false},
+		{"docs//guide.md", false},
+		{"..", false},
+	}
+
+	for _, tc := range testCases {
+		repo := &stubContentsRepository{data: []byte("text")}
+		service := NewContentsService(repo, nil)
+
+		_, fileErr := service.GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: tc.path})
+		_, dirErr := service.ListDirectory(&domain.ListDirectoryRequest{Owner: "acme", Repo: "api", Path: tc.path})
+
+		for _, err := range []error{fileErr, dirErr} {
+			if valid := err == nil; valid != tc.valid {
+				t.Errorf("%q: valid = %v, want %v (%v)", tc.path, valid, tc.valid, err)
+			} else if err != nil && appErrorCode(t, err) != errors.ErrCodeValidation {
+				t.Errorf("%q: got %v, want a validation error", tc.path, err)
+			}
+		}
+		if !tc.valid && len(repo.paths) > 0 {
+			t.Errorf("%q: reached the repository with %v", tc.path, repo.paths)
+		}
+	}
+
+	// The root directory is listed with an empty path
+	if _, err := NewContentsService(&stubContentsRepository{}, nil).ListDirectory(&domain.ListDirectoryRequest{Owner: "acme", Repo: "api"}); err != nil {
+		t.Errorf("root directory: unexpected error %v", err)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	euro := "€" // three bytes in UTF-8
+
+	testCases := []struct {
+		name   string
+		data   []byte
+		binary bool
+	}{
+		{"empty", nil, false},
+		{"text", []byte("package main\n"), false},
+		{"utf-8", []byte("héllo " + euro), false},
+		{"nul byte", []byte("abc\x00def"), true},
+		{"invalid utf-8", []byte{0xff, 0xfe, 'a'}, true},
+		{"invalid trailing byte", []byte("abc\xff"), true},
+		{"png header", []byte("\x89PNG\r\n\x1a\n\x00\x00"), true},
+		{"nul after sniff window", append([]byte(strings.Repeat("a", binarySniffLength)), 0), false},
+		{"rune cut at sniff window", []byte(strings.Repeat("a", binarySniffLength-1) + euro), false},
+	}
+
+	for _, tc := range testCases {
+		if binary := isBinary(tc.data); binary != tc.binary {
+			t.Errorf("%s: got %v, want %v", tc.name, binary, tc.binary)
+		}
+	}
+}
+
+func TestHeadTruncate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      string
+		size      int
+		limit     int
+		expected  string
+		truncated bool
+	}{
+		{"fits", "a\nb\n", 4, 10, "a\nb\n", false},
+		{"exact", "a\nb\n", 4, 4, "a\nb\n", false},
+		{"line boundary", "first\nsecond\nthird\n", 19, 15, "first\nsecond\n\n... [truncated: showing first 13 of 19 bytes] ...\n", true},
+		{"no newline", "abcdefghij", 10, 4, "abcd\n... [truncated: showing first 4 of 10 bytes] ...\n", true},
+		{"newline first", "\nabcdef", 7, 4, "\nabc\n... [truncated: showing first 4 of 7 bytes] ...\n", true},
+		{"partly downloaded", "one\ntwo\nt", 5000000, 8, "one\ntwo\n\n... [truncated: showing first 8 of 5000000 bytes] ...\n", true},
+		{"unknown size", "a\nb\n", 0, 10, "a\nb\n", false},
+	}
+
+	for _, tc := range testCases {
+		got, truncated := headTruncate([]byte(tc.data), tc.size, tc.limit)
+		if string(got) != tc.expected || truncated != tc.truncated {
+			t.Errorf("%s: got %q (%v), want %q (%v)", tc.name, got, truncated, tc.expected, tc.truncated)
+		}
+	}
+}
+
+func TestGetFileContentsDetectsBinaryAndTruncates(t *testing.T) {
+	binary, err := NewContentsService(&stubContentsRepository{data: []byte("\x00\x01")}, nil).
+		GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "logo.png"})
+	if err != nil || !binary.Binary || binary.Content != "" {
+		t.Errorf("binary file: got %+v, %v", binary, err)
+	}
+
+	text, err := NewContentsService(&stubContentsRepository{data: []byte(strings.Repeat("line\n", 10))}, nil).
+		GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "notes.txt", MaxBytes: 12})
+	if err != nil || !text.Truncated || !strings.HasPrefix(text.Content, "line\nline\n\n... [truncated") {
+		t.Errorf("large file: got %+v, %v", text, err)
+	}
+
+	for _, maxBytes := range []int{-1, MaxFileBytes + 1} {
+		_, err := NewContentsService(&stubContentsRepository{}, nil).
+			GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "notes.txt", MaxBytes: maxBytes})
+		if appErrorCode(t, err) != errors.ErrCodeValidation {
+			t.Errorf("max_bytes %d: got %v, want a validation error", maxBytes, err)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+const (
+	// defaultChangeLimit is how many changes one call returns by default
+	defaultChangeLimit = 100
+	// maxChangeLimit bounds how many changes one call returns
+	maxChangeLimit = 500
+	// defaultChangeWindow is how far back the feed starts without a checkpoint
+	defaultChangeWindow = 24 * time.Hour
+	// maxEventPages bounds how many pages of each listing are read per call
+	maxEventPages = 10
+	// eventPageSize is the page size of the listings
+	eventPageSize = 100
+	// commentExcerptLength is how much of a comment the feed shows
+	commentExcerptLength = 80
+)
+
+// eventChangeTypes maps the issue events kept in the feed to change types
+var eventChangeTypes = map[string]string{
+	"closed":     domain.ChangeClosed,
+	"reopened":   domain.ChangeReopened,
+	"labeled":    domain.ChangeLabeled,
+	"unlabeled":  domain.ChangeUnlabeled,
+	"assigned":   domain.ChangeAssigned,
+	"unassigned": domain.ChangeUnassigned,
+}
+
+// EventsServiceInterface defines the issue change feed service interface
+type EventsServiceInterface interface {
+	GetRepoEventsSince(req *domain.GetRepoEventsRequest) (*domain.GetRepoEventsResponse, error)
+	FormatChangesForMCP(response *domain.GetRepoEventsResponse) string
+}
+
+// EventsService builds a change feed of the issues of a repository from the
+// issue events, issue comments and issue listings
+type EventsService struct {
+	repo     repositories.EventsRepositoryInterface
+	resolver RepositoryResolver
+}
+
+// NewEventsService creates a new EventsService instance
+func NewEventsService(repo repositories.EventsRepositoryInterface, resolver RepositoryResolver) *EventsService {
+	return &EventsService{
+		repo:     repo,
+		resolver: resolver,
+	}
+}
+
+// checkpoint is the decoded form of a checkpoint token: the time of the last
+// returned change and the IDs of the changes returned at that time
+type checkpoint struct {
+	Repository string    `json:"r"`
+	Since      time.Time `json:"t"`
+	Seen       []string  `json:"s,omitempty"`
+}
+
+// GetRepoEventsSince returns the changes since the checkpoint (or since
+// req.Since), oldest first, and a checkpoint that resumes after them
+func (s *EventsService) GetRepoEventsSince(req *domain.GetRepoEventsRequest) (*domain.GetRepoEventsResponse, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	repository := req.Owner + "/" + req.Repo
+
+	from, err := startingPoint(req, repository)
+	if err != nil {
+		return nil, err
+	}
+	types, err := changeTypeFilter(req.Types)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit < 0 {
+		return nil, errors.NewValidationError("the 'limit' parameter must be positive")
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultChangeLimit
+	}
+	req.Limit = min(req.Limit, maxChangeLimit)
+
+	changes, cut, err := s.collectChanges(req, from.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(from.Seen))
+	for _, id := range from.Seen {
+		seen[id] = true
+	}
+	var fresh []domain.IssueChange
+	for _, change := range changes {
+		if change.At.Before(from.Since) || (change.At.Equal(from.Since) && seen[change.ID]) {
+			continue
+		}
+		fresh = append(fresh, change)
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		if !fresh[i].At.Equal(fresh[j].At) {
+			return fresh[i].At.Before(fresh[j].At)
+		}
+		return fresh[i].ID < fresh[j].ID
+	})
+
+	response := &domain.GetRepoEventsResponse{
+		Repository: repository,
+		Since:      from.Since,
+	}
+	// A cut listing leaves a gap after cut.until; changes past it are held
+	// back so that the checkpoint never moves over unread changes
+	if cut != nil {
+		kept := 0
+		for kept < len(fresh) && !fresh[kept].At.After(cut.until) {
+			kept++
+		}
+		if kept < len(fresh) && cut.until.After(from.Since) {
+			response.More = true
+		}
+		fresh = fresh[:kept]
+		if cut.until.After(from.Since) {
+			response.Warning = fmt.Sprintf("more than %d pages of %s since %s; changes after %s follow in the next call", maxEventPages, cut.listing, from.Since.Format(time.RFC3339), cut.until.Format(time.RFC3339))
+		} else {
+			response.Warning = fmt.Sprintf("more than %d pages of %s since %s; the checkpoint cannot move past them, pass a later since to skip ahead", maxEventPages, cut.listing, from.Since.Format(time.RFC3339))
+		}
+	}
+	if len(fresh) > req.Limit {
+		fresh = fresh[:req.Limit]
+		response.More = true
+	}
+
+	// The checkpoint moves to the last returned change; changes the type
+	// filter drops are passed over as well
+	next := from
+	for _, change := range fresh {
+		if !change.At.Equal(next.Since) {
+			next = checkpoint{Repository: repository, Since: change.At}
+		}
+		next.Seen = append(next.Seen, change.ID)
+		if types == nil || types[change.Type] {
+			response.Changes = append(response.Changes, change)
+		}
+	}
+	response.Checkpoint = encodeCheckpoint(next)
+
+	return response, nil
+}
+
+// startingPoint decodes the checkpoint or, without one, turns req.Since into
+// a starting point; the default is the last 24 hours
+func startingPoint(req *domain.GetRepoEventsRequest, repository string) (checkpoint, error) {
+	if req.Checkpoint != "" {
+		from, err := decodeCheckpoint(req.Checkpoint)
+		if err != nil {
+			return checkpoint{}, err
+		}
+		if !strings.EqualFold(from.Repository, repository) {
+			return checkpoint{}, errors.NewValidationError(fmt.Sprintf("the checkpoint belongs to %s, not %s", from.Repository, repository))
+		}
+		return from, nil
+	}
+
+	if req.Since == "" {
+		return checkpoint{Repository: repository, Since: time.Now().Add(-defaultChangeWindow).UTC().Truncate(time.Second)}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if since, err := time.Parse(layout, req.Since); err == nil {
+			return checkpoint{Repository: repository, Since: since.UTC()}, nil
+		}
+	}
+	return checkpoint{}, errors.NewValidationError(fmt.Sprintf("invalid since %q: use YYYY-MM-DD or an RFC 3339 time", req.Since))
+}
+
+// changeTypeFilter validates the requested change types; nil keeps every type
+func changeTypeFilter(requested []string) (map[string]bool, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	types := make(map[string]bool, len(requested))
+	for _, t := range requested {
+		t = strings.ToLower(t)
+		known := false
+		for _, changeType := range domain.ChangeTypes {
+			known = known || changeType == t
+		}
+		if !known {
+			return nil, errors.NewValidationError(fmt.Sprintf("unknown change type %q: use %s", t, strings.Join(domain.ChangeTypes, ", ")))
+		}
+		types[t] = true
+	}
+	return types, nil
+}
+
+// listingCut records a listing that was cut at maxEventPages: every change
+// up to until was read, later ones may be missing
+type listingCut struct {
+	listing string
+	until   time.Time
+}
+
+// collectChanges reads the three sources of the feed back to since. When a
+// listing was cut at maxEventPages, the second result tells up to when the
+// changes are complete; with several cuts it is the earliest one.
+func (s *EventsService) collectChanges(req *domain.GetRepoEventsRequest, since time.Time) ([]domain.IssueChange, *listingCut, error) {
+	var changes []domain.IssueChange
+	var cut *listingCut
+	cutAt := func(listing string, until time.Time) {
+		if cut == nil || until.Before(cut.until) {
+			cut = &listingCut{listing: listing, until: until}
+		}
+	}
+	page := &domain.ListRepoPageRequest{Owner: req.Owner, Repo: req.Repo, Since: since, PerPage: eventPageSize}
+
+	// Opened issues: issues updated since the checkpoint that were also
+	// created after it. The listing is oldest created first, so a cut one
+	// is complete up to the creation of the last issue read.
+	last := since
+	for page.Page = 1; page.Page > 0 && page.Page <= maxEventPages; {
+		found, err := s.repo.ListUpdatedIssues(page)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, issue := range found.Issues {
+			if issue.CreatedAt.Before(since) {
+				continue
+			}
+			last = issue.CreatedAt
+			changes = append(changes, domain.IssueChange{
+				ID:         fmt.Sprintf("issue:%d", issue.Number),
+				Type:       domain.ChangeOpened,
+				Issue:      issue.Number,
+				IssueTitle: issue.Title,
+				Actor:      issue.User.Login,
+				At:         issue.CreatedAt,
+				URL:        issue.HTMLURL,
+			})
+		}
+		page.Page = found.NextPage
+	}
+	if page.Page > maxEventPages {
+		cutAt("issues", last)
+	}
+
+	// Comments created since the checkpoint, oldest first as well
+	last = since
+	for page.Page = 1; page.Page > 0 && page.Page <= maxEventPages; {
+		found, err := s.repo.ListRepoIssueComments(page)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, comment := range found.Comments {
+			if comment.CreatedAt.Before(since) {
+				continue
+			}
+			last = comment.CreatedAt
+			number, _ := strconv.Atoi(path.Base(comment.IssueURL))
+			changes = append(changes, domain.IssueChange{
+				ID:     fmt.Sprintf("comment:%d", comment.ID),
+				Type:   domain.ChangeCommented,
+				Issue:  number,
+				Actor:  comment.Author.Login,
+				At:     comment.CreatedAt,
+				Detail: commentExcerpt(comment.Body),
+				URL:    comment.HTMLURL,
+			})
+		}
+		page.Page = found.NextPage
+	}
+	if page.Page > maxEventPages {
+		cutAt("issue comments", last)
+	}
+
+	// Issue events, newest first, until one is older than the checkpoint.
+	// A cut listing misses the oldest events, so nothing after since is complete.
+	page.Since = time.Time{}
+	complete := false
+	for page.Page = 1; page.Page > 0 && page.Page <= maxEventPages; {
+		found, err := s.repo.ListRepoIssueEvents(page)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, event := range found.Events {
+			if event.CreatedAt.Before(since) {
+				complete = true
+				break
+			}
+			if change, ok := eventChange(event); ok {
+				changes = append(changes, change)
+			}
+		}
+		if complete || found.NextPage == 0 {
+			complete = true
+			break
+		}
+		page.Page = found.NextPage
+	}
+	if !complete {
+		cutAt("issue events", since)
+	}
+
+	return changes, cut, nil
+}
+
+// eventChange normalizes an issue event; events outside the feed are dropped
+func eventChange(event domain.IssueEvent) (domain.IssueChange, bool) {
+	changeType, ok := eventChangeTypes[event.Event]
+	if !ok {
+		return domain.IssueChange{}, false
+	}
+
+	change := domain.IssueChange{
+		ID:    fmt.Sprintf("event:%d", event.ID),
+		Type:  changeType,
+		Actor: event.Actor.Login,
+		At:    event.CreatedAt,
+	}
+	if event.Issue != nil {
+		change.Issue = event.Issue.Number
+		change.IssueTitle = event.Issue.Title
+		change.URL = event.Issue.HTMLURL
+	}
+	if event.Label != nil {
+		change.Detail = event.Label.Name
+	}
+	if event.Assignee != nil {
+		change.Detail = event.Assignee.Login
+	}
+	return change, true
+}
+
+// commentExcerpt returns the first line of a comment, shortened
+func commentExcerpt(body string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	if runes := []rune(line); len(runes) > commentExcerptLength {
+		line = string(runes[:commentExcerptLength]) + "…"
+	}
+	return line
+}
+
+// encodeCheckpoint turns a checkpoint into an opaque token
+func encodeCheckpoint(c checkpoint) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCheckpoint parses a token returned by encodeCheckpoint
+func decodeCheckpoint(token string) (checkpoint, error) {
+	var c checkpoint
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.Repository == "" || c.Since.IsZero() {
+		return checkpoint{}, errors.NewValidationError("invalid checkpoint: pass the checkpoint returned by the previous call unchanged")
+	}
+	return c, nil
+}
+
+// FormatChangesForMCP formats the change feed for MCP output
+func (s *EventsService) FormatChangesForMCP(response *domain.GetRepoEventsResponse) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d changes in %s since %s\n", len(response.Changes), response.Repository, response.Since.Format(time.RFC3339))
+	for _, change := range response.Changes {
+		fmt.Fprintf(&b, "\n%s #%d %s by %s", change.At.Format("2006-01-02 15:04"), change.Issue, change.Type, change.Actor)
+		switch change.Type {
+		case domain.ChangeCommented:
+			fmt.Fprintf(&b, ": %q", change.Detail)
+		case domain.ChangeLabeled, domain.ChangeUnlabeled, domain.ChangeAssigned, domain.ChangeUnassigned:
+			fmt.Fprintf(&b, ": %s", change.Detail)
+		}
+		if change.IssueTitle != "" {
+			fmt.Fprintf(&b, " (%s)", change.IssueTitle)
+		}
+	}
+
+	if response.Warning != "" {
+		fmt.Fprintf(&b, "\n\nWarning: %s", response.Warning)
+	}
+	fmt.Fprintf(&b, "\n\nCheckpoint: %s", response.Checkpoint)
+	if response.More {
+		b.WriteString("\nMore changes are pending; call again with this checkpoint")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-server/internal/domain"
+)
+
+// stubEventsRepository pages over fixed listings like the GitHub API: events
+// newest first, comments and issues created since req.Since oldest first
+type stubEventsRepository struct {
+	events   []domain.IssueEvent
+	comments []domain.Comment
+	issues   []domain.Issue
+}
+
+// stubPage returns the bounds of the requested page and the next page number
+func stubPage(req *domain.ListRepoPageRequest, total int) (int, int, int) {
+	start := min(max(req.Page-1, 0)*req.PerPage, total)
+	end := min(start+req.PerPage, total)
+	next := 0
+	if end < total {
+		next = max(req.Page, 1) + 1
+	}
+	return start, end, next
+}
+
+func (r *stubEventsRepository) ListRepoIssueEvents(req *domain.ListRepoPageRequest) (*domain.ListIssueEventsResponse, error) {
+	start, end, next := stubPage(req, len(r.events))
+	return &domain.ListIssueEventsResponse{Events: r.events[start:end], NextPage: next}, nil
+}
+
+func (r *stubEventsRepository) ListRepoIssueComments(req *domain.ListRepoPageRequest) (*domain.ListIssueCommentsResponse, error) {
+	var listed []domain.Comment
+	for _, comment := range r.comments {
+		if !comment.CreatedAt.Before(req.Since) {
+			listed = append(listed, comment)
+		}
+	}
+	start, end, next := stubPage(req, len(listed))
+	return &domain.ListIssueCommentsResponse{Comments: listed[start:end], NextPage: next}, nil
+}
+
+func (r *stubEventsRepository) ListUpdatedIssues(req *domain.ListRepoPageRequest) (*domain.ListUpdatedIssuesResponse, error) {
+	var listed []domain.Issue
+	for _, issue := range r.issues {
+		if !issue.CreatedAt.Before(req.Since) {
+			listed = append(listed, issue)
+		}
+	}
+	start, end, next := stubPage(req, len(listed))
+	return &domain.ListUpdatedIssuesResponse{Issues: listed[start:end], NextPage: next}, nil
+}
+
+var feedStart = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRepoEventsCheckpointSkipsNothingWhenListingsAreCut(t *testing.T) {
+	repo := &stubEventsRepository{}
+	for i := 1; i <= 1500; i++ {
+		repo.comments = append(repo.comments, domain.Comment{ID: int64(i), CreatedAt: feedStart.Add(time.Duration(i) * time.Minute)})
+	}
+	for i := 1; i <= 1200; i++ {
+		repo.issues = append(repo.issues, domain.Issue{Number: i, CreatedAt: feedStart.Add(time.Duration(i)*time.Minute + 30*time.Second)})
+	}
+	for _, minute := range []int{1490, 1200, 10} {
+		repo.events = append(repo.events, domain.IssueEvent{ID: int64(minute), Event: "closed", CreatedAt: feedStart.Add(time.Duration(minute) * time.Minute)})
+	}
+	service := NewEventsService(repo, nil)
+
+	got := map[string]int{}
+	req := &domain.GetRepoEventsRequest{Owner: "acme", Repo: "api", Since: "2025-02-01", Limit: maxChangeLimit}
+	for call := 0; call < 20; call++ {
+		response, err := service.GetRepoEventsSince(req)
+		if err != nil {
+			t.Fatalf("GetRepoEventsSince: %v", err)
+		}
+		for _, change := range response.Changes {
+			got[change.ID]++
+		}
+		if !response.More && len(response.Changes) == 0 {
+			break
+		}
+		req = &domain.GetRepoEventsRequest{Owner: "acme", Repo: "api", Checkpoint: response.Checkpoint, Limit: maxChangeLimit}
+	}
+
+	if want := len(repo.comments) + len(repo.issues) + len(repo.events); len(got) != want {
+		t.Errorf("got %d distinct changes, want %d", len(got), want)
+	}
+	for id, n := range got {
+		if n != 1 {
+			t.Errorf("change %s returned %d times, want once", id, n)
+		}
+	}
+}
+
+func TestRepoEventsCheckpointHoldsWhenEventsAreCut(t *testing.T) {
+	repo := &stubEventsRepository{
+		comments: []domain.Comment{{ID: 1, CreatedAt: feedStart.Add(time.Hour)}},
+	}
+	for i := maxEventPages*eventPageSize + 50; i > 0; i-- {
+		repo.events = append(repo.events, domain.IssueEvent{ID: int64(i), Event: "labeled", CreatedAt: feedStart.Add(time.Duration(i) * time.Second)})
+	}
+	service := NewEventsService(repo, nil)
+
+	response, err := service.GetRepoEventsSince(&domain.GetRepoEventsRequest{Owner: "acme", Repo: "api", Since: "2025-02-01"})
+	if err != nil {
+		t.Fatalf("GetRepoEventsSince: %v", err)
+	}
+	if len(response.Changes) != 0 {
+		t.Errorf("got %d changes, want none past the unread events", len(response.Changes))
+	}
+	if !strings.Contains(response.Warning, "more than 10 pages of issue events") {
+		t.Errorf("warning = %q, want it to name the cut listing", response.Warning)
+	}
+	next, err := decodeCheckpoint(response.Checkpoint)
+	if err != nil {
+		t.Fatalf("decodeCheckpoint: %v", err)
+	}
+	if !next.Since.Equal(feedStart) {
+		t.Errorf("checkpoint moved to %s, want it to stay at %s", next.Since, feedStart)
+	}
+}
+
+func TestRepoEventsCheckpointStopsAtCutComments(t *testing.T) {
+	repo := &stubEventsRepository{
+		events: []domain.IssueEvent{{ID: 1, Event: "closed", CreatedAt: feedStart.Add(48 * time.Hour)}},
+	}
+	for i := 1; i <= maxEventPages*eventPageSize+1; i++ {
+		repo.comments = append(repo.comments, domain.Comment{ID: int64(i), CreatedAt: feedStart.Add(time.Duration(i) * time.Second)})
+	}
+	service := NewEventsService(repo, nil)
+
+	response, err := service.GetRepoEventsSince(&domain.GetRepoEventsRequest{Owner: "acme", Repo: "api", Since: "2025-02-01", Limit: maxChangeLimit})
+	if err != nil {
+		t.Fatalf("GetRepoEventsSince: %v", err)
+	}
+	for _, change := range response.Changes {
+		if change.ID == "event:1" {
+			t.Errorf("the event after the unread comments was returned")
+		}
+	}
+	if !response.More {
+		t.Error("More = false, want true while comments are pending")
+	}
+	want := fmt.Sprintf("changes after %s follow", feedStart.Add(time.Duration(maxEventPages*eventPageSize)*time.Second).Format(time.RFC3339))
+	if !strings.Contains(response.Warning, want) {
+		t.Errorf("warning = %q, want it to contain %q", response.Warning, want)
+	}
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// exportPageSize is the search page size used while exporting
+const exportPageSize = 100
+
+// exportFilenamePattern restricts export file names to plain names inside the export directory
+var exportFilenamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ExportServiceInterface defines the issue export service interface
+type ExportServiceInterface interface {
+	ExportIssues(req *domain.ExportIssuesRequest, w io.Writer) (*domain.ExportIssuesResult, error)
+	ExportIssuesToFile(req *domain.ExportIssuesRequest, filename string) (*domain.ExportIssuesResult, error)
+}
+
+// ExportService streams the issues matching a filter set into CSV, JSONL or
+// a Markdown report
+type ExportService struct {
+	repo      repositories.GitHubRepositoryInterface
+	resolver  RepositoryResolver
+	exportDir string
+}
+
+// NewExportService creates a new ExportService instance. Exports are only
+// written to files inside exportDir; without one they can only be streamed.
+func NewExportService(repo repositories.GitHubRepositoryInterface, resolver RepositoryResolver, exportDir string) *ExportService {
+	return &ExportService{
+		repo:      repo,
+		resolver:  resolver,
+		exportDir: exportDir,
+	}
+}
+
+// ExportIssues writes the matching issues to w page by page. The Markdown
+// report is grouped, so it is written once all pages are read.
+func (s *ExportService) ExportIssues(req *domain.ExportIssuesRequest, w io.Writer) (*domain.ExportIssuesResult, error) {
+	if err := s.prepareExport(req); err != nil {
+		return nil, err
+	}
+	return s.export(req, w)
+}
+
+// ExportIssuesToFile writes the export to a file in the export directory.
+// The file only appears once the export is complete.
+func (s *ExportService) ExportIssuesToFile(req *domain.ExportIssuesRequest, filename string) (*domain.ExportIssuesResult, error) {
+	if s.exportDir == "" {
+		return nil, errors.NewForbiddenError("no export directory is configured; set export_dir or MCP_EXPORT_DIR to write exports to files")
+	}
+	if err := s.prepareExport(req); err != nil {
+		return nil, err
+	}
+
+	if filename == "" {
+		filename = fmt.Sprintf("%s-%s-issues-%s.%s", req.Owner, req.Repo, time.Now().Format("2006-01-02"), domain.ExportExtension(req.Format))
+	}
+	if !exportFilenamePattern.MatchString(filename) {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid file name %q: use letters, digits, '.', '-' and '_' only", filename))
+	}
+
+	if err := os.MkdirAll(s.exportDir, 0o755); err != nil {
+		return nil, errors.NewExportError(fmt.Sprintf("unable to create the export directory: %v", err))
+	}
+	file, err := os.CreateTemp(s.exportDir, "."+filename+".*")
+	if err != nil {
+		return nil, errors.NewExportError(fmt.Sprintf("unable to create the export file: %v", err))
+	}
+	defer os.Remove(file.Name())
+
+	result, err := s.export(req, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = errors.NewExportError(fmt.Sprintf("unable to write the export file: %v", closeErr))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(s.exportDir, filename)
+	if err := os.Rename(file.Name(), path); err != nil {
+		return nil, errors.NewExportError(fmt.Sprintf("unable to write the export file: %v", err))
+	}
+	result.Path = path
+
+	return result, nil
+}
+
+// prepareExport resolves the repository and validates the request
+func (s *ExportService) prepareExport(req *domain.ExportIssuesRequest) error {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return err
+	}
+
+	req.Format = strings.ToLower(req.Format)
+	switch req.Format {
+	case domain.ExportFormatCSV, domain.ExportFormatJSONL, domain.ExportFormatMarkdown:
+	case "":
+		return errors.NewValidationError("the 'format' parameter is required")
+	default:
+		return errors.NewValidationError(fmt.Sprintf("invalid format %q: use csv, jsonl or markdown", req.Format))
+	}
+
+	if req.State == "" {
+		req.State = "open"
+	}
+	if req.State != "open" && req.State != "closed" && req.State != "all" {
+		return errors.NewValidationError(fmt.Sprintf("invalid state %q: use open, closed or all", req.State))
+	}
+
+	if req.GroupBy == "" {
+		req.GroupBy = domain.ExportGroupByLabel
+	}
+	if req.GroupBy != domain.ExportGroupByLabel && req.GroupBy != domain.ExportGroupByMilestone && req.GroupBy != domain.ExportGroupByNone {
+		return errors.NewValidationError(fmt.Sprintf("invalid group_by %q: use label, milestone or none", req.GroupBy))
+	}
+
+	if req.Since != "" {
+		if _, err := time.Parse("2006-01-02", req.Since); err != nil {
+			return errors.NewValidationError(fmt.Sprintf("invalid since date %q: use YYYY-MM-DD", req.Since))
+		}
+	}
+
+	if req.Limit < 0 {
+		return errors.NewValidationError("the 'limit' parameter must be positive")
+	}
+	if req.Limit == 0 || req.Limit > domain.MaxExportIssues {
+		req.Limit = domain.MaxExportIssues
+	}
+
+	return nil
+}
+
+// export pages through the search results and hands every issue to the
+// writer for the requested format
+func (s *ExportService) export(req *domain.ExportIssuesRequest, w io.Writer) (*domain.ExportIssuesResult, error) {
+	writer := newIssueWriter(req, w)
+	result := &domain.ExportIssuesResult{
+		Repository: req.Owner + "/" + req.Repo,
+		Format:     req.Format,
+	}
+
+	query, err := exportQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	for page := 1; page > 0 && result.Count < req.Limit; {
+		found, err := s.repo.SearchIssues(&domain.SearchIssuesRequest{
+			Query:   query,
+			PerPage: min(exportPageSize, req.Limit),
+			Page:    page,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range found.Issues {
+			if result.Count == req.Limit {
+				break
+			}
+			if !inRepository(&found.Issues[i], req.Owner, req.Repo) {
+				continue
+			}
+			if err := writer.WriteIssue(&found.Issues[i]); err != nil {
+				return nil, errors.NewExportError(fmt.Sprintf("unable to write the export: %v", err))
+			}
+			result.Count++
+		}
+		result.Truncated = found.TotalCount > result.Count
+		page = found.NextPage
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, errors.NewExportError(fmt.Sprintf("unable to write the export: %v", err))
+	}
+
+	return result, nil
+}
+
+// exportQuery turns the export filters into issue search qualifiers, scoped
+// to the repository being exported
+func exportQuery(req *domain.ExportIssuesRequest) (string, error) {
+	var terms []string
+
+	if req.State != "all" {
+		terms = append(terms, "is:"+req.State)
+	}
+	for _, label := range req.Labels {
+		terms = append(terms, "label:"+searchValue(label))
+	}
+	switch req.Milestone {
+	case "":
+	case "none":
+		terms = append(terms, "no:milestone")
+	default:
+		terms = append(terms, "milestone:"+searchValue(req.Milestone))
+	}
+	switch req.Assignee {
+	case "":
+	case "none":
+		terms = append(terms, "no:assignee")
+	default:
+		terms = append(terms, "assignee:"+req.Assignee)
+	}
+	if req.Author != "" {
+		terms = append(terms, "author:"+req.Author)
+	}
+	if req.Since != "" {
+		terms = append(terms, "updated:>="+req.Since)
+	}
+	if req.Query != "" {
+		terms = append(terms, req.Query)
+	}
+
+	return scopedIssueQuery(req.Owner, req.Repo, strings.Join(terms, " "))
+}
+
+// searchValue quotes qualifier values that contain spaces
+func searchValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
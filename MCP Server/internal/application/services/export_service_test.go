@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+func TestExportQuery(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      domain.ExportIssuesRequest
+		expected string
+		code     string
+	}{
+		{"all", domain.ExportIssuesRequest{State: "all"}, "repo:acme/api is:issue", ""},
+		{
+			"filters",
+			domain.ExportIssuesRequest{State: "open", Labels: []string{"bug", "good first issue"}, Milestone: "none", Assignee: "alice", Since: "2025-01-01"},
+			`repo:acme/api is:issue is:open label:bug label:"good first issue" no:milestone assignee:alice updated:>=2025-01-01`,
+			"",
+		},
+		{"extra query", domain.ExportIssuesRequest{State: "all", Query: "comments:>5"}, "repo:acme/api is:issue comments:>5", ""},
+		{"scoped query", domain.ExportIssuesRequest{State: "all", Query: "repo:acme/secret"}, "", errors.ErrCodeValidation},
+		{"org query", domain.ExportIssuesRequest{State: "all", Query: "comments:>5 org:globex"}, "", errors.ErrCodeValidation},
+		{"scope in a filter", domain.ExportIssuesRequest{State: "all", Assignee: "alice user:mallory"}, "", errors.ErrCodeValidation},
+	}
+
+	for _, tc := range testCases {
+		tc.req.Owner, tc.req.Repo = "acme", "api"
+		query, err := exportQuery(&tc.req)
+		if code := appErrorCode(t, err); code != tc.code || query != tc.expected {
+			t.Errorf("%s: got %q (%q), want %q (%q)", tc.name, query, code, tc.expected, tc.code)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+)
+
+// issueWriter writes exported issues in one format
+type issueWriter interface {
+	WriteIssue(issue *domain.Issue) error
+	// Close writes whatever the format keeps until the end
+	Close() error
+}
+
+// newIssueWriter returns the writer for the format of the request
+func newIssueWriter(req *domain.ExportIssuesRequest, w io.Writer) issueWriter {
+	switch req.Format {
+	case domain.ExportFormatCSV:
+		return &csvIssueWriter{w: csv.NewWriter(w)}
+	case domain.ExportFormatJSONL:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		return &jsonlIssueWriter{encoder: encoder}
+	default:
+		return &markdownIssueWriter{w: w, req: req}
+	}
+}
+
+// csvIssueColumns is the header row of CSV exports
+var csvIssueColumns = []string{"number", "title", "state", "author", "assignees", "labels", "milestone", "created_at", "updated_at", "closed_at", "url"}
+
+// csvIssueWriter writes one row per issue; lists are joined with ';'
+type csvIssueWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvIssueWriter) WriteIssue(issue *domain.Issue) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvIssueColumns); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	closedAt := ""
+	if issue.ClosedAt != nil {
+		closedAt = issue.ClosedAt.Format(time.RFC3339)
+	}
+	row := []string{
+		strconv.Itoa(issue.Number),
+		issue.Title,
+		issue.State,
+		issue.User.Login,
+		strings.Join(assigneeLogins(issue), ";"),
+		strings.Join(labelNames(issue), ";"),
+		milestoneTitle(issue),
+		issue.CreatedAt.Format(time.RFC3339),
+		issue.UpdatedAt.Format(time.RFC3339),
+		closedAt,
+		issue.HTMLURL,
+	}
+	if err := c.w.Write(row); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvIssueWriter) Close() error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvIssueColumns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlIssueWriter writes one JSON object per line
+type jsonlIssueWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlIssueWriter) WriteIssue(issue *domain.Issue) error {
+	return j.encoder.Encode(issue)
+}
+
+func (j *jsonlIssueWriter) Close() error {
+	return nil
+}
+
+// markdownIssueWriter collects the issues and writes a report grouped by
+// label or milestone when closed
+type markdownIssueWriter struct {
+	w      io.Writer
+	req    *domain.ExportIssuesRequest
+	issues []domain.Issue
+}
+
+func (m *markdownIssueWriter) WriteIssue(issue *domain.Issue) error {
+	m.issues = append(m.issues, *issue)
+	return nil
+}
+
+func (m *markdownIssueWriter) Close() error {
+	var b strings.Builder
+
+	open := 0
+	for _, issue := range m.issues {
+		if issue.State == "open" {
+			open++
+		}
+	}
+
+	fmt.Fprintf(&b, "# Issues in %s/%s\n\n", m.req.Owner, m.req.Repo)
+	fmt.Fprintf(&b, "Generated %s. Filters: %s.\n\n", time.Now().Format("2006-01-02"), describeExportFilters(m.req))
+	fmt.Fprintf(&b, "%d issues: %d open, %d closed.\n", len(m.issues), open, len(m.issues)-open)
+
+	switch m.req.GroupBy {
+	case domain.ExportGroupByMilestone:
+		writeIssueGroups(&b, groupIssues(m.issues, func(issue *domain.Issue) []string {
+			if title := milestoneTitle(issue); title != "" {
+				return []string{title}
+			}
+			return nil
+		}), "No milestone")
+	case domain.ExportGroupByLabel:
+		b.WriteString("\nIssues with several labels are listed under each of them.\n")
+		writeIssueGroups(&b, groupIssues(m.issues, labelNames), "No label")
+	default:
+		b.WriteString("\n")
+		for i := range m.issues {
+			writeIssueLine(&b, &m.issues[i])
+		}
+	}
+
+	_, err := io.WriteString(m.w, b.String())
+	return err
+}
+
+// issueGroup is a section of the Markdown report
+type issueGroup struct {
+	name   string
+	issues []*domain.Issue
+}
+
+// groupIssues sorts issues into groups by the keys returned for each one;
+// issues without keys end up in the group with an empty name
+func groupIssues(issues []domain.Issue, keys func(issue *domain.Issue) []string) []issueGroup {
+	byName := map[string]*issueGroup{}
+	var groups []*issueGroup
+
+	add := func(name string, issue *domain.Issue) {
+		group, ok := byName[name]
+		if !ok {
+			group = &issueGroup{name: name}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.issues = append(group.issues, issue)
+	}
+
+	for i := range issues {
+		names := keys(&issues[i])
+		if len(names) == 0 {
+			add("", &issues[i])
+		}
+		for _, name := range names {
+			add(name, &issues[i])
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].name == "" || groups[j].name == "" {
+			return groups[j].name == ""
+		}
+		return strings.ToLower(groups[i].name) < strings.ToLower(groups[j].name)
+	})
+
+	result := make([]issueGroup, len(groups))
+	for i, group := range groups {
+		result[i] = *group
+	}
+	return result
+}
+
+// writeIssueGroups writes one section per group
+func writeIssueGroups(b *strings.Builder, groups []issueGroup, ungrouped string) {
+	for _, group := range groups {
+		name := group.name
+		if name == "" {
+			name = ungrouped
+		}
+		fmt.Fprintf(b, "\n## %s (%d)\n\n", name, len(group.issues))
+		for _, issue := range group.issues {
+			writeIssueLine(b, issue)
+		}
+	}
+}
+
+// writeIssueLine writes an issue as a Markdown list item
+func writeIssueLine(b *strings.Builder, issue *domain.Issue) {
+	fmt.Fprintf(b, "- [#%d](%s) %s (%s", issue.Number, issue.HTMLURL, issue.Title, issue.State)
+	if logins := assigneeLogins(issue); len(logins) > 0 {
+		fmt.Fprintf(b, ", @%s", strings.Join(logins, ", @"))
+	}
+	fmt.Fprintf(b, ", updated %s)\n", issue.UpdatedAt.Format("2006-01-02"))
+}
+
+// describeExportFilters summarizes the filters of an export for the report header
+func describeExportFilters(req *domain.ExportIssuesRequest) string {
+	filters := []string{"state " + req.State}
+	if len(req.Labels) > 0 {
+		filters = append(filters, "labels "+strings.Join(req.Labels, ", "))
+	}
+	if req.Milestone != "" {
+		filters = append(filters, "milestone "+req.Milestone)
+	}
+	if req.Assignee != "" {
+		filters = append(filters, "assignee "+req.Assignee)
+	}
+	if req.Author != "" {
+		filters = append(filters, "author "+req.Author)
+	}
+	if req.Since != "" {
+		filters = append(filters, "updated since "+req.Since)
+	}
+	if req.Query != "" {
+		filters = append(filters, fmt.Sprintf("query %q", req.Query))
+	}
+	return strings.Join(filters, "; ")
+}
+
+func assigneeLogins(issue *domain.Issue) []string {
+	logins := make([]string, len(issue.Assignees))
+	for i, assignee := range issue.Assignees {
+		logins[i] = assignee.Login
+	}
+	return logins
+}
+
+func labelNames(issue *domain.Issue) []string {
+	names := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		names[i] = label.Name
+	}
+	return names
+}
+
+func milestoneTitle(issue *domain.Issue) string {
+	if issue.Milestone == nil {
+		return ""
+	}
+	return issue.Milestone.Title
+}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"mcp-server/internal/domain"
 	"mcp-server/internal/infrastructure/repositories"
@@ -11,33 +13,52 @@ import (
 // IssueServiceInterface defines the issues service interface
 type IssueServiceInterface interface {
 	GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error)
+	GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error)
 	FormatIssuesForMCP(issues []domain.Issue) []string
+	FormatIssueDetailsForMCP(issue *domain.Issue) string
 	ValidateGetIssuesRequest(req *domain.GetIssuesRequest) error
 }
 
+// validIssueFields are the optional fields that can be requested
+var validIssueFields = map[string]bool{
+	domain.IssueFieldBody:      true,
+	domain.IssueFieldAssignees: true,
+	domain.IssueFieldComments:  true,
+	domain.IssueFieldLinkedPRs: true,
+	domain.IssueFieldProjects:  true,
+}
+
 // IssueService implements business logic for issues
 type IssueService struct {
-	repo repositories.GitHubRepositoryInterface
+	repo     repositories.GitHubRepositoryInterface
+	resolver RepositoryResolver
 }
 
 // NewIssueService creates a new IssueService instance
-func NewIssueService(repo repositories.GitHubRepositoryInterface) *IssueService {
+func NewIssueService(repo repositories.GitHubRepositoryInterface, resolver RepositoryResolver) *IssueService {
 	return &IssueService{
-		repo: repo,
+		repo:     repo,
+		resolver: resolver,
 	}
 }
 
 // GetIssues fetches issues with validations and business logic applied
 func (s *IssueService) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error) {
+	// Resolve aliases and default owner
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+
 	// Validate request
 	if err := s.ValidateGetIssuesRequest(req); err != nil {
 		return nil, err
 	}
 
-	// Set default state if not provided
+	// Set defaults if not provided
 	if req.State == "" {
 		req.State = "open"
 	}
+	if req.PerPage == 0 {
+		req.PerPage = 30
+	}
 
 	// Fetch data from repository
 	response, err := s.repo.GetIssues(req)
@@ -48,25 +69,117 @@ func (s *IssueService) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssue
 	return response, nil
 }
 
+// GetIssue fetches a single issue with the requested fields
+func (s *IssueService) GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.Number <= 0 {
+		return nil, errors.NewValidationError("the 'number' parameter is required")
+	}
+	if err := validateIssueFields(req.Fields); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetIssue(req)
+}
+
 // FormatIssuesForMCP formats issues for MCP output
 func (s *IssueService) FormatIssuesForMCP(issues []domain.Issue) []string {
 	var formatted []string
-	
+
 	for _, issue := range issues {
 		// Format: #[Number] [State] Title
 		formattedIssue := fmt.Sprintf("#%d [%s] %s", issue.Number, issue.State, issue.Title)
+		for _, line := range issueExtras(&issue) {
+			formattedIssue += "\n  " + line
+		}
+		if issue.Body != "" {
+			formattedIssue += "\n  " + strings.ReplaceAll(issue.Body, "\n", "\n  ")
+		}
 		formatted = append(formatted, formattedIssue)
 	}
 
 	return formatted
 }
 
+// FormatIssueDetailsForMCP formats a single issue with its body and comments
+func (s *IssueService) FormatIssueDetailsForMCP(issue *domain.Issue) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#%d [%s] %s\n%s\n", issue.Number, issue.State, issue.Title, issue.HTMLURL)
+	fmt.Fprintf(&b, "opened by %s on %s\n", issue.User.Login, issue.CreatedAt.Format("2006-01-02"))
+	for _, line := range issueExtras(issue) {
+		b.WriteString(line + "\n")
+	}
+
+	if issue.Body != "" {
+		b.WriteString("\n" + issue.Body + "\n")
+	}
+
+	for _, comment := range issue.Comments {
+		fmt.Fprintf(&b, "\n--- %s on %s\n%s\n", comment.Author.Login, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Body)
+	}
+
+	return b.String()
+}
+
+// issueExtras returns one line per populated optional field
+func issueExtras(issue *domain.Issue) []string {
+	var lines []string
+
+	if len(issue.Labels) > 0 {
+		names := make([]string, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			names = append(names, label.Name)
+		}
+		lines = append(lines, "labels: "+strings.Join(names, ", "))
+	}
+
+	if len(issue.Assignees) > 0 {
+		logins := make([]string, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			logins = append(logins, "@"+assignee.Login)
+		}
+		lines = append(lines, "assignees: "+strings.Join(logins, ", "))
+	}
+
+	if len(issue.Comments) > 0 {
+		lines = append(lines, fmt.Sprintf("comments: %d", issue.CommentCount))
+	}
+
+	for _, pr := range issue.LinkedPullRequests {
+		lines = append(lines, fmt.Sprintf("linked PR #%d [%s] %s", pr.Number, pr.State, pr.Title))
+	}
+
+	for _, item := range issue.ProjectItems {
+		line := fmt.Sprintf("project #%d %s", item.ProjectNumber, item.ProjectTitle)
+		if len(item.Fields) > 0 {
+			names := make([]string, 0, len(item.Fields))
+			for name := range item.Fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			values := make([]string, 0, len(names))
+			for _, name := range names {
+				values = append(values, name+"="+item.Fields[name])
+			}
+			line += " (" + strings.Join(values, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
 // ValidateGetIssuesRequest validates request parameters
 func (s *IssueService) ValidateGetIssuesRequest(req *domain.GetIssuesRequest) error {
 	if req.Owner == "" {
 		return errors.NewValidationError("the 'owner' parameter is required")
 	}
-	
+
 	if req.Repo == "" {
 		return errors.NewValidationError("the 'repo' parameter is required")
 	}
@@ -76,5 +189,19 @@ func (s *IssueService) ValidateGetIssuesRequest(req *domain.GetIssuesRequest) er
 		return errors.NewValidationError("the 'state' parameter must be 'open', 'closed', or 'all'")
 	}
 
+	if req.PerPage < 0 || req.PerPage > 100 {
+		return errors.NewValidationError("the 'per_page' parameter must be between 1 and 100")
+	}
+
+	return validateIssueFields(req.Fields)
+}
+
+// validateIssueFields checks the optional field names
+func validateIssueFields(fields []string) error {
+	for _, field := range fields {
+		if !validIssueFields[field] {
+			return errors.NewValidationError(fmt.Sprintf("unknown field %q, expected one of: body, assignees, comments, linked_prs, projects", field))
+		}
+	}
 	return nil
 }
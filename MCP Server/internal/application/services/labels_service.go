@@ -0,0 +1,399 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxSyncRepositories bounds how many repositories one sync_labels call touches
+const maxSyncRepositories = 50
+
+// labelColorPattern matches a label color without the leading '#'
+var labelColorPattern = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// LabelsServiceInterface defines the labels service interface
+type LabelsServiceInterface interface {
+	ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error)
+	CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error)
+	UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error)
+	DeleteLabel(req *domain.DeleteLabelRequest) error
+	SyncLabels(req *domain.SyncLabelsRequest) (*domain.SyncLabelsResponse, error)
+	FormatLabelsForMCP(labels []domain.Label) []string
+	FormatLabelSyncForMCP(response *domain.SyncLabelsResponse) string
+}
+
+// LabelsService implements business logic for repository labels
+type LabelsService struct {
+	repo     repositories.LabelsRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewLabelsService creates a new LabelsService instance.
+// In read-only mode labels can be listed and diffed but not changed.
+func NewLabelsService(repo repositories.LabelsRepositoryInterface, resolver RepositoryResolver, readOnly bool) *LabelsService {
+	return &LabelsService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// ListLabels fetches all labels of a repository
+func (s *LabelsService) ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListLabels(req)
+}
+
+// CreateLabel creates a label
+func (s *LabelsService) CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error) {
+	if s.readOnly {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.NewValidationError("the 'name' parameter is required")
+	}
+
+	color, err := normalizeLabelColor(req.Color)
+	if err != nil {
+		return nil, err
+	}
+	if color == "" {
+		return nil, errors.NewValidationError("the 'color' parameter is required")
+	}
+	req.Color = color
+
+	return s.repo.CreateLabel(req)
+}
+
+// UpdateLabel renames a label or changes its color or description
+func (s *LabelsService) UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error) {
+	if s.readOnly {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errors.NewValidationError("the 'name' parameter is required")
+	}
+
+	color, err := normalizeLabelColor(req.Color)
+	if err != nil {
+		return nil, err
+	}
+	req.Color = color
+
+	if req.NewName == "" && req.Color == "" && req.Description == nil {
+		return nil, errors.NewValidationError("at least one of 'new_name', 'color' or 'description' is required")
+	}
+
+	return s.repo.UpdateLabel(req)
+}
+
+// DeleteLabel deletes a label
+func (s *LabelsService) DeleteLabel(req *domain.DeleteLabelRequest) error {
+	if s.readOnly {
+		return errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return err
+	}
+	if req.Name == "" {
+		return errors.NewValidationError("the 'name' parameter is required")
+	}
+
+	return s.repo.DeleteLabel(req)
+}
+
+// SyncLabels compares every target repository with the spec and, unless it
+// is a dry run, applies the differences. A failing repository is reported in
+// its result and does not stop the others.
+func (s *LabelsService) SyncLabels(req *domain.SyncLabelsRequest) (*domain.SyncLabelsResponse, error) {
+	if s.readOnly && !req.DryRun {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode; use dry_run to preview the changes")
+	}
+	if err := validateLabelSpec(&req.Spec); err != nil {
+		return nil, err
+	}
+
+	response := &domain.SyncLabelsResponse{DryRun: req.DryRun}
+
+	for _, target := range req.Spec.Repositories {
+		owner, repo := resolveRepository(s.resolver, "", target)
+		result := domain.LabelSyncResult{Repository: owner + "/" + repo}
+
+		if err := validateRepository(owner, repo); err != nil {
+			result.Error = err.Error()
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		current, err := s.repo.ListLabels(&domain.ListLabelsRequest{Owner: owner, Repo: repo})
+		if err != nil {
+			result.Error = err.Error()
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		result.Changes, result.Unchanged = diffLabels(current, &req.Spec)
+
+		if !req.DryRun {
+			if err := s.applyLabelChanges(owner, repo, result.Changes); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Applied = true
+			}
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// applyLabelChanges applies a diff to a repository, stopping at the first failure
+func (s *LabelsService) applyLabelChanges(owner, repo string, changes []domain.LabelChange) error {
+	for _, change := range changes {
+		var err error
+
+		switch change.Action {
+		case domain.LabelActionCreate:
+			_, err = s.repo.CreateLabel(&domain.CreateLabelRequest{
+				Owner:       owner,
+				Repo:        repo,
+				Name:        change.Desired.Name,
+				Color:       change.Desired.Color,
+				Description: change.Desired.Description,
+			})
+		case domain.LabelActionUpdate, domain.LabelActionRename:
+			description := change.Desired.Description
+			update := &domain.UpdateLabelRequest{
+				Owner:       owner,
+				Repo:        repo,
+				Name:        change.Current.Name,
+				Color:       change.Desired.Color,
+				Description: &description,
+			}
+			if change.Current.Name != change.Desired.Name {
+				update.NewName = change.Desired.Name
+			}
+			_, err = s.repo.UpdateLabel(update)
+		case domain.LabelActionDelete:
+			err = s.repo.DeleteLabel(&domain.DeleteLabelRequest{Owner: owner, Repo: repo, Name: change.Current.Name})
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s %q: %w", change.Action, change.Name, err)
+		}
+	}
+	return nil
+}
+
+// diffLabels lists the changes that turn current into the spec's label set and
+// counts the labels that already match. Names are compared case-insensitively,
+// as GitHub does; deletions come last so renames are never pruned.
+func diffLabels(current []domain.Label, spec *domain.LabelSpec) ([]domain.LabelChange, int) {
+	byName := make(map[string]*domain.Label, len(current))
+	for i := range current {
+		byName[strings.ToLower(current[i].Name)] = &current[i]
+	}
+
+	claimed := make(map[string]bool, len(current))
+	changes := []domain.LabelChange{}
+	unchanged := 0
+
+	for _, def := range spec.Labels {
+		desired := &domain.Label{Name: def.Name, Color: def.Color, Description: def.Description}
+		key := strings.ToLower(def.Name)
+
+		if existing, ok := byName[key]; ok {
+			claimed[key] = true
+			if existing.Name == desired.Name && strings.EqualFold(existing.Color, desired.Color) && existing.Description == desired.Description {
+				unchanged++
+				continue
+			}
+			changes = append(changes, domain.LabelChange{Action: domain.LabelActionUpdate, Name: def.Name, Current: existing, Desired: desired})
+			continue
+		}
+
+		change := domain.LabelChange{Action: domain.LabelActionCreate, Name: def.Name, Desired: desired}
+		for _, alias := range def.Aliases {
+			aliasKey := strings.ToLower(alias)
+			if existing, ok := byName[aliasKey]; ok && !claimed[aliasKey] {
+				claimed[aliasKey] = true
+				change.Action = domain.LabelActionRename
+				change.Current = existing
+				break
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	if spec.Prune {
+		for i := range current {
+			if !claimed[strings.ToLower(current[i].Name)] {
+				changes = append(changes, domain.LabelChange{Action: domain.LabelActionDelete, Name: current[i].Name, Current: &current[i]})
+			}
+		}
+	}
+
+	return changes, unchanged
+}
+
+// ParseLabelSpec decodes a YAML label spec, rejecting unknown keys
+func ParseLabelSpec(data string) (*domain.LabelSpec, error) {
+	if strings.TrimSpace(data) == "" {
+		return nil, errors.NewValidationError("the 'spec' parameter is required")
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(data)))
+	decoder.KnownFields(true)
+
+	var spec domain.LabelSpec
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid label spec: %v", err))
+	}
+	return &spec, nil
+}
+
+// validateLabelSpec checks a spec and normalizes its colors
+func validateLabelSpec(spec *domain.LabelSpec) error {
+	if len(spec.Repositories) == 0 {
+		return errors.NewValidationError("the label spec names no repositories")
+	}
+	if len(spec.Repositories) > maxSyncRepositories {
+		return errors.NewValidationError(fmt.Sprintf("a label sync can target at most %d repositories", maxSyncRepositories))
+	}
+	if len(spec.Labels) == 0 && !spec.Prune {
+		return errors.NewValidationError("the label spec defines no labels")
+	}
+
+	seen := make(map[string]bool)
+	for i := range spec.Labels {
+		def := &spec.Labels[i]
+		if strings.TrimSpace(def.Name) == "" {
+			return errors.NewValidationError(fmt.Sprintf("label %d of the spec has no name", i+1))
+		}
+
+		color, err := normalizeLabelColor(def.Color)
+		if err != nil {
+			return errors.NewValidationError(fmt.Sprintf("label %q: %v", def.Name, err))
+		}
+		if color == "" {
+			return errors.NewValidationError(fmt.Sprintf("label %q has no color", def.Name))
+		}
+		def.Color = color
+
+		for _, name := range append([]string{def.Name}, def.Aliases...) {
+			key := strings.ToLower(name)
+			if seen[key] {
+				return errors.NewValidationError(fmt.Sprintf("label name %q appears more than once in the spec", name))
+			}
+			seen[key] = true
+		}
+	}
+
+	return nil
+}
+
+// normalizeLabelColor strips a leading '#' and lowercases a hex color; an
+// empty color stays empty
+func normalizeLabelColor(color string) (string, error) {
+	if color == "" {
+		return "", nil
+	}
+
+	normalized := strings.ToLower(strings.TrimPrefix(color, "#"))
+	if !labelColorPattern.MatchString(normalized) {
+		return "", errors.NewValidationError(fmt.Sprintf("the color %q must be a 6-digit hex value such as d73a4a", color))
+	}
+	return normalized, nil
+}
+
+// FormatLabelsForMCP formats labels for MCP output
+func (s *LabelsService) FormatLabelsForMCP(labels []domain.Label) []string {
+	formatted := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		// Format: name #color - description
+		line := fmt.Sprintf("%s #%s", label.Name, label.Color)
+		if label.Description != "" {
+			line += " - " + label.Description
+		}
+		formatted = append(formatted, line)
+	}
+
+	return formatted
+}
+
+// FormatLabelSyncForMCP formats the per-repository diff of a label sync
+func (s *LabelsService) FormatLabelSyncForMCP(response *domain.SyncLabelsResponse) string {
+	var b strings.Builder
+
+	if response.DryRun {
+		b.WriteString("Dry run: no labels were changed\n")
+	}
+
+	for _, result := range response.Results {
+		status := ""
+		switch {
+		case result.Error != "":
+			status = " [failed: " + result.Error + "]"
+		case result.Applied:
+			status = " [applied]"
+		}
+		fmt.Fprintf(&b, "\n%s: %d changes, %d unchanged%s\n", result.Repository, len(result.Changes), result.Unchanged, status)
+
+		for _, change := range result.Changes {
+			switch change.Action {
+			case domain.LabelActionCreate:
+				fmt.Fprintf(&b, "  + %s #%s\n", change.Desired.Name, change.Desired.Color)
+			case domain.LabelActionUpdate:
+				fmt.Fprintf(&b, "  ~ %s %s\n", change.Name, describeLabelUpdate(change.Current, change.Desired))
+			case domain.LabelActionRename:
+				fmt.Fprintf(&b, "  > %s -> %s\n", change.Current.Name, change.Desired.Name)
+			case domain.LabelActionDelete:
+				fmt.Fprintf(&b, "  - %s\n", change.Current.Name)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// describeLabelUpdate lists the fields an update changes
+func describeLabelUpdate(current, desired *domain.Label) string {
+	var parts []string
+	if current.Name != desired.Name {
+		parts = append(parts, fmt.Sprintf("name %q -> %q", current.Name, desired.Name))
+	}
+	if !strings.EqualFold(current.Color, desired.Color) {
+		parts = append(parts, fmt.Sprintf("color #%s -> #%s", current.Color, desired.Color))
+	}
+	if current.Description != desired.Description {
+		parts = append(parts, fmt.Sprintf("description %q -> %q", current.Description, desired.Description))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// MilestonesServiceInterface defines the milestones service interface
+type MilestonesServiceInterface interface {
+	ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error)
+	CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error)
+	UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error)
+	DeleteMilestone(req *domain.DeleteMilestoneRequest) error
+	FormatMilestonesForMCP(milestones []domain.Milestone) []string
+	FormatMilestoneForMCP(milestone *domain.Milestone) string
+}
+
+// MilestonesService implements business logic for repository milestones
+type MilestonesService struct {
+	repo     repositories.MilestonesRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewMilestonesService creates a new MilestonesService instance.
+// In read-only mode milestones can only be listed.
+func NewMilestonesService(repo repositories.MilestonesRepositoryInterface, resolver RepositoryResolver, readOnly bool) *MilestonesService {
+	return &MilestonesService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// ListMilestones fetches the milestones of a repository
+func (s *MilestonesService) ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error) {
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+
+	if req.State == "" {
+		req.State = "open"
+	}
+	if req.State != "open" && req.State != "closed" && req.State != "all" {
+		return nil, errors.NewValidationError("the 'state' parameter must be 'open', 'closed' or 'all'")
+	}
+
+	return s.repo.ListMilestones(req)
+}
+
+// CreateMilestone creates a milestone
+func (s *MilestonesService) CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error) {
+	if s.readOnly {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Title) == "" {
+		return nil, errors.NewValidationError("the 'title' parameter is required")
+	}
+	if err := validateMilestoneState(req.State); err != nil {
+		return nil, err
+	}
+	if err := validateDueOn(req.DueOn); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateMilestone(req)
+}
+
+// UpdateMilestone changes the title, state, description or due date of a milestone
+func (s *MilestonesService) UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error) {
+	if s.readOnly {
+		return nil, errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return nil, err
+	}
+	if req.Number <= 0 {
+		return nil, errors.NewValidationError("the 'number' parameter must be a positive milestone number")
+	}
+	if err := validateMilestoneState(req.State); err != nil {
+		return nil, err
+	}
+	if req.DueOn != nil {
+		if err := validateDueOn(*req.DueOn); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Title == "" && req.State == "" && req.Description == nil && req.DueOn == nil {
+		return nil, errors.NewValidationError("at least one of 'title', 'state', 'description' or 'due_on' is required")
+	}
+
+	return s.repo.UpdateMilestone(req)
+}
+
+// DeleteMilestone deletes a milestone
+func (s *MilestonesService) DeleteMilestone(req *domain.DeleteMilestoneRequest) error {
+	if s.readOnly {
+		return errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+	if err := validateRepository(req.Owner, req.Repo); err != nil {
+		return err
+	}
+	if req.Number <= 0 {
+		return errors.NewValidationError("the 'number' parameter must be a positive milestone number")
+	}
+
+	return s.repo.DeleteMilestone(req)
+}
+
+// FormatMilestonesForMCP formats milestones for MCP output
+func (s *MilestonesService) FormatMilestonesForMCP(milestones []domain.Milestone) []string {
+	formatted := make([]string, 0, len(milestones))
+
+	for i := range milestones {
+		formatted = append(formatted, s.FormatMilestoneForMCP(&milestones[i]))
+	}
+
+	return formatted
+}
+
+// FormatMilestoneForMCP formats a single milestone with its progress
+func (s *MilestonesService) FormatMilestoneForMCP(m *domain.Milestone) string {
+	due := "no due date"
+	if m.DueOn != nil {
+		due = "due " + m.DueOn.Format("2006-01-02")
+	}
+
+	// Format: #number [state] title (due ..., open/closed issues)
+	line := fmt.Sprintf("#%d [%s] %s (%s, %d open, %d closed)", m.Number, m.State, m.Title, due, m.OpenIssues, m.ClosedIssues)
+	if m.Description != "" {
+		line += "\n  " + m.Description
+	}
+	return line
+}
+
+// validateMilestoneState checks an optional open/closed state
+func validateMilestoneState(state string) error {
+	if state != "" && state != "open" && state != "closed" {
+		return errors.NewValidationError("the 'state' parameter must be 'open' or 'closed'")
+	}
+	return nil
+}
+
+// validateDueOn checks an optional YYYY-MM-DD due date
+func validateDueOn(dueOn string) error {
+	if dueOn == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", dueOn); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("the 'due_on' parameter %q must be a date in YYYY-MM-DD form", dueOn))
+	}
+	return nil
+}
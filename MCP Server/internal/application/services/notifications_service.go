@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// validNotificationReasons are the reasons GitHub gives for a notification
+var validNotificationReasons = map[string]bool{
+	"approval_requested": true, "assign": true, "author": true, "ci_activity": true,
+	"comment": true, "invitation": true, "manual": true, "member_feature_requested": true,
+	"mention": true, "review_requested": true, "security_advisory_credit": true,
+	"security_alert": true, "state_change": true, "subscribed": true, "team_mention": true,
+}
+
+// NotificationsServiceInterface defines the notifications service interface
+type NotificationsServiceInterface interface {
+	ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error)
+	GetNotificationThread(req *domain.GetNotificationThreadRequest) (*domain.NotificationThread, error)
+	MarkNotificationRead(req *domain.MarkNotificationReadRequest) error
+	FormatNotificationsForMCP(notifications []domain.Notification) []string
+	FormatNotificationThreadForMCP(thread *domain.NotificationThread) string
+}
+
+// NotificationsService implements business logic for the notifications inbox
+type NotificationsService struct {
+	repo     repositories.NotificationsRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewNotificationsService creates a new NotificationsService instance.
+// In read-only mode threads cannot be marked as read.
+func NewNotificationsService(repo repositories.NotificationsRepositoryInterface, resolver RepositoryResolver, readOnly bool) *NotificationsService {
+	return &NotificationsService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// ListNotifications fetches a page of notifications, optionally limited to a
+// repository and filtered by reason
+func (s *NotificationsService) ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error) {
+	if req.Repo != "" {
+		req.Owner, req.Repo = resolveRepository(s.resolver, req.Owner, req.Repo)
+		if err := validateRepository(req.Owner, req.Repo); err != nil {
+			return nil, err
+		}
+	} else if req.Owner != "" {
+		return nil, errors.NewValidationError("the 'owner' parameter requires 'repo'")
+	}
+
+	for _, reason := range req.Reasons {
+		if !validNotificationReasons[reason] {
+			return nil, errors.NewValidationError(fmt.Sprintf("the 'reason' parameter %q is not a valid notification reason", reason))
+		}
+	}
+
+	if req.Since != "" {
+		if _, err := time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, errors.NewValidationError("the 'since' parameter must be an ISO 8601 timestamp (2006-01-02T15:04:05Z)")
+		}
+	}
+
+	if req.PerPage < 0 || req.PerPage > 50 {
+		return nil, errors.NewValidationError("the 'per_page' parameter must be between 1 and 50")
+	}
+	if req.PerPage == 0 {
+		req.PerPage = 30
+	}
+	if req.Page < 0 {
+		return nil, errors.NewValidationError("the 'page' parameter must be positive")
+	}
+
+	response, err := s.repo.ListNotifications(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// GitHub cannot filter by reason, so the page is filtered here
+	if len(req.Reasons) > 0 {
+		wanted := make(map[string]bool, len(req.Reasons))
+		for _, reason := range req.Reasons {
+			wanted[reason] = true
+		}
+		kept := response.Notifications[:0]
+		for _, notification := range response.Notifications {
+			if wanted[notification.Reason] {
+				kept = append(kept, notification)
+			}
+		}
+		response.Notifications = kept
+		response.Count = len(kept)
+	}
+
+	return response, nil
+}
+
+// GetNotificationThread fetches a thread and, on request, the issue or pull
+// request it is about and its latest comment
+func (s *NotificationsService) GetNotificationThread(req *domain.GetNotificationThreadRequest) (*domain.NotificationThread, error) {
+	if err := validateThreadID(req.ThreadID); err != nil {
+		return nil, err
+	}
+
+	notification, err := s.repo.GetNotificationThread(req.ThreadID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &domain.NotificationThread{Notification: *notification}
+	if !req.WithSubject {
+		return thread, nil
+	}
+
+	subject := notification.Subject
+	if (subject.Type == "Issue" || subject.Type == "PullRequest") && subject.URL != "" {
+		details, err := s.repo.GetNotificationSubject(subject.URL)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		thread.Subject = details
+	}
+
+	if subject.LatestCommentURL != "" && subject.LatestCommentURL != subject.URL {
+		comment, err := s.repo.GetNotificationComment(subject.LatestCommentURL)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		thread.LatestComment = comment
+	}
+
+	return thread, nil
+}
+
+// MarkNotificationRead marks a thread as read
+func (s *NotificationsService) MarkNotificationRead(req *domain.MarkNotificationReadRequest) error {
+	if s.readOnly {
+		return errors.NewForbiddenError("the server is running in read-only mode")
+	}
+	if err := validateThreadID(req.ThreadID); err != nil {
+		return err
+	}
+
+	return s.repo.MarkNotificationRead(req.ThreadID)
+}
+
+// FormatNotificationsForMCP formats notifications for MCP output
+func (s *NotificationsService) FormatNotificationsForMCP(notifications []domain.Notification) []string {
+	formatted := make([]string, 0, len(notifications))
+
+	for _, n := range notifications {
+		marker := " "
+		if n.Unread {
+			marker = "*"
+		}
+		// Format: * [thread] reason repo Type: Title (updated ...)
+		formatted = append(formatted, fmt.Sprintf("%s [%s] %s %s %s: %s (updated %s)",
+			marker, n.ID, n.Reason, n.Repository.FullName, n.Subject.Type, n.Subject.Title,
+			n.UpdatedAt.Format("2006-01-02 15:04")))
+	}
+
+	return formatted
+}
+
+// FormatNotificationThreadForMCP formats a thread with its subject and latest comment
+func (s *NotificationsService) FormatNotificationThreadForMCP(thread *domain.NotificationThread) string {
+	var b strings.Builder
+
+	n := thread.Notification
+	status := "read"
+	if n.Unread {
+		status = "unread"
+	}
+	fmt.Fprintf(&b, "Thread %s [%s] %s in %s\n", n.ID, status, n.Reason, n.Repository.FullName)
+	fmt.Fprintf(&b, "%s: %s\nupdated %s\n", n.Subject.Type, n.Subject.Title, n.UpdatedAt.Format("2006-01-02 15:04"))
+
+	if subject := thread.Subject; subject != nil {
+		state := subject.State
+		if subject.Merged {
+			state = "merged"
+		}
+		fmt.Fprintf(&b, "\n#%d [%s] %s\n%s\nopened by %s\n", subject.Number, state, subject.Title, subject.HTMLURL, subject.User.Login)
+		if subject.Body != "" {
+			b.WriteString("\n" + subject.Body + "\n")
+		}
+	}
+
+	if comment := thread.LatestComment; comment != nil {
+		fmt.Fprintf(&b, "\n--- latest comment by %s on %s\n%s\n%s\n",
+			comment.Author.Login, comment.CreatedAt.Format("2006-01-02 15:04"), comment.HTMLURL, comment.Body)
+	}
+
+	return b.String()
+}
+
+// validateThreadID checks that a notification thread ID is numeric
+func validateThreadID(threadID string) error {
+	if threadID == "" {
+		return errors.NewValidationError("the 'thread_id' parameter is required")
+	}
+	if _, err := strconv.ParseUint(threadID, 10, 64); err != nil {
+		return errors.NewValidationError(fmt.Sprintf("the 'thread_id' parameter %q must be numeric", threadID))
+	}
+	return nil
+}
+
+// isNotFound reports whether err is a NOT_FOUND AppError
+func isNotFound(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	return ok && appErr.Code == errors.ErrCodeNotFound
+}
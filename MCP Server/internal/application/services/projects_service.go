@@ -0,0 +1,224 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/repositories"
+	"mcp-server/pkg/errors"
+)
+
+// ProjectsServiceInterface defines the Projects (v2) service interface
+type ProjectsServiceInterface interface {
+	ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error)
+	GetProjectItem(itemID string) (*domain.ProjectBoardItem, error)
+	MoveProjectItem(req *domain.SetProjectFieldRequest) error
+	SetProjectField(req *domain.SetProjectFieldRequest) error
+	FormatProjectItemsForMCP(items []domain.ProjectBoardItem) []string
+}
+
+// ProjectsService implements business logic for Projects (v2) boards
+type ProjectsService struct {
+	repo     repositories.ProjectsRepositoryInterface
+	resolver RepositoryResolver
+	readOnly bool
+}
+
+// NewProjectsService creates a new ProjectsService instance.
+// In read-only mode every update is rejected.
+func NewProjectsService(repo repositories.ProjectsRepositoryInterface, resolver RepositoryResolver, readOnly bool) *ProjectsService {
+	return &ProjectsService{
+		repo:     repo,
+		resolver: resolver,
+		readOnly: readOnly,
+	}
+}
+
+// ListProjectItems fetches a page of project items, optionally filtered by status
+func (s *ProjectsService) ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error) {
+	req.Owner, _ = resolveRepository(s.resolver, req.Owner, "")
+	if err := validateProject(req.Owner, req.Number); err != nil {
+		return nil, err
+	}
+	if req.PerPage < 0 || req.PerPage > 100 {
+		return nil, errors.NewValidationError("the 'per_page' parameter must be between 1 and 100")
+	}
+	if req.PerPage == 0 {
+		req.PerPage = 50
+	}
+
+	response, err := s.repo.ListProjectItems(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != "" {
+		filtered := response.Items[:0]
+		for _, item := range response.Items {
+			if strings.EqualFold(item.Fields[domain.StatusFieldName], req.Status) {
+				filtered = append(filtered, item)
+			}
+		}
+		response.Items = filtered
+		response.Count = len(filtered)
+	}
+
+	return response, nil
+}
+
+// GetProjectItem fetches a single project item with the repository of its content
+func (s *ProjectsService) GetProjectItem(itemID string) (*domain.ProjectBoardItem, error) {
+	if itemID == "" {
+		return nil, errors.NewValidationError("the 'item_id' parameter is required")
+	}
+	return s.repo.GetProjectItem(itemID)
+}
+
+// MoveProjectItem sets the Status field of a project item
+func (s *ProjectsService) MoveProjectItem(req *domain.SetProjectFieldRequest) error {
+	req.Field = domain.StatusFieldName
+	if req.Value == "" {
+		return errors.NewValidationError("the 'status' parameter is required")
+	}
+	return s.SetProjectField(req)
+}
+
+// SetProjectField sets or clears a field of a project item. The field is
+// looked up by name and the value is converted to the field's data type.
+func (s *ProjectsService) SetProjectField(req *domain.SetProjectFieldRequest) error {
+	if s.readOnly {
+		return errors.NewForbiddenError("the server is running in read-only mode")
+	}
+
+	req.Owner, _ = resolveRepository(s.resolver, req.Owner, "")
+	if err := validateProject(req.Owner, req.Number); err != nil {
+		return err
+	}
+	if req.ItemID == "" {
+		return errors.NewValidationError("the 'item_id' parameter is required")
+	}
+	if req.Field == "" {
+		return errors.NewValidationError("the 'field' parameter is required")
+	}
+
+	project, err := s.repo.GetProject(req.Owner, req.Number)
+	if err != nil {
+		return err
+	}
+
+	field, err := findProjectField(project, req.Field)
+	if err != nil {
+		return err
+	}
+
+	if req.Value == "" {
+		return s.repo.ClearProjectItemField(project.ID, req.ItemID, field.ID)
+	}
+
+	value, err := projectFieldValue(field, req.Value)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateProjectItemField(project.ID, req.ItemID, field.ID, value)
+}
+
+// FormatProjectItemsForMCP formats project items and their field values for MCP output
+func (s *ProjectsService) FormatProjectItemsForMCP(items []domain.ProjectBoardItem) []string {
+	var formatted []string
+
+	for _, item := range items {
+		// Format: [item ID] owner/repo#Number Title {Field=Value, ...}
+		ref := item.Type
+		if item.Number > 0 {
+			ref = fmt.Sprintf("%s#%d", item.Repository, item.Number)
+		}
+		formattedItem := fmt.Sprintf("[%s] %s %s", item.ID, ref, item.Title)
+
+		if len(item.Fields) > 0 {
+			names := make([]string, 0, len(item.Fields))
+			for name := range item.Fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			values := make([]string, 0, len(names))
+			for _, name := range names {
+				values = append(values, name+"="+item.Fields[name])
+			}
+			formattedItem += " {" + strings.Join(values, ", ") + "}"
+		}
+
+		formatted = append(formatted, formattedItem)
+	}
+
+	return formatted
+}
+
+// validateProject checks the owner and project number parameters
+func validateProject(owner string, number int) error {
+	if owner == "" {
+		return errors.NewValidationError("the 'owner' parameter is required")
+	}
+	if number <= 0 {
+		return errors.NewValidationError("the 'project' parameter is required")
+	}
+	return nil
+}
+
+// findProjectField looks up a project field by name, ignoring case
+func findProjectField(project *domain.Project, name string) (*domain.ProjectField, error) {
+	names := make([]string, 0, len(project.Fields))
+	for i, field := range project.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return &project.Fields[i], nil
+		}
+		names = append(names, field.Name)
+	}
+	return nil, errors.NewValidationError(fmt.Sprintf("project %q has no field %q (fields: %s)", project.Title, name, strings.Join(names, ", ")))
+}
+
+// projectFieldValue converts a string to the value type of a field
+func projectFieldValue(field *domain.ProjectField, raw string) (domain.ProjectFieldValue, error) {
+	switch field.DataType {
+	case domain.ProjectFieldText:
+		return domain.ProjectFieldValue{Text: raw}, nil
+	case domain.ProjectFieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return domain.ProjectFieldValue{}, errors.NewValidationError(fmt.Sprintf("field %q expects a number, got %q", field.Name, raw))
+		}
+		return domain.ProjectFieldValue{Number: &number}, nil
+	case domain.ProjectFieldDate:
+		if _, err := time.Parse("2006-01-02", raw); err != nil {
+			return domain.ProjectFieldValue{}, errors.NewValidationError(fmt.Sprintf("field %q expects a date (YYYY-MM-DD), got %q", field.Name, raw))
+		}
+		return domain.ProjectFieldValue{Date: raw}, nil
+	case domain.ProjectFieldSingleSelect, domain.ProjectFieldIteration:
+		option, err := findFieldOption(field, raw)
+		if err != nil {
+			return domain.ProjectFieldValue{}, err
+		}
+		if field.DataType == domain.ProjectFieldIteration {
+			return domain.ProjectFieldValue{IterationID: option.ID}, nil
+		}
+		return domain.ProjectFieldValue{SingleSelectOptionID: option.ID}, nil
+	default:
+		return domain.ProjectFieldValue{}, errors.NewValidationError(fmt.Sprintf("field %q of type %s cannot be set with this tool", field.Name, field.DataType))
+	}
+}
+
+// findFieldOption looks up a single select option or iteration by name, ignoring case
+func findFieldOption(field *domain.ProjectField, name string) (*domain.ProjectFieldOption, error) {
+	names := make([]string, 0, len(field.Options))
+	for i, option := range field.Options {
+		if strings.EqualFold(option.Name, name) {
+			return &field.Options[i], nil
+		}
+		names = append(names, option.Name)
+	}
+	return nil, errors.NewValidationError(fmt.Sprintf("field %q has no option %q (options: %s)", field.Name, name, strings.Join(names, ", ")))
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// RepositoryResolver expands repository aliases and default owners
+type RepositoryResolver interface {
+	ResolveRepository(owner, repo string) (string, string)
+}
+
+// resolveRepository applies the resolver if one is configured
+func resolveRepository(resolver RepositoryResolver, owner, repo string) (string, string) {
+	if resolver == nil {
+		return owner, repo
+	}
+	return resolver.ResolveRepository(owner, repo)
+}
+
+// validateRepository checks the owner and repo parameters shared by all tools
+func validateRepository(owner, repo string) error {
+	if owner == "" {
+		return errors.NewValidationError("the 'owner' parameter is required")
+	}
+
+	if repo == "" {
+		return errors.NewValidationError("the 'repo' parameter is required")
+	}
+
+	if !domain.ValidOwnerName(owner) {
+		return errors.NewValidationError(fmt.Sprintf("invalid owner %q: only letters, digits, '-' and '_' are allowed", owner))
+	}
+
+	if !domain.ValidRepoName(repo) {
+		return errors.NewValidationError(fmt.Sprintf("invalid repo %q: only letters, digits, '.', '-' and '_' are allowed, and it must not be '.' or '..'", repo))
+	}
+
+	return nil
+}
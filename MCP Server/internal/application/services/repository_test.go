@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"mcp-server/pkg/errors"
+)
+
+func TestValidateRepository(t *testing.T) {
+	testCases := []struct {
+		name        string
+		owner, repo string
+		valid       bool
+	}{
+		{"plain", "acme", "api", true},
+		{"dots and underscores", "acme", "api.go_v2-x", true},
+		{"managed user", "octo_acme", "api", true},
+		{"missing owner", "", "api", false},
+		{"missing repo", "acme", "", false},
+		{"traversal", "acme", "api/../secret", false},
+		{"dot", "acme", ".", false},
+		{"dot dot", "acme", "..", false},
+		{"slash in owner", "acme/api", "secret", false},
+		{"escaped slash", "acme", "api%2F..%2Fsecret", false},
+		{"query", "acme", "api?ref=x", false},
+		{"space", "acme", "my api", false},
+		{"dot owner", ".", "api", false},
+	}
+
+	for _, tc := range testCases {
+		err := validateRepository(tc.owner, tc.repo)
+		if valid := err == nil; valid != tc.valid {
+			t.Errorf("%s: valid = %v, want %v (%v)", tc.name, valid, tc.valid, err)
+			continue
+		}
+		if err != nil && err.(*errors.AppError).Code != errors.ErrCodeValidation {
+			t.Errorf("%s: got %v, want a validation error", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// scopeQualifiers select the repositories an issue search runs on; a user
+// query containing one could reach repositories the policy never checked
+var scopeQualifiers = []string{"repo:", "org:", "user:", "owner:"}
+
+// scopedIssueQuery restricts a user supplied search query to the issues of
+// one repository. Queries with their own scope qualifiers are rejected.
+func scopedIssueQuery(owner, repo, query string) (string, error) {
+	for _, term := range strings.Fields(query) {
+		qualifier := strings.ToLower(strings.TrimPrefix(term, "-"))
+		for _, scope := range scopeQualifiers {
+			if strings.HasPrefix(qualifier, scope) {
+				return "", errors.NewValidationError(fmt.Sprintf("the query must not contain %q: it is already scoped to %s/%s", term, owner, repo))
+			}
+		}
+	}
+
+	scoped := fmt.Sprintf("repo:%s/%s is:issue", owner, repo)
+	if query = strings.TrimSpace(query); query != "" {
+		scoped += " " + query
+	}
+	return scoped, nil
+}
+
+// inRepository reports whether a search hit belongs to owner/repo, going by
+// its repository_url. Search results are filtered with it as a second line
+// of defense, since the search API decides what a query matches.
+func inRepository(issue *domain.Issue, owner, repo string) bool {
+	return strings.HasSuffix(strings.ToLower(issue.RepositoryURL), strings.ToLower("/repos/"+owner+"/"+repo))
+}
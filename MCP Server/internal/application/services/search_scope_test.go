@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+func TestScopedIssueQuery(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+		code     string
+	}{
+		{"", "repo:acme/api is:issue", ""},
+		{"is:open label:bug", "repo:acme/api is:issue is:open label:bug", ""},
+		{`label:"good first issue"`, `repo:acme/api is:issue label:"good first issue"`, ""},
+		{"reporting crash", "repo:acme/api is:issue reporting crash", ""},
+		{"is:open repo:acme/secret", "", errors.ErrCodeValidation},
+		{"is:open REPO:acme/secret", "", errors.ErrCodeValidation},
+		{"org:globex", "", errors.ErrCodeValidation},
+		{"user:mallory label:bug", "", errors.ErrCodeValidation},
+		{"owner:globex", "", errors.ErrCodeValidation},
+		{"-repo:acme/web", "", errors.ErrCodeValidation},
+	}
+
+	for _, tc := range testCases {
+		query, err := scopedIssueQuery("acme", "api", tc.query)
+		if code := appErrorCode(t, err); code != tc.code || query != tc.expected {
+			t.Errorf("%q: got %q (%q), want %q (%q)", tc.query, query, code, tc.expected, tc.code)
+		}
+	}
+}
+
+func TestInRepository(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://api.github.com/repos/acme/api", true},
+		{"https://api.github.com/repos/Acme/API", true},
+		{"https://github.example.com/api/v3/repos/acme/api", true},
+		{"https://api.github.com/repos/acme/secret", false},
+		{"https://api.github.com/repos/notacme/api", false},
+		{"https://api.github.com/repos/acme/api-legacy", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		if got := inRepository(&domain.Issue{RepositoryURL: tc.url}, "acme", "api"); got != tc.expected {
+			t.Errorf("%q: got %v, want %v", tc.url, got, tc.expected)
+		}
+	}
+}
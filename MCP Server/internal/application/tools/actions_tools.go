@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateListWorkflowRunsTool creates the tool for listing workflow runs
+func (f *ToolFactory) CreateListWorkflowRunsTool() mcp.Tool {
+	return mcp.NewTool("list_workflow_runs",
+		mcp.WithDescription("Lists GitHub Actions workflow runs of a repository"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("branch", mcp.Description("Only runs for this branch")),
+		mcp.WithString("event", mcp.Description("Only runs triggered by this event (push, pull_request, ...)")),
+		mcp.WithString("status", mcp.Description("Run status or conclusion: queued, in_progress, completed, success, failure, ...")),
+		mcp.WithNumber("per_page", mcp.Description("Number of runs to return, 1-100 (default: 20)")),
+	)
+}
+
+// CreateListWorkflowRunsHandler creates the handler for the list_workflow_runs tool
+func (f *ToolFactory) CreateListWorkflowRunsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListWorkflowRunsRequest{
+			Owner:   getStringArg(args, "owner"),
+			Repo:    getStringArg(args, "repo"),
+			Branch:  getStringArg(args, "branch"),
+			Event:   getStringArg(args, "event"),
+			Status:  getStringArg(args, "status"),
+			PerPage: int(getIntArg(args, "per_page")),
+		}
+
+		response, err := f.actionsService.ListWorkflowRuns(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing workflow runs", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, run := range f.actionsService.FormatWorkflowRunsForMCP(response.Runs) {
+			contents = append(contents, mcp.NewTextContent(run))
+		}
+
+		summary := mcp.NewTextContent(fmt.Sprintf("\nShowing %d of %d workflow runs in %s/%s", response.Count, response.TotalCount, request.Owner, request.Repo))
+		contents = append(contents, summary)
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateGetWorkflowRunTool creates the tool for fetching a workflow run
+func (f *ToolFactory) CreateGetWorkflowRunTool() mcp.Tool {
+	return mcp.NewTool("get_workflow_run",
+		mcp.WithDescription("Fetches a GitHub Actions workflow run with its jobs and failed steps"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("run_id", mcp.Required(), mcp.Description("Workflow run ID")),
+	)
+}
+
+// CreateGetWorkflowRunHandler creates the handler for the get_workflow_run tool
+func (f *ToolFactory) CreateGetWorkflowRunHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetWorkflowRunRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+			RunID: getIntArg(args, "run_id"),
+		}
+
+		response, err := f.actionsService.GetWorkflowRun(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching workflow run", err), nil
+		}
+
+		contents := []mcp.Content{}
+		for _, run := range f.actionsService.FormatWorkflowRunsForMCP([]domain.WorkflowRun{response.Run}) {
+			contents = append(contents, mcp.NewTextContent(run+"\n"+response.Run.HTMLURL))
+		}
+		for _, job := range f.actionsService.FormatWorkflowJobsForMCP(response.Jobs) {
+			contents = append(contents, mcp.NewTextContent(job))
+		}
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateGetJobLogsTool creates the tool for downloading job logs
+func (f *ToolFactory) CreateGetJobLogsTool() mcp.Tool {
+	return mcp.NewTool("get_job_logs",
+		mcp.WithDescription("Downloads the logs of a workflow job, keeping the tail that fits a token budget"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("job_id", mcp.Required(), mcp.Description("Workflow job ID")),
+		mcp.WithNumber("max_tokens", mcp.Description("Approximate token budget for the returned logs (default: 4000)")),
+	)
+}
+
+// CreateGetJobLogsHandler creates the handler for the get_job_logs tool
+func (f *ToolFactory) CreateGetJobLogsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetJobLogsRequest{
+			Owner:     getStringArg(args, "owner"),
+			Repo:      getStringArg(args, "repo"),
+			JobID:     getIntArg(args, "job_id"),
+			MaxTokens: int(getIntArg(args, "max_tokens")),
+		}
+
+		response, err := f.actionsService.GetJobLogs(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching job logs", err), nil
+		}
+
+		return mcp.NewToolResultText(response.Logs), nil
+	}
+}
+
+// CreateRerunFailedJobsTool creates the tool for re-running failed jobs
+func (f *ToolFactory) CreateRerunFailedJobsTool() mcp.Tool {
+	return mcp.NewTool("rerun_failed_jobs",
+		mcp.WithDescription("Re-runs the failed jobs of a GitHub Actions workflow run"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("run_id", mcp.Required(), mcp.Description("Workflow run ID")),
+	)
+}
+
+// CreateRerunFailedJobsHandler creates the handler for the rerun_failed_jobs tool
+func (f *ToolFactory) CreateRerunFailedJobsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.RerunFailedJobsRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+			RunID: getIntArg(args, "run_id"),
+		}
+
+		if err := f.actionsService.RerunFailedJobs(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error re-running failed jobs", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Re-run of failed jobs requested for run %d in %s/%s", request.RunID, request.Owner, request.Repo)), nil
+	}
+}
@@ -0,0 +1,282 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// BudgetProvider returns the configured output budget of a tool
+type BudgetProvider interface {
+	OutputBudget(tool string) domain.OutputBudget
+}
+
+// continuationPrefix marks cursors that resume inside a page cut short by the budget
+const continuationPrefix = "budget:"
+
+// summaryReserve is kept free in the budget for group headers and the summary
+const summaryReserve = 512
+
+// maxSummaryLabels is the number of labels listed in an overflow summary
+const maxSummaryLabels = 10
+
+// outputBudget returns the budget of a tool with the per-call overrides applied
+func outputBudget(budgets BudgetProvider, tool string, args map[string]interface{}) domain.OutputBudget {
+	var budget domain.OutputBudget
+	if budgets != nil {
+		budget = budgets.OutputBudget(tool)
+	}
+	return budget.Merge(domain.OutputBudget{
+		MaxTokens:     int(getIntArg(args, "max_output_tokens")),
+		MaxBodyTokens: int(getIntArg(args, "max_body_tokens")),
+		GroupBy:       getStringArg(args, "group_by"),
+	})
+}
+
+// validateBudget checks the per-call budget arguments
+func validateBudget(budget domain.OutputBudget) error {
+	if budget.MaxTokens < 0 || budget.MaxBodyTokens < 0 {
+		return errors.NewValidationError("the 'max_output_tokens' and 'max_body_tokens' parameters must not be negative")
+	}
+	switch budget.GroupBy {
+	case "", domain.GroupByNone, domain.GroupByState, domain.GroupByLabel:
+		return nil
+	}
+	return errors.NewValidationError("the 'group_by' parameter must be 'none', 'state' or 'label'")
+}
+
+// truncateBody keeps the first maxBytes of a body, cut on a rune boundary,
+// and appends a marker saying how much was dropped
+func truncateBody(body string, maxBytes int) string {
+	if maxBytes <= 0 || len(body) <= maxBytes {
+		return body
+	}
+
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut] + fmt.Sprintf("\n... [truncated: showing first %d of %d bytes] ...", cut, len(body))
+}
+
+// truncateIssueBodies applies the body budget to an issue and its comments
+func truncateIssueBodies(issue *domain.Issue, maxBytes int) {
+	issue.Body = truncateBody(issue.Body, maxBytes)
+	for i := range issue.Comments {
+		issue.Comments[i].Body = truncateBody(issue.Comments[i].Body, maxBytes)
+	}
+}
+
+// fitBudget returns how many entries fit in maxBytes. At least one entry is
+// always returned so a continuation makes progress, even when maxBytes is
+// used up before the first entry.
+func fitBudget(entries []string, maxBytes int) int {
+	used := 0
+	for i, entry := range entries {
+		used += len(entry) + 1
+		if used > maxBytes && i > 0 {
+			return i
+		}
+	}
+	return len(entries)
+}
+
+// groupKey returns the group an issue is listed under
+func groupKey(issue *domain.Issue, groupBy string) string {
+	switch groupBy {
+	case domain.GroupByState:
+		return issue.State
+	case domain.GroupByLabel:
+		if len(issue.Labels) > 0 {
+			return issue.Labels[0].Name
+		}
+		return "unlabeled"
+	}
+	return ""
+}
+
+// groupContents renders formatted issues, one content per issue or, when
+// grouping, one content per group in order of first appearance
+func groupContents(issues []domain.Issue, formatted []string, groupBy string) []mcp.Content {
+	var contents []mcp.Content
+	if groupBy == "" || groupBy == domain.GroupByNone {
+		for _, entry := range formatted {
+			contents = append(contents, mcp.NewTextContent(entry))
+		}
+		return contents
+	}
+
+	var keys []string
+	groups := map[string][]string{}
+	for i := range formatted {
+		key := groupKey(&issues[i], groupBy)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], formatted[i])
+	}
+
+	for _, key := range keys {
+		text := fmt.Sprintf("## %s: %s (%d)\n%s", groupBy, key, len(groups[key]), strings.Join(groups[key], "\n"))
+		contents = append(contents, mcp.NewTextContent(text))
+	}
+	return contents
+}
+
+// overflowSummary describes the issues left out by the budget by state and label
+func overflowSummary(issues []domain.Issue) string {
+	states := map[string]int{}
+	labels := map[string]int{}
+	for i := range issues {
+		states[issues[i].State]++
+		if len(issues[i].Labels) == 0 {
+			labels["unlabeled"]++
+		}
+		for _, label := range issues[i].Labels {
+			labels[label.Name]++
+		}
+	}
+
+	summary := fmt.Sprintf("%d more issues not shown (%s", len(issues), countList(states, 0))
+	if len(labels) > 0 {
+		summary += "; labels: " + countList(labels, maxSummaryLabels)
+	}
+	return summary + ")"
+}
+
+// countList formats counts as "name n" pairs, most frequent first
+func countList(counts map[string]int, limit int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	more := 0
+	if limit > 0 && len(names) > limit {
+		more = len(names) - limit
+		names = names[:limit]
+	}
+
+	parts := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %d", name, counts[name]))
+	}
+	if more > 0 {
+		parts = append(parts, fmt.Sprintf("%d more", more))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// continuation is the state encoded in a budget cursor: the page cursor the
+// results came from and how many of them were already returned
+type continuation struct {
+	Cursor string `json:"c,omitempty"`
+	Offset int    `json:"o"`
+}
+
+// encodeContinuation builds a cursor resuming at offset within the page of cursor
+func encodeContinuation(cursor string, offset int) string {
+	data, _ := json.Marshal(continuation{Cursor: cursor, Offset: offset})
+	return continuationPrefix + base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeContinuation splits a cursor into the page cursor and the offset in
+// that page; plain page cursors have offset 0
+func decodeContinuation(cursor string) (string, int, error) {
+	encoded, ok := strings.CutPrefix(cursor, continuationPrefix)
+	if !ok {
+		return cursor, 0, nil
+	}
+
+	var c continuation
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.Offset < 0 {
+		return "", 0, errors.NewValidationError("the 'cursor' parameter is not a valid continuation cursor")
+	}
+	return c.Cursor, c.Offset, nil
+}
+
+// OutputBudgetMiddleware caps the text of every tool result at the tool's
+// budget, as a safety net for tools that do not budget their own output.
+// Tools marked OwnBudget in the registry are left alone: their own limits
+// may be larger than the default budget and they keep a different part of
+// the output, such as the tail of job logs.
+func OutputBudgetMiddleware(budgets BudgetProvider, registry *Registry) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, req)
+			if err != nil || result == nil {
+				return result, err
+			}
+			if def, ok := registry.Lookup(req.Params.Name); ok && def.OwnBudget {
+				return result, nil
+			}
+
+			args, _ := req.Params.Arguments.(map[string]interface{})
+			capResult(result, outputBudget(budgets, req.Params.Name, args).MaxBytes())
+			return result, nil
+		}
+	}
+}
+
+// capResult drops the text beyond maxBytes and notes how much was dropped
+func capResult(result *mcp.CallToolResult, maxBytes int) {
+	if maxBytes <= 0 {
+		return
+	}
+
+	total := 0
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			total += len(text.Text)
+		}
+	}
+	if total <= maxBytes {
+		return
+	}
+
+	remaining := maxBytes
+	kept := result.Content[:0]
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok {
+			kept = append(kept, content)
+			continue
+		}
+		if remaining <= 0 {
+			continue
+		}
+		if len(text.Text) > remaining {
+			cut := remaining
+			for cut > 0 && !utf8.RuneStart(text.Text[cut]) {
+				cut--
+			}
+			text.Text = text.Text[:cut]
+		}
+		remaining -= len(text.Text)
+		kept = append(kept, text)
+	}
+
+	result.Content = append(kept, mcp.NewTextContent(fmt.Sprintf(
+		"\n... [output truncated: showing %d of %d bytes; narrow the query or raise max_output_tokens] ...",
+		maxBytes, total)))
+}
@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTruncateBody(t *testing.T) {
+	testCases := []struct {
+		body     string
+		maxBytes int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"short", 0, "short"},
+		{"0123456789", 4, "0123\n... [truncated: showing first 4 of 10 bytes] ..."},
+		{"héllo", 2, "h\n... [truncated: showing first 1 of 6 bytes] ..."},
+	}
+
+	for _, tc := range testCases {
+		if result := truncateBody(tc.body, tc.maxBytes); result != tc.expected {
+			t.Errorf("truncateBody(%q, %d) = %q, expected %q", tc.body, tc.maxBytes, result, tc.expected)
+		}
+	}
+}
+
+func TestContinuationRoundTrip(t *testing.T) {
+	for _, pageCursor := range []string{"", "Y3Vyc29yOjI="} {
+		cursor, offset, err := decodeContinuation(encodeContinuation(pageCursor, 7))
+		if err != nil || cursor != pageCursor || offset != 7 {
+			t.Errorf("round trip of (%q, 7) = (%q, %d, %v)", pageCursor, cursor, offset, err)
+		}
+	}
+
+	if cursor, offset, err := decodeContinuation("Y3Vyc29yOjI="); err != nil || cursor != "Y3Vyc29yOjI=" || offset != 0 {
+		t.Errorf("plain cursor decoded as (%q, %d, %v)", cursor, offset, err)
+	}
+	if _, _, err := decodeContinuation(continuationPrefix + "not base64!"); err == nil {
+		t.Error("invalid continuation cursor was accepted")
+	}
+}
+
+func TestFitBudgetAlwaysMakesProgress(t *testing.T) {
+	entries := []string{strings.Repeat("a", 100), "b", "c"}
+
+	testCases := []struct {
+		maxBytes int
+		expected int
+	}{
+		{-100, 1},
+		{0, 1},
+		{10, 1},
+		{103, 2},
+		{1000, 3},
+	}
+
+	for _, tc := range testCases {
+		if shown := fitBudget(entries, tc.maxBytes); shown != tc.expected {
+			t.Errorf("fitBudget(%d) = %d, expected %d", tc.maxBytes, shown, tc.expected)
+		}
+	}
+}
+
+func TestOutputBudgetOverrides(t *testing.T) {
+	budgets := budgetMap{"get_issues": {MaxTokens: 100, MaxBodyTokens: 10}}
+
+	budget := outputBudget(budgets, "get_issues", map[string]interface{}{"max_body_tokens": float64(20), "group_by": "label"})
+	expected := domain.OutputBudget{MaxTokens: 100, MaxBodyTokens: 20, GroupBy: domain.GroupByLabel}
+	if budget != expected {
+		t.Errorf("outputBudget = %+v, expected %+v", budget, expected)
+	}
+}
+
+// budgetMap is a BudgetProvider backed by a map
+type budgetMap map[string]domain.OutputBudget
+
+func (m budgetMap) OutputBudget(tool string) domain.OutputBudget {
+	return m[tool]
+}
+
+func TestOutputBudgetMiddlewareSkipsToolsWithOwnBudget(t *testing.T) {
+	output := strings.Repeat("log line\n", 100)
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(output), nil
+	}
+	registry := NewRegistry(
+		ToolDefinition{Tool: mcp.NewTool("get_issue"), Handler: handler},
+		ToolDefinition{Tool: mcp.NewTool("get_job_logs"), Handler: handler, OwnBudget: true},
+	)
+	budgets := budgetMap{"get_issue": {MaxTokens: 10}, "get_job_logs": {MaxTokens: 10}}
+	middleware := OutputBudgetMiddleware(budgets, registry)
+
+	testCases := []struct {
+		tool   string
+		capped bool
+	}{
+		{"get_issue", true},
+		{"get_job_logs", false},
+	}
+
+	for _, tc := range testCases {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = tc.tool
+		result, err := middleware(handler)(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.tool, err)
+		}
+
+		text := result.Content[0].(mcp.TextContent).Text
+		if capped := text != output; capped != tc.capped {
+			t.Errorf("%s: capped = %v, expected %v", tc.tool, capped, tc.capped)
+		}
+		if tc.capped && (len(text) != 40 || !strings.Contains(result.Content[1].(mcp.TextContent).Text, "showing 40 of 900 bytes")) {
+			t.Errorf("%s: capped output is unexpected: %+v", tc.tool, result.Content)
+		}
+	}
+}
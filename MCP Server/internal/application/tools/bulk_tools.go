@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"mcp-server/internal/application/services"
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// CreateBulkUpdateIssuesTool creates the tool for updating many issues at once
+func (f *ToolFactory) CreateBulkUpdateIssuesTool() mcp.Tool {
+	return mcp.NewTool("bulk_update_issues",
+		mcp.WithDescription("Applies one action (label, close, reassign, milestone) to many issues of a repository, selected by number or by a search query. Dry run by default: returns a preview of the change per issue. Progress is reported as MCP progress notifications when the call carries a progress token"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("issues", mcp.Description("Comma-separated issue numbers; alternative to query")),
+		mcp.WithString("query", mcp.Description("GitHub search qualifiers within the repository, e.g. 'is:open label:stale updated:<2024-01-01'; at most 100 matches; repo:, org: and user: are not allowed")),
+		mcp.WithString("action", mcp.Required(), mcp.Description("Action: label, close, reassign, milestone")),
+		mcp.WithString("add_labels", mcp.Description("label: comma-separated labels to add")),
+		mcp.WithString("remove_labels", mcp.Description("label: comma-separated labels to remove")),
+		mcp.WithString("state_reason", mcp.Description("close: completed or not_planned")),
+		mcp.WithString("assignees", mcp.Description("reassign: comma-separated logins replacing the current assignees, or 'none'")),
+		mcp.WithString("milestone", mcp.Description("milestone: milestone number, or 'none' to clear it")),
+		mcp.WithBoolean("dry_run", mcp.Description("Only preview the changes (default: true)")),
+		mcp.WithNumber("concurrency", mcp.Description("Issues updated at once, 1-10 (default: 4)")),
+	)
+}
+
+// CreateBulkUpdateIssuesHandler creates the handler for the bulk_update_issues tool
+func (f *ToolFactory) CreateBulkUpdateIssuesHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.BulkUpdateIssuesRequest{
+			Owner:        getStringArg(args, "owner"),
+			Repo:         getStringArg(args, "repo"),
+			Issues:       getIntListArg(args, "issues"),
+			Query:        strings.TrimSpace(getStringArg(args, "query")),
+			Action:       getStringArg(args, "action"),
+			AddLabels:    getStringListArg(args, "add_labels"),
+			RemoveLabels: getStringListArg(args, "remove_labels"),
+			StateReason:  getStringArg(args, "state_reason"),
+			DryRun:       true,
+			Concurrency:  int(getIntArg(args, "concurrency")),
+		}
+		if _, set := args["dry_run"]; set {
+			request.DryRun = getBoolArg(args, "dry_run")
+		}
+		if _, set := args["assignees"]; set {
+			request.Assignees = getStringListArg(args, "assignees")
+			if request.Assignees == nil || (len(request.Assignees) == 1 && request.Assignees[0] == "none") {
+				request.Assignees = []string{}
+			}
+		}
+		if _, set := args["milestone"]; set {
+			milestone := -1
+			if getStringArg(args, "milestone") == "none" {
+				milestone = 0
+			} else if n := getIntArg(args, "milestone"); n > 0 {
+				milestone = int(n)
+			}
+			request.Milestone = &milestone
+		}
+
+		response, err := f.bulkIssuesService.BulkUpdateIssues(ctx, request, progressNotifier(ctx, req))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error updating issues", err), nil
+		}
+
+		return mcp.NewToolResultText(f.bulkIssuesService.FormatBulkUpdateForMCP(response)), nil
+	}
+}
+
+// progressNotifier returns a ProgressFunc that sends MCP progress
+// notifications when the client asked for them with a progress token
+func progressNotifier(ctx context.Context, req mcp.CallToolRequest) services.ProgressFunc {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return nil
+	}
+
+	token := req.Params.Meta.ProgressToken
+	return func(done, total int, message string) {
+		// Progress is best effort; a client that went away must not fail the update
+		_ = mcpServer.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      done,
+			"total":         total,
+			"message":       message,
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mcp-server/pkg/errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ParseCommandLineArguments turns "--name value" and "--name=value" pairs
+// into tool call arguments. Dashes in names may stand for underscores,
+// values are converted to the type declared in the tool schema, boolean
+// flags may omit their value and repeated flags become lists. Names the
+// tool does not declare are kept so that ValidateArguments reports them.
+func ParseCommandLineArguments(tool mcp.Tool, args []string) (map[string]interface{}, error) {
+	arguments := map[string]interface{}{}
+
+	for i := 0; i < len(args); i++ {
+		token := args[i]
+		if !strings.HasPrefix(token, "-") || strings.TrimLeft(token, "-") == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("unexpected argument %q: tool arguments are passed as --name value", token))
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(token, "-"), "=")
+		name = argumentName(tool, name)
+		declared := argumentType(tool, name)
+
+		if !hasValue {
+			switch {
+			case i+1 < len(args) && !strings.HasPrefix(args[i+1], "--"):
+				i++
+				value = args[i]
+			case declared == "boolean":
+				value = "true"
+			default:
+				return nil, errors.NewValidationError(fmt.Sprintf("missing value for --%s", name))
+			}
+		}
+
+		converted := convertArgument(declared, value)
+		switch existing := arguments[name].(type) {
+		case nil:
+			arguments[name] = converted
+		case []interface{}:
+			arguments[name] = append(existing, converted)
+		default:
+			arguments[name] = []interface{}{existing, converted}
+		}
+	}
+
+	return arguments, nil
+}
+
+// argumentName maps a command-line name to the declared argument, so
+// --per-page finds per_page
+func argumentName(tool mcp.Tool, name string) string {
+	if _, ok := tool.InputSchema.Properties[name]; ok {
+		return name
+	}
+	if underscored := strings.ReplaceAll(name, "-", "_"); tool.InputSchema.Properties[underscored] != nil {
+		return underscored
+	}
+	return name
+}
+
+// argumentType returns the JSON type declared for an argument
+func argumentType(tool mcp.Tool, name string) string {
+	schema, _ := tool.InputSchema.Properties[name].(map[string]any)
+	declared, _ := schema["type"].(string)
+	return declared
+}
+
+// convertArgument converts a command-line value to the declared type; values
+// that do not convert are passed on as strings for validation to report
+func convertArgument(declared, value string) interface{} {
+	switch declared {
+	case "number", "integer":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCommandLineArguments(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected map[string]interface{}
+		error    string
+	}{
+		{"pairs", []string{"--repo", "api", "--per_page", "10"}, map[string]interface{}{"repo": "api", "per_page": 10.0}, ""},
+		{"equals and dashes", []string{"--repo=acme/api", "--per-page=5"}, map[string]interface{}{"repo": "acme/api", "per_page": 5.0}, ""},
+		{"boolean flag", []string{"--dry-run", "--repo", "api"}, map[string]interface{}{"dry_run": true, "repo": "api"}, ""},
+		{"boolean value", []string{"--dry_run", "false"}, map[string]interface{}{"dry_run": false}, ""},
+		{"negative number", []string{"--per_page", "-1"}, map[string]interface{}{"per_page": -1.0}, ""},
+		{"repeated", []string{"--labels", "bug", "--labels", "docs"}, map[string]interface{}{"labels": []interface{}{"bug", "docs"}}, ""},
+		{"unconverted", []string{"--per_page", "ten"}, map[string]interface{}{"per_page": "ten"}, ""},
+		{"unknown kept", []string{"--reop", "api"}, map[string]interface{}{"reop": "api"}, ""},
+		{"missing value", []string{"--repo"}, nil, "missing value for --repo"},
+		{"positional", []string{"api"}, nil, `unexpected argument "api"`},
+	}
+
+	for _, tc := range testCases {
+		arguments, err := ParseCommandLineArguments(testTool(), tc.args)
+		switch {
+		case tc.error != "":
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Errorf("%s: got error %v, want %q", tc.name, err, tc.error)
+			}
+		case err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case !reflect.DeepEqual(arguments, tc.expected):
+			t.Errorf("%s: got %v, want %v", tc.name, arguments, tc.expected)
+		}
+	}
+}
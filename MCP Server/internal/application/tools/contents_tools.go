@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateGetFileContentsTool creates the tool for reading a file
+func (f *ToolFactory) CreateGetFileContentsTool() mcp.Tool {
+	return mcp.NewTool("get_file_contents",
+		mcp.WithDescription("Reads a file from a GitHub repository. Binary files are detected and large files are truncated"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("path", mcp.Required(), mcp.Description("File path within the repository")),
+		mcp.WithString("ref", mcp.Description("Branch, tag or commit SHA (default: the default branch)")),
+		mcp.WithNumber("max_bytes", mcp.Description("Maximum number of bytes to return (default: 65536)")),
+	)
+}
+
+// CreateGetFileContentsHandler creates the handler for the get_file_contents tool
+func (f *ToolFactory) CreateGetFileContentsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetFileContentsRequest{
+			Owner:    getStringArg(args, "owner"),
+			Repo:     getStringArg(args, "repo"),
+			Path:     getStringArg(args, "path"),
+			Ref:      getStringArg(args, "ref"),
+			MaxBytes: int(getIntArg(args, "max_bytes")),
+		}
+
+		file, err := f.contentsService.GetFileContents(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error reading file", err), nil
+		}
+
+		if file.Binary {
+			return mcp.NewToolResultText(fmt.Sprintf("%s is a binary file (%d bytes, sha %s); content not shown\n%s",
+				file.Entry.Path, file.Entry.Size, file.Entry.SHA, file.Entry.HTMLURL)), nil
+		}
+
+		return mcp.NewToolResultText(file.Content), nil
+	}
+}
+
+// CreateListDirectoryTool creates the tool for listing a directory
+func (f *ToolFactory) CreateListDirectoryTool() mcp.Tool {
+	return mcp.NewTool("list_directory",
+		mcp.WithDescription("Lists the files and directories at a path of a GitHub repository"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("path", mcp.Description("Directory path (default: repository root)")),
+		mcp.WithString("ref", mcp.Description("Branch, tag or commit SHA (default: the default branch)")),
+	)
+}
+
+// CreateListDirectoryHandler creates the handler for the list_directory tool
+func (f *ToolFactory) CreateListDirectoryHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListDirectoryRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+			Path:  getStringArg(args, "path"),
+			Ref:   getStringArg(args, "ref"),
+		}
+
+		response, err := f.contentsService.ListDirectory(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing directory", err), nil
+		}
+
+		entries := f.contentsService.FormatEntriesForMCP(response.Entries)
+		summary := fmt.Sprintf("\n%d entries in %s/%s/%s", response.Count, request.Owner, request.Repo, strings.Trim(request.Path, "/"))
+
+		return mcp.NewToolResultText(strings.Join(entries, "\n") + summary), nil
+	}
+}
+
+// CreateGetRepoTreeTool creates the tool for fetching a repository tree
+func (f *ToolFactory) CreateGetRepoTreeTool() mcp.Tool {
+	return mcp.NewTool("get_repo_tree",
+		mcp.WithDescription("Fetches the file tree of a GitHub repository"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("ref", mcp.Description("Branch, tag or tree SHA (default: HEAD)")),
+		mcp.WithBoolean("recursive", mcp.Description("Include all nested entries (default: false)")),
+		mcp.WithNumber("max_entries", mcp.Description("Maximum number of entries to return (default: 1000)")),
+	)
+}
+
+// CreateGetRepoTreeHandler creates the handler for the get_repo_tree tool
+func (f *ToolFactory) CreateGetRepoTreeHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetRepoTreeRequest{
+			Owner:      getStringArg(args, "owner"),
+			Repo:       getStringArg(args, "repo"),
+			Ref:        getStringArg(args, "ref"),
+			Recursive:  getBoolArg(args, "recursive"),
+			MaxEntries: int(getIntArg(args, "max_entries")),
+		}
+
+		tree, err := f.contentsService.GetRepoTree(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching repository tree", err), nil
+		}
+
+		paths := f.contentsService.FormatTreeForMCP(tree.Entries)
+		summary := fmt.Sprintf("\n%d entries in %s/%s@%s", len(tree.Entries), request.Owner, request.Repo, request.Ref)
+		if tree.Truncated {
+			summary += " (truncated)"
+		}
+
+		return mcp.NewToolResultText(strings.Join(paths, "\n") + summary), nil
+	}
+}
+
+// CreateSearchCodeTool creates the tool for searching code
+func (f *ToolFactory) CreateSearchCodeTool() mcp.Tool {
+	return mcp.NewTool("search_code",
+		mcp.WithDescription("Searches code on GitHub, optionally within one repository or owner"),
+		mcp.WithString("query", mcp.Required(), mcp.Description("Search terms and qualifiers (e.g. 'NewClient language:go')")),
+		mcp.WithString("owner", mcp.Description("Restrict the search to an owner, or the owner of repo")),
+		mcp.WithString("repo", mcp.Description("Restrict the search to a repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("per_page", mcp.Description("Number of results, 1-100 (default: 20)")),
+	)
+}
+
+// CreateSearchCodeHandler creates the handler for the search_code tool
+func (f *ToolFactory) CreateSearchCodeHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.SearchCodeRequest{
+			Query:   getStringArg(args, "query"),
+			Owner:   getStringArg(args, "owner"),
+			Repo:    getStringArg(args, "repo"),
+			PerPage: int(getIntArg(args, "per_page")),
+		}
+
+		response, err := f.contentsService.SearchCode(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error searching code", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, item := range f.contentsService.FormatSearchResultsForMCP(response.Items) {
+			contents = append(contents, mcp.NewTextContent(item))
+		}
+
+		summary := fmt.Sprintf("\nShowing %d of %d results", response.Count, response.TotalCount)
+		if response.Incomplete {
+			summary += " (search timed out, results may be incomplete)"
+		}
+		contents = append(contents, mcp.NewTextContent(summary))
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
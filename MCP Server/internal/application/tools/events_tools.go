@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateGetRepoEventsSinceTool creates the tool for reading the issue change feed
+func (f *ToolFactory) CreateGetRepoEventsSinceTool() mcp.Tool {
+	return mcp.NewTool("get_repo_events_since",
+		mcp.WithDescription("Returns what changed on the issues of a repository since a checkpoint: issues opened, closed, reopened, labeled, unlabeled, assigned, unassigned and commented, each with actor and time, oldest first. Pass the returned checkpoint to the next call to get only newer changes"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("checkpoint", mcp.Description("Checkpoint returned by the previous call")),
+		mcp.WithString("since", mcp.Description("Start of the feed without a checkpoint: YYYY-MM-DD or RFC 3339 time (default: 24 hours ago)")),
+		mcp.WithString("types", mcp.Description("Comma-separated change types to return: "+strings.Join(domain.ChangeTypes, ", ")+" (default: all)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of changes, 1-500 (default: 100)")),
+	)
+}
+
+// CreateGetRepoEventsSinceHandler creates the handler for the get_repo_events_since tool
+func (f *ToolFactory) CreateGetRepoEventsSinceHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetRepoEventsRequest{
+			Owner:      getStringArg(args, "owner"),
+			Repo:       getStringArg(args, "repo"),
+			Checkpoint: getStringArg(args, "checkpoint"),
+			Since:      getStringArg(args, "since"),
+			Types:      getStringListArg(args, "types"),
+			Limit:      int(getIntArg(args, "limit")),
+		}
+
+		response, err := f.eventsService.GetRepoEventsSince(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error reading repository events", err), nil
+		}
+
+		return mcp.NewToolResultText(f.eventsService.FormatChangesForMCP(response)), nil
+	}
+}
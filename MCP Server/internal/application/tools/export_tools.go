@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateExportIssuesTool creates the tool for exporting issues
+func (f *ToolFactory) CreateExportIssuesTool() mcp.Tool {
+	return mcp.NewTool("export_issues",
+		mcp.WithDescription("Exports every issue matching the filters as CSV, JSONL or a Markdown report grouped by label or milestone. The export is returned inline or, for large exports, written to the configured export directory"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("format", mcp.Required(), mcp.Description("Export format: csv, jsonl, markdown")),
+		mcp.WithString("state", mcp.Description("Issue state: open, closed, all (default: open)")),
+		mcp.WithString("labels", mcp.Description("Comma-separated labels; issues must have all of them")),
+		mcp.WithString("milestone", mcp.Description("Milestone title, or none for issues without a milestone")),
+		mcp.WithString("assignee", mcp.Description("Assignee login, or none for unassigned issues")),
+		mcp.WithString("author", mcp.Description("Author login")),
+		mcp.WithString("since", mcp.Description("Only issues updated on or after this date (YYYY-MM-DD)")),
+		mcp.WithString("query", mcp.Description("Extra GitHub search qualifiers within the repository, e.g. 'comments:>5 sort:created-asc'; repo:, org: and user: are not allowed")),
+		mcp.WithString("group_by", mcp.Description("Grouping of the Markdown report: label, milestone, none (default: label)")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of issues, up to %d (default: %d)", domain.MaxExportIssues, domain.MaxExportIssues))),
+		mcp.WithString("destination", mcp.Description("Where the export goes: inline, file (default: inline)")),
+		mcp.WithString("filename", mcp.Description("File name inside the export directory (default: <owner>-<repo>-issues-<date>.<ext>)")),
+	)
+}
+
+// CreateExportIssuesHandler creates the handler for the export_issues tool
+func (f *ToolFactory) CreateExportIssuesHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ExportIssuesRequest{
+			Owner:     getStringArg(args, "owner"),
+			Repo:      getStringArg(args, "repo"),
+			Format:    getStringArg(args, "format"),
+			State:     getStringArg(args, "state"),
+			Labels:    getStringListArg(args, "labels"),
+			Milestone: getStringArg(args, "milestone"),
+			Assignee:  getStringArg(args, "assignee"),
+			Author:    getStringArg(args, "author"),
+			Since:     getStringArg(args, "since"),
+			Query:     getStringArg(args, "query"),
+			GroupBy:   getStringArg(args, "group_by"),
+			Limit:     int(getIntArg(args, "limit")),
+		}
+
+		switch destination := getStringArg(args, "destination"); destination {
+		case "", "inline":
+			var export strings.Builder
+			result, err := f.exportService.ExportIssues(request, &export)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error exporting issues", err), nil
+			}
+			return &mcp.CallToolResult{Content: []mcp.Content{
+				mcp.NewTextContent(export.String()),
+				mcp.NewTextContent(describeExport(result)),
+			}}, nil
+		case "file":
+			result, err := f.exportService.ExportIssuesToFile(request, getStringArg(args, "filename"))
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error exporting issues", err), nil
+			}
+			return mcp.NewToolResultText(describeExport(result)), nil
+		default:
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid destination %q: use inline or file", destination)), nil
+		}
+	}
+}
+
+// describeExport summarizes a finished export
+func describeExport(result *domain.ExportIssuesResult) string {
+	text := fmt.Sprintf("Exported %d issues from %s as %s", result.Count, result.Repository, result.Format)
+	if result.Path != "" {
+		text += " to " + result.Path
+	}
+	if result.Truncated {
+		text += "; more issues matched than the limit allowed, narrow the filters or raise the limit"
+	}
+	return text
+}
@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/application/services"
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateListLabelsTool creates the tool for listing repository labels
+func (f *ToolFactory) CreateListLabelsTool() mcp.Tool {
+	return mcp.NewTool("list_labels",
+		mcp.WithDescription("Lists the labels of a GitHub repository with their colors and descriptions"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+	)
+}
+
+// CreateListLabelsHandler creates the handler for the list_labels tool
+func (f *ToolFactory) CreateListLabelsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListLabelsRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+		}
+
+		labels, err := f.labelsService.ListLabels(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing labels", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, label := range f.labelsService.FormatLabelsForMCP(labels) {
+			contents = append(contents, mcp.NewTextContent(label))
+		}
+		contents = append(contents, mcp.NewTextContent(fmt.Sprintf("\nFound %d labels in %s/%s", len(labels), request.Owner, request.Repo)))
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateCreateLabelTool creates the tool for creating a label
+func (f *ToolFactory) CreateCreateLabelTool() mcp.Tool {
+	return mcp.NewTool("create_label",
+		mcp.WithDescription("Creates a label in a GitHub repository"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Label name")),
+		mcp.WithString("color", mcp.Required(), mcp.Description("6-digit hex color, with or without '#' (e.g. d73a4a)")),
+		mcp.WithString("description", mcp.Description("Short description of the label")),
+	)
+}
+
+// CreateCreateLabelHandler creates the handler for the create_label tool
+func (f *ToolFactory) CreateCreateLabelHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.CreateLabelRequest{
+			Owner:       getStringArg(args, "owner"),
+			Repo:        getStringArg(args, "repo"),
+			Name:        getStringArg(args, "name"),
+			Color:       getStringArg(args, "color"),
+			Description: getStringArg(args, "description"),
+		}
+
+		label, err := f.labelsService.CreateLabel(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error creating label", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Created label %s #%s in %s/%s", label.Name, label.Color, request.Owner, request.Repo)), nil
+	}
+}
+
+// CreateUpdateLabelTool creates the tool for updating a label
+func (f *ToolFactory) CreateUpdateLabelTool() mcp.Tool {
+	return mcp.NewTool("update_label",
+		mcp.WithDescription("Renames a label or changes its color or description"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Current label name")),
+		mcp.WithString("new_name", mcp.Description("New label name")),
+		mcp.WithString("color", mcp.Description("New 6-digit hex color")),
+		mcp.WithString("description", mcp.Description("New description; an empty string clears it")),
+	)
+}
+
+// CreateUpdateLabelHandler creates the handler for the update_label tool
+func (f *ToolFactory) CreateUpdateLabelHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.UpdateLabelRequest{
+			Owner:   getStringArg(args, "owner"),
+			Repo:    getStringArg(args, "repo"),
+			Name:    getStringArg(args, "name"),
+			NewName: getStringArg(args, "new_name"),
+			Color:   getStringArg(args, "color"),
+		}
+		if _, set := args["description"]; set {
+			description := getStringArg(args, "description")
+			request.Description = &description
+		}
+
+		label, err := f.labelsService.UpdateLabel(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error updating label", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Updated label %s #%s in %s/%s", label.Name, label.Color, request.Owner, request.Repo)), nil
+	}
+}
+
+// CreateDeleteLabelTool creates the tool for deleting a label
+func (f *ToolFactory) CreateDeleteLabelTool() mcp.Tool {
+	return mcp.NewTool("delete_label",
+		mcp.WithDescription("Deletes a label from a GitHub repository; it is removed from all issues"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Label name")),
+	)
+}
+
+// CreateDeleteLabelHandler creates the handler for the delete_label tool
+func (f *ToolFactory) CreateDeleteLabelHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.DeleteLabelRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+			Name:  getStringArg(args, "name"),
+		}
+
+		if err := f.labelsService.DeleteLabel(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error deleting label", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Deleted label %s from %s/%s", request.Name, request.Owner, request.Repo)), nil
+	}
+}
+
+// CreateSyncLabelsTool creates the tool for applying a label spec to many repositories
+func (f *ToolFactory) CreateSyncLabelsTool() mcp.Tool {
+	return mcp.NewTool("sync_labels",
+		mcp.WithDescription("Applies a YAML label spec (repositories, labels with name/color/description/aliases, prune) across repositories. Dry run by default: returns the diff per repository without changing anything"),
+		mcp.WithString("spec", mcp.Required(), mcp.Description("YAML label spec; aliases are old names that are renamed to the label")),
+		mcp.WithString("repos", mcp.Description("Comma-separated owner/repo targets; overrides the spec's repositories")),
+		mcp.WithBoolean("dry_run", mcp.Description("Only report the differences (default: true)")),
+		mcp.WithBoolean("prune", mcp.Description("Delete labels that are not in the spec; overrides the spec's prune setting")),
+	)
+}
+
+// CreateSyncLabelsHandler creates the handler for the sync_labels tool
+func (f *ToolFactory) CreateSyncLabelsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		spec, err := services.ParseLabelSpec(getStringArg(args, "spec"))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error syncing labels", err), nil
+		}
+		if repos := getStringListArg(args, "repos"); len(repos) > 0 {
+			spec.Repositories = repos
+		}
+		if _, set := args["prune"]; set {
+			spec.Prune = getBoolArg(args, "prune")
+		}
+
+		request := &domain.SyncLabelsRequest{
+			Spec:   *spec,
+			DryRun: true,
+		}
+		if _, set := args["dry_run"]; set {
+			request.DryRun = getBoolArg(args, "dry_run")
+		}
+
+		response, err := f.labelsService.SyncLabels(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error syncing labels", err), nil
+		}
+
+		return mcp.NewToolResultText(f.labelsService.FormatLabelSyncForMCP(response)), nil
+	}
+}
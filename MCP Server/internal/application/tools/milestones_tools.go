@@ -0,0 +1,167 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateListMilestonesTool creates the tool for listing milestones
+func (f *ToolFactory) CreateListMilestonesTool() mcp.Tool {
+	return mcp.NewTool("list_milestones",
+		mcp.WithDescription("Lists the milestones of a GitHub repository with due dates and open/closed issue counts"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("state", mcp.Description("Milestone state: open, closed, all (default: open)")),
+	)
+}
+
+// CreateListMilestonesHandler creates the handler for the list_milestones tool
+func (f *ToolFactory) CreateListMilestonesHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListMilestonesRequest{
+			Owner: getStringArg(args, "owner"),
+			Repo:  getStringArg(args, "repo"),
+			State: getStringArg(args, "state"),
+		}
+
+		milestones, err := f.milestonesService.ListMilestones(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing milestones", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, milestone := range f.milestonesService.FormatMilestonesForMCP(milestones) {
+			contents = append(contents, mcp.NewTextContent(milestone))
+		}
+		contents = append(contents, mcp.NewTextContent(fmt.Sprintf("\nFound %d %s milestones in %s/%s", len(milestones), request.State, request.Owner, request.Repo)))
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateCreateMilestoneTool creates the tool for creating a milestone
+func (f *ToolFactory) CreateCreateMilestoneTool() mcp.Tool {
+	return mcp.NewTool("create_milestone",
+		mcp.WithDescription("Creates a milestone in a GitHub repository"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithString("title", mcp.Required(), mcp.Description("Milestone title")),
+		mcp.WithString("description", mcp.Description("Milestone description")),
+		mcp.WithString("due_on", mcp.Description("Due date in YYYY-MM-DD form")),
+		mcp.WithString("state", mcp.Description("Milestone state: open, closed (default: open)")),
+	)
+}
+
+// CreateCreateMilestoneHandler creates the handler for the create_milestone tool
+func (f *ToolFactory) CreateCreateMilestoneHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.CreateMilestoneRequest{
+			Owner:       getStringArg(args, "owner"),
+			Repo:        getStringArg(args, "repo"),
+			Title:       getStringArg(args, "title"),
+			Description: getStringArg(args, "description"),
+			DueOn:       getStringArg(args, "due_on"),
+			State:       getStringArg(args, "state"),
+		}
+
+		milestone, err := f.milestonesService.CreateMilestone(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error creating milestone", err), nil
+		}
+
+		return mcp.NewToolResultText("Created milestone " + f.milestonesService.FormatMilestoneForMCP(milestone)), nil
+	}
+}
+
+// CreateUpdateMilestoneTool creates the tool for updating a milestone
+func (f *ToolFactory) CreateUpdateMilestoneTool() mcp.Tool {
+	return mcp.NewTool("update_milestone",
+		mcp.WithDescription("Changes the title, description, due date or state of a milestone; closing a milestone is done by setting state to closed"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("number", mcp.Required(), mcp.Description("Milestone number")),
+		mcp.WithString("title", mcp.Description("New title")),
+		mcp.WithString("description", mcp.Description("New description; an empty string clears it")),
+		mcp.WithString("due_on", mcp.Description("New due date in YYYY-MM-DD form; an empty string clears it")),
+		mcp.WithString("state", mcp.Description("New state: open, closed")),
+	)
+}
+
+// CreateUpdateMilestoneHandler creates the handler for the update_milestone tool
+func (f *ToolFactory) CreateUpdateMilestoneHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.UpdateMilestoneRequest{
+			Owner:  getStringArg(args, "owner"),
+			Repo:   getStringArg(args, "repo"),
+			Number: int(getIntArg(args, "number")),
+			Title:  getStringArg(args, "title"),
+			State:  getStringArg(args, "state"),
+		}
+		if _, set := args["description"]; set {
+			description := getStringArg(args, "description")
+			request.Description = &description
+		}
+		if _, set := args["due_on"]; set {
+			dueOn := getStringArg(args, "due_on")
+			request.DueOn = &dueOn
+		}
+
+		milestone, err := f.milestonesService.UpdateMilestone(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error updating milestone", err), nil
+		}
+
+		return mcp.NewToolResultText("Updated milestone " + f.milestonesService.FormatMilestoneForMCP(milestone)), nil
+	}
+}
+
+// CreateDeleteMilestoneTool creates the tool for deleting a milestone
+func (f *ToolFactory) CreateDeleteMilestoneTool() mcp.Tool {
+	return mcp.NewTool("delete_milestone",
+		mcp.WithDescription("Deletes a milestone; its issues are kept but lose the milestone"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("number", mcp.Required(), mcp.Description("Milestone number")),
+	)
+}
+
+// CreateDeleteMilestoneHandler creates the handler for the delete_milestone tool
+func (f *ToolFactory) CreateDeleteMilestoneHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.DeleteMilestoneRequest{
+			Owner:  getStringArg(args, "owner"),
+			Repo:   getStringArg(args, "repo"),
+			Number: int(getIntArg(args, "number")),
+		}
+
+		if err := f.milestonesService.DeleteMilestone(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error deleting milestone", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Deleted milestone #%d from %s/%s", request.Number, request.Owner, request.Repo)), nil
+	}
+}
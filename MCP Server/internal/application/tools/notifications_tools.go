@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateListNotificationsTool creates the tool for listing notifications
+func (f *ToolFactory) CreateListNotificationsTool() mcp.Tool {
+	return mcp.NewTool("list_notifications",
+		mcp.WithDescription("Lists the GitHub notifications inbox of the authenticated user (mentions, review requests, assignments, ...)"),
+		mcp.WithString("owner", mcp.Description("Repository owner; only used together with repo")),
+		mcp.WithString("repo", mcp.Description("Only notifications of this repository (name, owner/repo pair or configured alias)")),
+		mcp.WithString("reason", mcp.Description("Comma-separated reasons: mention, team_mention, review_requested, assign, author, comment, ci_activity, state_change, subscribed, ...")),
+		mcp.WithBoolean("participating", mcp.Description("Only threads the user participates in or is mentioned in")),
+		mcp.WithBoolean("all", mcp.Description("Include notifications already marked as read")),
+		mcp.WithString("since", mcp.Description("Only notifications updated after this ISO 8601 timestamp")),
+		mcp.WithNumber("per_page", mcp.Description("Number of notifications to fetch, 1-50 (default: 30)")),
+		mcp.WithNumber("page", mcp.Description("Page number returned by a previous call")),
+	)
+}
+
+// CreateListNotificationsHandler creates the handler for the list_notifications tool
+func (f *ToolFactory) CreateListNotificationsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListNotificationsRequest{
+			Owner:         getStringArg(args, "owner"),
+			Repo:          getStringArg(args, "repo"),
+			Reasons:       getStringListArg(args, "reason"),
+			Participating: getBoolArg(args, "participating"),
+			All:           getBoolArg(args, "all"),
+			Since:         getStringArg(args, "since"),
+			PerPage:       int(getIntArg(args, "per_page")),
+			Page:          int(getIntArg(args, "page")),
+		}
+
+		response, err := f.notificationsService.ListNotifications(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing notifications", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, notification := range f.notificationsService.FormatNotificationsForMCP(response.Notifications) {
+			contents = append(contents, mcp.NewTextContent(notification))
+		}
+
+		summaryText := fmt.Sprintf("\nFound %d notifications (* = unread)", response.Count)
+		if response.NextPage > 0 {
+			summaryText += fmt.Sprintf("\nMore notifications available, call again with page %d", response.NextPage)
+		}
+		contents = append(contents, mcp.NewTextContent(summaryText))
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateGetNotificationThreadTool creates the tool for reading a notification thread
+func (f *ToolFactory) CreateGetNotificationThreadTool() mcp.Tool {
+	return mcp.NewTool("get_notification_thread",
+		mcp.WithDescription("Fetches a notification thread with the issue or pull request it is about and its latest comment"),
+		mcp.WithString("thread_id", mcp.Required(), mcp.Description("Notification thread ID as returned by list_notifications")),
+		mcp.WithNumber("max_body_tokens", mcp.Description("Token budget of the subject body and the comment; longer ones are truncated")),
+	)
+}
+
+// CreateGetNotificationThreadHandler creates the handler for the get_notification_thread tool
+func (f *ToolFactory) CreateGetNotificationThreadHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		budget := outputBudget(f.budgets, "get_notification_thread", args)
+		if err := validateBudget(budget); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching notification thread", err), nil
+		}
+
+		request := &domain.GetNotificationThreadRequest{
+			ThreadID:    getThreadIDArg(args),
+			WithSubject: true,
+		}
+
+		thread, err := f.notificationsService.GetNotificationThread(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching notification thread", err), nil
+		}
+
+		if thread.Subject != nil {
+			thread.Subject.Body = truncateBody(thread.Subject.Body, budget.MaxBodyBytes())
+		}
+		if thread.LatestComment != nil {
+			thread.LatestComment.Body = truncateBody(thread.LatestComment.Body, budget.MaxBodyBytes())
+		}
+
+		return mcp.NewToolResultText(f.notificationsService.FormatNotificationThreadForMCP(thread)), nil
+	}
+}
+
+// CreateMarkNotificationReadTool creates the tool for marking a thread as read
+func (f *ToolFactory) CreateMarkNotificationReadTool() mcp.Tool {
+	return mcp.NewTool("mark_notification_read",
+		mcp.WithDescription("Marks a notification thread as read"),
+		mcp.WithString("thread_id", mcp.Required(), mcp.Description("Notification thread ID as returned by list_notifications")),
+	)
+}
+
+// CreateMarkNotificationReadHandler creates the handler for the mark_notification_read tool
+func (f *ToolFactory) CreateMarkNotificationReadHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.MarkNotificationReadRequest{
+			ThreadID: getThreadIDArg(args),
+		}
+
+		if err := f.notificationsService.MarkNotificationRead(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error marking notification as read", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Marked thread %s as read", request.ThreadID)), nil
+	}
+}
+
+// getThreadIDArg reads the thread ID, which clients may send as a string or a number
+func getThreadIDArg(args map[string]interface{}) string {
+	if id := getStringArg(args, "thread_id"); id != "" {
+		return id
+	}
+	if id := getIntArg(args, "thread_id"); id > 0 {
+		return strconv.FormatInt(id, 10)
+	}
+	return ""
+}
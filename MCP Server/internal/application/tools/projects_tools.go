@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateListProjectItemsTool creates the tool for listing project board items
+func (f *ToolFactory) CreateListProjectItemsTool() mcp.Tool {
+	return mcp.NewTool("list_project_items",
+		mcp.WithDescription("Lists the items of a GitHub Projects (v2) board with their custom field values (status, iteration, estimate, ...)"),
+		mcp.WithString("owner", mcp.Description("Organization or user that owns the project; defaults to the profile's default owner")),
+		mcp.WithNumber("project", mcp.Required(), mcp.Description("Project number")),
+		mcp.WithString("status", mcp.Description("Only items with this Status value")),
+		mcp.WithNumber("per_page", mcp.Description("Number of items to fetch, 1-100 (default: 50)")),
+		mcp.WithString("cursor", mcp.Description("Pagination cursor returned by a previous call")),
+	)
+}
+
+// CreateListProjectItemsHandler creates the handler for the list_project_items tool
+func (f *ToolFactory) CreateListProjectItemsHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.ListProjectItemsRequest{
+			Owner:   getStringArg(args, "owner"),
+			Number:  int(getIntArg(args, "project")),
+			Status:  getStringArg(args, "status"),
+			PerPage: int(getIntArg(args, "per_page")),
+			Cursor:  getStringArg(args, "cursor"),
+		}
+
+		response, err := f.projectsService.ListProjectItems(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error listing project items", err), nil
+		}
+
+		var contents []mcp.Content
+		for _, item := range f.projectsService.FormatProjectItemsForMCP(response.Items) {
+			contents = append(contents, mcp.NewTextContent(item))
+		}
+
+		summaryText := fmt.Sprintf("\nShowing %d of %d items in project #%d %s", response.Count, response.TotalCount, response.Project.Number, response.Project.Title)
+		if response.NextCursor != "" {
+			summaryText += fmt.Sprintf("\nMore items available, call again with cursor %q", response.NextCursor)
+		}
+		contents = append(contents, mcp.NewTextContent(summaryText))
+
+		return &mcp.CallToolResult{Content: contents}, nil
+	}
+}
+
+// CreateMoveProjectItemTool creates the tool for moving an item to another status
+func (f *ToolFactory) CreateMoveProjectItemTool() mcp.Tool {
+	return mcp.NewTool("move_project_item",
+		mcp.WithDescription("Moves a GitHub Projects (v2) item to another Status column"),
+		mcp.WithString("owner", mcp.Description("Organization or user that owns the project; defaults to the profile's default owner")),
+		mcp.WithNumber("project", mcp.Required(), mcp.Description("Project number")),
+		mcp.WithString("item_id", mcp.Required(), mcp.Description("Project item ID (PVTI_...)")),
+		mcp.WithString("status", mcp.Required(), mcp.Description("Name of the target Status option (e.g. 'In Progress')")),
+	)
+}
+
+// CreateMoveProjectItemHandler creates the handler for the move_project_item tool
+func (f *ToolFactory) CreateMoveProjectItemHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.SetProjectFieldRequest{
+			Owner:  getStringArg(args, "owner"),
+			Number: int(getIntArg(args, "project")),
+			ItemID: getStringArg(args, "item_id"),
+			Value:  getStringArg(args, "status"),
+		}
+
+		if err := f.projectsService.MoveProjectItem(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error moving project item", err), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Moved item %s to %q", request.ItemID, request.Value)), nil
+	}
+}
+
+// CreateSetProjectFieldTool creates the tool for setting a project field value
+func (f *ToolFactory) CreateSetProjectFieldTool() mcp.Tool {
+	return mcp.NewTool("set_project_field",
+		mcp.WithDescription("Sets or clears a custom field value (text, number, date, single select, iteration) of a GitHub Projects (v2) item"),
+		mcp.WithString("owner", mcp.Description("Organization or user that owns the project; defaults to the profile's default owner")),
+		mcp.WithNumber("project", mcp.Required(), mcp.Description("Project number")),
+		mcp.WithString("item_id", mcp.Required(), mcp.Description("Project item ID (PVTI_...)")),
+		mcp.WithString("field", mcp.Required(), mcp.Description("Field name (e.g. 'Estimate', 'Iteration')")),
+		mcp.WithString("value", mcp.Description("New value: text, number, YYYY-MM-DD date, option name or iteration title; empty clears the field")),
+	)
+}
+
+// CreateSetProjectFieldHandler creates the handler for the set_project_field tool
+func (f *ToolFactory) CreateSetProjectFieldHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.SetProjectFieldRequest{
+			Owner:  getStringArg(args, "owner"),
+			Number: int(getIntArg(args, "project")),
+			ItemID: getStringArg(args, "item_id"),
+			Field:  getStringArg(args, "field"),
+			Value:  getStringArg(args, "value"),
+		}
+
+		if err := f.projectsService.SetProjectField(request); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error setting project field", err), nil
+		}
+
+		if request.Value == "" {
+			return mcp.NewToolResultText(fmt.Sprintf("Cleared %q on item %s", request.Field, request.ItemID)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Set %q to %q on item %s", request.Field, request.Value, request.ItemID)), nil
+	}
+}
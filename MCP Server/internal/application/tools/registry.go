@@ -0,0 +1,205 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mcp-server/pkg/errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolDefinition declares a tool once: its schema, which is also used to
+// validate the arguments of every call, its handler, whether it writes
+// to GitHub and whether it limits the size of its own output
+type ToolDefinition struct {
+	Tool    mcp.Tool
+	Handler server.ToolHandlerFunc
+	// Writes marks tools that modify GitHub; they are not registered in read-only mode
+	Writes bool
+	// OwnBudget marks tools whose output is already limited by their own
+	// arguments (max_tokens, max_bytes); OutputBudgetMiddleware leaves them alone
+	OwnBudget bool
+}
+
+// Registry holds the tool definitions and registers them with an MCP server
+type Registry struct {
+	definitions []ToolDefinition
+	byName      map[string]int
+}
+
+// NewRegistry creates a registry from definitions. Every tool without its
+// own budget gets the max_output_tokens argument honored by
+// OutputBudgetMiddleware. Duplicate tool names are a programming error and panic.
+func NewRegistry(definitions ...ToolDefinition) *Registry {
+	r := &Registry{byName: make(map[string]int, len(definitions))}
+
+	for _, def := range definitions {
+		name := def.Tool.Name
+		if _, exists := r.byName[name]; exists {
+			panic(fmt.Sprintf("tool %q is defined twice", name))
+		}
+		if _, declared := def.Tool.InputSchema.Properties["max_output_tokens"]; !declared && !def.OwnBudget {
+			mcp.WithNumber("max_output_tokens", mcp.Description("Token budget of the whole result; longer output is truncated"))(&def.Tool)
+		}
+
+		r.byName[name] = len(r.definitions)
+		r.definitions = append(r.definitions, def)
+	}
+
+	return r
+}
+
+// Definitions returns the tool definitions in declaration order
+func (r *Registry) Definitions() []ToolDefinition {
+	return append([]ToolDefinition(nil), r.definitions...)
+}
+
+// Lookup returns the definition of the named tool
+func (r *Registry) Lookup(name string) (ToolDefinition, bool) {
+	i, ok := r.byName[name]
+	if !ok {
+		return ToolDefinition{}, false
+	}
+	return r.definitions[i], true
+}
+
+// Unknown returns the names that do not match a defined tool
+func (r *Registry) Unknown(names []string) []string {
+	var unknown []string
+	for _, name := range names {
+		if _, ok := r.byName[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// Register adds the enabled tools to the server, skipping tools that write
+// in read-only mode, and returns the names of the registered tools. Every
+// handler is wrapped so calls with invalid arguments are rejected.
+func (r *Registry) Register(mcpServer *server.MCPServer, enabled func(name string) bool, readOnly bool) []string {
+	var registered []string
+
+	for _, def := range r.definitions {
+		if !enabled(def.Tool.Name) || (def.Writes && readOnly) {
+			continue
+		}
+		mcpServer.AddTool(def.Tool, validatingHandler(def))
+		registered = append(registered, def.Tool.Name)
+	}
+
+	return registered
+}
+
+// validatingHandler checks the arguments of a call against the tool schema
+// before handing it to the tool's handler
+func validatingHandler(def ToolDefinition) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := ValidateArguments(def.Tool, req.Params.Arguments); err != nil {
+			return mcp.NewToolResultErrorFromErr("Invalid arguments", err), nil
+		}
+		return def.Handler(ctx, req)
+	}
+}
+
+// ValidateArguments checks call arguments against a tool schema: unknown
+// arguments are rejected, required ones must be present and values must fit
+// the declared type. Types are checked leniently, the way the argument
+// helpers read them: numbers and booleans may be sent as strings, and string
+// arguments also take numbers (IDs) and arrays of strings (lists).
+func ValidateArguments(tool mcp.Tool, arguments any) error {
+	var args map[string]interface{}
+	switch value := arguments.(type) {
+	case nil:
+	case map[string]interface{}:
+		args = value
+	default:
+		return errors.NewValidationError("the arguments must be an object")
+	}
+
+	properties := tool.InputSchema.Properties
+
+	var unknown []string
+	for name := range args {
+		if _, ok := properties[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		known := make([]string, 0, len(properties))
+		for name := range properties {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return errors.NewValidationError(fmt.Sprintf("unknown arguments %s for %s; accepted arguments: %s",
+			strings.Join(unknown, ", "), tool.Name, strings.Join(known, ", ")))
+	}
+
+	for _, name := range tool.InputSchema.Required {
+		if value, ok := args[name]; !ok || value == nil || value == "" {
+			return errors.NewValidationError(fmt.Sprintf("the '%s' parameter is required", name))
+		}
+	}
+
+	for name, value := range args {
+		schema, _ := properties[name].(map[string]any)
+		if err := checkArgumentType(name, schema["type"], value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkArgumentType checks a single argument against its declared JSON type
+func checkArgumentType(name string, declared any, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	switch declared {
+	case "number", "integer":
+		switch v := value.(type) {
+		case float64, int, int64:
+			return nil
+		case string:
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				return nil
+			}
+		}
+		return errors.NewValidationError(fmt.Sprintf("the '%s' parameter must be a number", name))
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return nil
+		case string:
+			if _, err := strconv.ParseBool(v); err == nil {
+				return nil
+			}
+		}
+		return errors.NewValidationError(fmt.Sprintf("the '%s' parameter must be true or false", name))
+	case "string":
+		switch v := value.(type) {
+		case string, float64, int, int64:
+			return nil
+		case []interface{}:
+			for _, item := range v {
+				switch item.(type) {
+				case string, float64:
+				default:
+					return errors.NewValidationError(fmt.Sprintf("the '%s' parameter must be a string or a list of strings", name))
+				}
+			}
+			return nil
+		}
+		return errors.NewValidationError(fmt.Sprintf("the '%s' parameter must be a string", name))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func testTool() mcp.Tool {
+	return mcp.NewTool("test_tool",
+		mcp.WithString("repo", mcp.Required()),
+		mcp.WithString("labels"),
+		mcp.WithNumber("per_page"),
+		mcp.WithBoolean("dry_run"),
+	)
+}
+
+func TestValidateArguments(t *testing.T) {
+	testCases := []struct {
+		name  string
+		args  any
+		error string
+	}{
+		{"valid", map[string]interface{}{"repo": "api", "per_page": 10.0, "dry_run": true}, ""},
+		{"lenient types", map[string]interface{}{"repo": 42.0, "labels": []interface{}{"a", "b"}, "per_page": "10", "dry_run": "false"}, ""},
+		{"unknown argument", map[string]interface{}{"repo": "api", "reop": "api"}, "unknown arguments reop for test_tool; accepted arguments: dry_run, labels, per_page, repo"},
+		{"missing required", map[string]interface{}{"per_page": 1.0}, "the 'repo' parameter is required"},
+		{"empty required", map[string]interface{}{"repo": ""}, "the 'repo' parameter is required"},
+		{"no arguments", nil, "the 'repo' parameter is required"},
+		{"bad number", map[string]interface{}{"repo": "api", "per_page": "ten"}, "the 'per_page' parameter must be a number"},
+		{"bad boolean", map[string]interface{}{"repo": "api", "dry_run": "maybe"}, "the 'dry_run' parameter must be true or false"},
+		{"bad list", map[string]interface{}{"repo": "api", "labels": []interface{}{map[string]interface{}{}}}, "must be a string or a list of strings"},
+		{"not an object", []interface{}{"api"}, "the arguments must be an object"},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateArguments(testTool(), tc.args)
+		switch {
+		case tc.error == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)):
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.error)
+		}
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	handler := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+	registry := NewRegistry(
+		ToolDefinition{Tool: mcp.NewTool("read_tool"), Handler: handler},
+		ToolDefinition{Tool: mcp.NewTool("write_tool"), Handler: handler, Writes: true},
+		ToolDefinition{Tool: mcp.NewTool("other_tool"), Handler: handler},
+		ToolDefinition{Tool: mcp.NewTool("logs_tool"), Handler: handler, OwnBudget: true},
+	)
+
+	testCases := []struct {
+		name     string
+		disabled string
+		readOnly bool
+		expected string
+	}{
+		{"all", "", false, "read_tool,write_tool,other_tool,logs_tool"},
+		{"read-only", "", true, "read_tool,other_tool,logs_tool"},
+		{"disabled", "other_tool", false, "read_tool,write_tool,logs_tool"},
+	}
+
+	for _, tc := range testCases {
+		enabled := func(name string) bool { return name != tc.disabled }
+		registered := registry.Register(server.NewMCPServer("test", "0"), enabled, tc.readOnly)
+		if got := strings.Join(registered, ","); got != tc.expected {
+			t.Errorf("%s: registered %s, want %s", tc.name, got, tc.expected)
+		}
+	}
+
+	// Every tool without its own budget accepts the output budget of the middleware
+	def, _ := registry.Lookup("read_tool")
+	if _, ok := def.Tool.InputSchema.Properties["max_output_tokens"]; !ok {
+		t.Error("max_output_tokens was not added to the schema")
+	}
+	def, _ = registry.Lookup("logs_tool")
+	if _, ok := def.Tool.InputSchema.Properties["max_output_tokens"]; ok {
+		t.Error("max_output_tokens was added to a tool with its own budget")
+	}
+
+	if unknown := registry.Unknown([]string{"read_tool", "raed_tool"}); len(unknown) != 1 || unknown[0] != "raed_tool" {
+		t.Errorf("Unknown returned %v", unknown)
+	}
+}
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("defining a tool twice did not panic")
+		}
+	}()
+	NewRegistry(ToolDefinition{Tool: mcp.NewTool("twice")}, ToolDefinition{Tool: mcp.NewTool("twice")})
+}
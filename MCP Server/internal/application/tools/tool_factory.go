@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"mcp-server/internal/application/services"
 	"mcp-server/internal/domain"
@@ -12,13 +14,92 @@ import (
 
 // ToolFactory creates MCP tools using the Factory pattern
 type ToolFactory struct {
-	issueService services.IssueServiceInterface
+	issueService         services.IssueServiceInterface
+	actionsService       services.ActionsServiceInterface
+	contentsService      services.ContentsServiceInterface
+	projectsService      services.ProjectsServiceInterface
+	notificationsService services.NotificationsServiceInterface
+	labelsService        services.LabelsServiceInterface
+	milestonesService    services.MilestonesServiceInterface
+	bulkIssuesService    services.BulkIssuesServiceInterface
+	exportService        services.ExportServiceInterface
+	eventsService        services.EventsServiceInterface
+	budgets              BudgetProvider
 }
 
 // NewToolFactory creates a new ToolFactory instance
-func NewToolFactory(issueService services.IssueServiceInterface) *ToolFactory {
+func NewToolFactory(
+	issueService services.IssueServiceInterface,
+	actionsService services.ActionsServiceInterface,
+	contentsService services.ContentsServiceInterface,
+	projectsService services.ProjectsServiceInterface,
+	notificationsService services.NotificationsServiceInterface,
+	labelsService services.LabelsServiceInterface,
+	milestonesService services.MilestonesServiceInterface,
+	bulkIssuesService services.BulkIssuesServiceInterface,
+	exportService services.ExportServiceInterface,
+	eventsService services.EventsServiceInterface,
+	budgets BudgetProvider,
+) *ToolFactory {
 	return &ToolFactory{
-		issueService: issueService,
+		issueService:         issueService,
+		actionsService:       actionsService,
+		contentsService:      contentsService,
+		projectsService:      projectsService,
+		notificationsService: notificationsService,
+		labelsService:        labelsService,
+		milestonesService:    milestonesService,
+		bulkIssuesService:    bulkIssuesService,
+		exportService:        exportService,
+		eventsService:        eventsService,
+		budgets:              budgets,
+	}
+}
+
+// Definitions declares every tool the server offers, in registration order.
+// Adding a tool means adding it here; the registry does the wiring.
+func (f *ToolFactory) Definitions() []ToolDefinition {
+	return []ToolDefinition{
+		// Issues; bulk_update_issues stays available in read-only mode for
+		// dry runs, and export_issues only writes to the export directory
+		{Tool: f.CreateGetIssuesTool(), Handler: f.CreateGetIssuesHandler()},
+		{Tool: f.CreateGetIssueTool(), Handler: f.CreateGetIssueHandler()},
+		{Tool: f.CreateBulkUpdateIssuesTool(), Handler: f.CreateBulkUpdateIssuesHandler()},
+		{Tool: f.CreateExportIssuesTool(), Handler: f.CreateExportIssuesHandler()},
+		{Tool: f.CreateGetRepoEventsSinceTool(), Handler: f.CreateGetRepoEventsSinceHandler()},
+
+		// GitHub Actions
+		{Tool: f.CreateListWorkflowRunsTool(), Handler: f.CreateListWorkflowRunsHandler()},
+		{Tool: f.CreateGetWorkflowRunTool(), Handler: f.CreateGetWorkflowRunHandler()},
+		{Tool: f.CreateGetJobLogsTool(), Handler: f.CreateGetJobLogsHandler(), OwnBudget: true},
+		{Tool: f.CreateRerunFailedJobsTool(), Handler: f.CreateRerunFailedJobsHandler(), Writes: true},
+
+		// Repository contents
+		{Tool: f.CreateGetFileContentsTool(), Handler: f.CreateGetFileContentsHandler(), OwnBudget: true},
+		{Tool: f.CreateListDirectoryTool(), Handler: f.CreateListDirectoryHandler()},
+		{Tool: f.CreateGetRepoTreeTool(), Handler: f.CreateGetRepoTreeHandler()},
+		{Tool: f.CreateSearchCodeTool(), Handler: f.CreateSearchCodeHandler()},
+
+		// Projects (v2)
+		{Tool: f.CreateListProjectItemsTool(), Handler: f.CreateListProjectItemsHandler()},
+		{Tool: f.CreateMoveProjectItemTool(), Handler: f.CreateMoveProjectItemHandler(), Writes: true},
+		{Tool: f.CreateSetProjectFieldTool(), Handler: f.CreateSetProjectFieldHandler(), Writes: true},
+
+		// Notifications
+		{Tool: f.CreateListNotificationsTool(), Handler: f.CreateListNotificationsHandler()},
+		{Tool: f.CreateGetNotificationThreadTool(), Handler: f.CreateGetNotificationThreadHandler()},
+		{Tool: f.CreateMarkNotificationReadTool(), Handler: f.CreateMarkNotificationReadHandler(), Writes: true},
+
+		// Labels and milestones; sync_labels stays available for dry runs
+		{Tool: f.CreateListLabelsTool(), Handler: f.CreateListLabelsHandler()},
+		{Tool: f.CreateCreateLabelTool(), Handler: f.CreateCreateLabelHandler(), Writes: true},
+		{Tool: f.CreateUpdateLabelTool(), Handler: f.CreateUpdateLabelHandler(), Writes: true},
+		{Tool: f.CreateDeleteLabelTool(), Handler: f.CreateDeleteLabelHandler(), Writes: true},
+		{Tool: f.CreateSyncLabelsTool(), Handler: f.CreateSyncLabelsHandler()},
+		{Tool: f.CreateListMilestonesTool(), Handler: f.CreateListMilestonesHandler()},
+		{Tool: f.CreateCreateMilestoneTool(), Handler: f.CreateCreateMilestoneHandler(), Writes: true},
+		{Tool: f.CreateUpdateMilestoneTool(), Handler: f.CreateUpdateMilestoneHandler(), Writes: true},
+		{Tool: f.CreateDeleteMilestoneTool(), Handler: f.CreateDeleteMilestoneHandler(), Writes: true},
 	}
 }
 
@@ -26,9 +107,16 @@ func NewToolFactory(issueService services.IssueServiceInterface) *ToolFactory {
 func (f *ToolFactory) CreateGetIssuesTool() mcp.Tool {
 	return mcp.NewTool("get_issues",
 		mcp.WithDescription("Fetches issues from a GitHub repository"),
-		mcp.WithString("owner", mcp.Required(), mcp.Description("Repository owner (organization or user)")),
-		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name")),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
 		mcp.WithString("state", mcp.Description("Issue state: open, closed, all (default: open)")),
+		mcp.WithString("fields", mcp.Description("Comma-separated extra fields: body, assignees, comments, linked_prs, projects")),
+		mcp.WithNumber("per_page", mcp.Description("Number of issues to return, 1-100 (default: 30)")),
+		mcp.WithString("cursor", mcp.Description("Pagination cursor returned by a previous call")),
+		mcp.WithBoolean("include_projects", mcp.Description("Annotate issues with their project memberships; opt-in because it needs the GraphQL API (default: false)")),
+		mcp.WithNumber("max_output_tokens", mcp.Description("Token budget of the whole result; issues beyond it are summarized and a cursor is returned")),
+		mcp.WithNumber("max_body_tokens", mcp.Description("Token budget of each issue body; longer bodies are truncated")),
+		mcp.WithString("group_by", mcp.Description("Group issues by: none, state, label (default: none)")),
 	)
 }
 
@@ -43,33 +131,116 @@ func (f *ToolFactory) CreateGetIssuesHandler() func(ctx context.Context, req mcp
 
 		// Build request
 		request := &domain.GetIssuesRequest{
-			Owner: getStringArg(args, "owner"),
-			Repo:  getStringArg(args, "repo"),
-			State: getStringArg(args, "state"),
+			Owner:   getStringArg(args, "owner"),
+			Repo:    getStringArg(args, "repo"),
+			State:   getStringArg(args, "state"),
+			Fields:  getStringListArg(args, "fields"),
+			PerPage: int(getIntArg(args, "per_page")),
+			Cursor:  getStringArg(args, "cursor"),
+		}
+		if getBoolArg(args, "include_projects") {
+			request.Fields = append(request.Fields, domain.IssueFieldProjects)
 		}
 
+		budget := outputBudget(f.budgets, "get_issues", args)
+		if err := validateBudget(budget); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching issues", err), nil
+		}
+
+		// A budget cursor resumes inside a page that did not fit last time
+		pageCursor, offset, err := decodeContinuation(request.Cursor)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching issues", err), nil
+		}
+		request.Cursor = pageCursor
+
 		// Execute business logic
 		response, err := f.issueService.GetIssues(request)
 		if err != nil {
 			return mcp.NewToolResultErrorFromErr("Error fetching issues", err), nil
 		}
 
-		// Format response for MCP
-		formattedIssues := f.issueService.FormatIssuesForMCP(response.Issues)
-		
-		var contents []mcp.Content
-		for _, issue := range formattedIssues {
-			contents = append(contents, mcp.NewTextContent(issue))
+		issues := response.Issues[min(offset, len(response.Issues)):]
+		showBodies := containsString(request.Fields, domain.IssueFieldBody)
+		for i := range issues {
+			if !showBodies {
+				issues[i].Body = ""
+			}
+			truncateIssueBodies(&issues[i], budget.MaxBodyBytes())
+		}
+
+		// Format response for MCP, keeping within the output budget
+		formattedIssues := f.issueService.FormatIssuesForMCP(issues)
+		shown := len(formattedIssues)
+		if maxBytes := budget.MaxBytes(); maxBytes > 0 {
+			shown = fitBudget(formattedIssues, max(maxBytes-summaryReserve, 1))
 		}
+		contents := groupContents(issues[:shown], formattedIssues[:shown], budget.GroupBy)
 
 		// Add summary information
-		summary := mcp.NewTextContent(fmt.Sprintf("\nFound %d issues in %s/%s", response.Count, request.Owner, request.Repo))
+		summaryText := fmt.Sprintf("\nFound %d issues in %s/%s", len(issues), request.Owner, request.Repo)
+		if response.Truncated {
+			summaryText += " (results capped by policy)"
+		}
+		if shown < len(issues) {
+			summaryText += "\n" + overflowSummary(issues[shown:])
+			summaryText += fmt.Sprintf("\nShowing %d; call again with cursor %q for the rest", shown, encodeContinuation(pageCursor, offset+shown))
+		} else if response.NextCursor != "" {
+			summaryText += fmt.Sprintf("\nMore issues available, call again with cursor %q", response.NextCursor)
+		}
+		summary := mcp.NewTextContent(summaryText)
 		contents = append(contents, summary)
 
 		return &mcp.CallToolResult{Content: contents}, nil
 	}
 }
 
+// CreateGetIssueTool creates the tool for fetching a single issue
+func (f *ToolFactory) CreateGetIssueTool() mcp.Tool {
+	return mcp.NewTool("get_issue",
+		mcp.WithDescription("Fetches a single GitHub issue, optionally with comments, linked pull requests and project fields"),
+		mcp.WithString("owner", mcp.Description("Repository owner (organization or user); defaults to the profile's default owner")),
+		mcp.WithString("repo", mcp.Required(), mcp.Description("Repository name, owner/repo pair or configured alias")),
+		mcp.WithNumber("number", mcp.Required(), mcp.Description("Issue number")),
+		mcp.WithString("fields", mcp.Description("Comma-separated extra fields: body, assignees, comments, linked_prs, projects (default: body)")),
+		mcp.WithNumber("max_output_tokens", mcp.Description("Token budget of the whole result")),
+		mcp.WithNumber("max_body_tokens", mcp.Description("Token budget of the body and of each comment; longer ones are truncated")),
+	)
+}
+
+// CreateGetIssueHandler creates the handler for the get_issue tool
+func (f *ToolFactory) CreateGetIssueHandler() func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return mcp.NewToolResultError("Unable to read request arguments"), nil
+		}
+
+		request := &domain.GetIssueRequest{
+			Owner:  getStringArg(args, "owner"),
+			Repo:   getStringArg(args, "repo"),
+			Number: int(getIntArg(args, "number")),
+			Fields: getStringListArg(args, "fields"),
+		}
+		if len(request.Fields) == 0 {
+			request.Fields = []string{domain.IssueFieldBody}
+		}
+
+		budget := outputBudget(f.budgets, "get_issue", args)
+		if err := validateBudget(budget); err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching issue", err), nil
+		}
+
+		issue, err := f.issueService.GetIssue(request)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("Error fetching issue", err), nil
+		}
+		truncateIssueBodies(issue, budget.MaxBodyBytes())
+
+		return mcp.NewToolResultText(f.issueService.FormatIssueDetailsForMCP(issue)), nil
+	}
+}
+
 // getStringArg retrieves a string argument from the arguments map
 func getStringArg(args map[string]interface{}, key string) string {
 	if value, ok := args[key].(string); ok {
@@ -77,3 +248,96 @@ func getStringArg(args map[string]interface{}, key string) string {
 	}
 	return ""
 }
+
+// getIntArg retrieves an integer argument from the arguments map.
+// JSON numbers arrive as float64; numeric strings are accepted as well.
+func getIntArg(args map[string]interface{}, key string) int64 {
+	switch value := args[key].(type) {
+	case float64:
+		return int64(value)
+	case int:
+		return int64(value)
+	case int64:
+		return value
+	case string:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+// getBoolArg retrieves a boolean argument from the arguments map
+func getBoolArg(args map[string]interface{}, key string) bool {
+	switch value := args[key].(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	}
+	return false
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getStringListArg retrieves a list argument given either as an array of
+// strings or as a comma-separated string
+func getStringListArg(args map[string]interface{}, key string) []string {
+	var values []string
+
+	switch value := args[key].(type) {
+	case string:
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+
+	return values
+}
+
+// getIntListArg retrieves a list of integers given either as an array of
+// numbers or as a comma-separated string. Entries that are not integers are
+// returned as -1 so validation can reject them.
+func getIntListArg(args map[string]interface{}, key string) []int {
+	var values []int
+
+	switch value := args[key].(type) {
+	case string:
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part), "#")); part != "" {
+				n, err := strconv.Atoi(part)
+				if err != nil {
+					n = -1
+				}
+				values = append(values, n)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			n := getIntArg(map[string]interface{}{key: item}, key)
+			if n <= 0 {
+				n = -1
+			}
+			values = append(values, int(n))
+		}
+	}
+
+	return values
+}
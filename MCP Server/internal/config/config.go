@@ -1,8 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"mcp-server/internal/domain"
 )
 
 // Config represents the application configuration
@@ -11,24 +18,274 @@ type Config struct {
 	ServerVersion string
 	GitHubToken   string
 	LogLevel      string
+
+	// Profile is the name of the profile loaded from the config file, if any
+	Profile string
+	// BaseURL is the GitHub API base URL
+	BaseURL string
+	// DefaultOwner is used when a tool call omits the repository owner
+	DefaultOwner string
+	// RepoAliases maps short names to "owner/repo" (e.g. backend -> acme/api)
+	RepoAliases map[string]string
+	// AllowRepos and DenyRepos are "owner/repo" glob patterns
+	AllowRepos []string
+	DenyRepos  []string
+	// EnabledTools lists the tools to register; empty means all tools
+	EnabledTools []string
+	// DisabledTools lists tools that are never registered, even if enabled
+	DisabledTools []string
+	// PolicyFile is the path of the hot-reloaded access policy file
+	PolicyFile string
+	// ExportDir is where export_issues writes files; empty disables file output
+	ExportDir string
+	// ReadOnly disables every tool that modifies GitHub
+	ReadOnly bool
+	// Output is the default size budget of tool results
+	Output domain.OutputBudget
+	// ToolOutput overrides the output budget of individual tools
+	ToolOutput map[string]domain.OutputBudget
+
+	// tokenErr is why the profile token could not be resolved; GITHUB_TOKEN
+	// clears it because it overrides the profile token anyway
+	tokenErr error
+	// envErrs are the environment values that could not be parsed
+	envErrs []error
 }
 
-// NewConfig creates a new configuration
+// Default values
+const (
+	DefaultServerName    = "GitHubIssues"
+	DefaultServerVersion = "0.0.1"
+	DefaultLogLevel      = "info"
+	DefaultBaseURL       = "https://api.github.com"
+
+	DefaultMaxOutputTokens = 8000
+	DefaultMaxBodyTokens   = 500
+)
+
+// NewConfig creates a new configuration from environment variables only
 func NewConfig() *Config {
+	cfg := defaultConfig()
+	cfg.applyEnv()
+	return cfg
+}
+
+// Load creates a configuration from the config file at path using the given
+// profile, then applies environment variable overrides.
+// An empty path falls back to MCP_CONFIG, and an empty profile falls back to
+// MCP_PROFILE and then to the file's default profile.
+func Load(path, profile string) (*Config, error) {
+	cfg := defaultConfig()
+
+	if path == "" {
+		path = os.Getenv("MCP_CONFIG")
+	}
+	if profile == "" {
+		profile = os.Getenv("MCP_PROFILE")
+	}
+
+	if path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		name, p, err := file.selectProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.applyProfile(name, p)
+	}
+
+	cfg.applyEnv()
+	return cfg, nil
+}
+
+// defaultConfig returns the configuration used when nothing is overridden
+func defaultConfig() *Config {
 	return &Config{
-		ServerName:    getEnvOrDefault("MCP_SERVER_NAME", "GitHubIssues"),
-		ServerVersion: getEnvOrDefault("MCP_SERVER_VERSION", "0.0.1"),
-		GitHubToken:   os.Getenv("GITHUB_TOKEN"),
-		LogLevel:      getEnvOrDefault("LOG_LEVEL", "info"),
+		ServerName:    DefaultServerName,
+		ServerVersion: DefaultServerVersion,
+		LogLevel:      DefaultLogLevel,
+		BaseURL:       DefaultBaseURL,
+		RepoAliases:   map[string]string{},
+		Output: domain.OutputBudget{
+			MaxTokens:     DefaultMaxOutputTokens,
+			MaxBodyTokens: DefaultMaxBodyTokens,
+		},
+		ToolOutput: map[string]domain.OutputBudget{},
+	}
+}
+
+// applyEnv overrides configuration values with environment variables.
+// Values that cannot be parsed are reported by Validate.
+func (c *Config) applyEnv() {
+	c.ServerName = getEnvOrDefault("MCP_SERVER_NAME", c.ServerName)
+	c.ServerVersion = getEnvOrDefault("MCP_SERVER_VERSION", c.ServerVersion)
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		c.GitHubToken = token
+		c.tokenErr = nil
+	}
+	c.LogLevel = getEnvOrDefault("LOG_LEVEL", c.LogLevel)
+	c.BaseURL = getEnvOrDefault("GITHUB_API_URL", c.BaseURL)
+	c.DefaultOwner = getEnvOrDefault("MCP_DEFAULT_OWNER", c.DefaultOwner)
+	c.PolicyFile = getEnvOrDefault("MCP_POLICY_FILE", c.PolicyFile)
+	c.ExportDir = getEnvOrDefault("MCP_EXPORT_DIR", c.ExportDir)
+	if value := os.Getenv("MCP_DISABLED_TOOLS"); value != "" {
+		c.DisabledTools = splitList(value)
+	}
+	if value := os.Getenv("MCP_READ_ONLY"); value != "" {
+		readOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			c.envErrs = append(c.envErrs, fmt.Errorf("MCP_READ_ONLY %q must be true or false", value))
+		} else {
+			c.ReadOnly = readOnly
+		}
+	}
+	if value := os.Getenv("MCP_MAX_OUTPUT_TOKENS"); value != "" {
+		maxTokens, err := strconv.Atoi(value)
+		if err != nil {
+			c.envErrs = append(c.envErrs, fmt.Errorf("MCP_MAX_OUTPUT_TOKENS %q must be a number", value))
+		} else {
+			c.Output.MaxTokens = maxTokens
+		}
 	}
 }
 
-// Validate validates the configuration
+// Validate validates the configuration and reports every problem found
 func (c *Config) Validate() error {
-	if c.GitHubToken == "" {
-		return fmt.Errorf("GITHUB_TOKEN is required")
+	problems := append([]error{}, c.envErrs...)
+
+	switch {
+	case c.tokenErr != nil:
+		problems = append(problems, c.tokenErr)
+	case c.GitHubToken == "":
+		problems = append(problems, fmt.Errorf("GITHUB_TOKEN is required"))
+	}
+
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Errorf("base_url %q must be an absolute http(s) URL", c.BaseURL))
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Errorf("log level %q must be debug, info, warn or error", c.LogLevel))
+	}
+
+	for alias, target := range c.RepoAliases {
+		if strings.Contains(alias, "/") {
+			problems = append(problems, fmt.Errorf("repo alias %q must not contain '/'", alias))
+		}
+		if _, _, ok := splitRepo(target); !ok {
+			problems = append(problems, fmt.Errorf("repo alias %q: target %q must be in owner/repo form", alias, target))
+		}
+	}
+
+	problems = append(problems, validatePatterns("allow", c.AllowRepos)...)
+	problems = append(problems, validatePatterns("deny", c.DenyRepos)...)
+
+	for _, tool := range c.EnabledTools {
+		if strings.TrimSpace(tool) == "" {
+			problems = append(problems, fmt.Errorf("enabled tools must not contain empty names"))
+			break
+		}
+	}
+	for _, tool := range c.DisabledTools {
+		if strings.TrimSpace(tool) == "" {
+			problems = append(problems, fmt.Errorf("disabled tools must not contain empty names"))
+			break
+		}
+	}
+
+	problems = append(problems, validateOutputBudget("output", c.Output)...)
+	for tool, budget := range c.ToolOutput {
+		problems = append(problems, validateOutputBudget("output for "+tool, budget)...)
+	}
+
+	return errors.Join(problems...)
+}
+
+// ToolEnabled reports whether the named tool should be registered
+func (c *Config) ToolEnabled(name string) bool {
+	for _, tool := range c.DisabledTools {
+		if tool == name {
+			return false
+		}
+	}
+	if len(c.EnabledTools) == 0 {
+		return true
+	}
+	for _, tool := range c.EnabledTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OutputBudget returns the output budget of the named tool: the defaults
+// with the tool's overrides applied
+func (c *Config) OutputBudget(tool string) domain.OutputBudget {
+	return c.Output.Merge(c.ToolOutput[tool])
+}
+
+// ResolveRepository expands repository aliases and applies the default owner.
+// The repo may be an alias, a bare name or an "owner/repo" pair.
+func (c *Config) ResolveRepository(owner, repo string) (string, string) {
+	if target, ok := c.RepoAliases[repo]; ok && owner == "" {
+		if o, r, ok := splitRepo(target); ok {
+			return o, r
+		}
 	}
-	return nil
+
+	if owner == "" {
+		if o, r, ok := splitRepo(repo); ok {
+			return o, r
+		}
+		owner = c.DefaultOwner
+	}
+
+	return owner, repo
+}
+
+// validatePatterns checks that every repository glob is well formed
+func validatePatterns(kind string, patterns []string) []error {
+	var problems []error
+	for _, pattern := range patterns {
+		if _, _, ok := splitRepo(pattern); !ok {
+			problems = append(problems, fmt.Errorf("%s pattern %q must be in owner/repo form", kind, pattern))
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			problems = append(problems, fmt.Errorf("%s pattern %q: %v", kind, pattern, err))
+		}
+	}
+	return problems
+}
+
+// validateOutputBudget checks the limits and grouping of an output budget
+func validateOutputBudget(kind string, budget domain.OutputBudget) []error {
+	var problems []error
+	if budget.MaxTokens < 0 || budget.MaxBodyTokens < 0 {
+		problems = append(problems, fmt.Errorf("%s: token limits must not be negative", kind))
+	}
+	switch budget.GroupBy {
+	case "", domain.GroupByNone, domain.GroupByState, domain.GroupByLabel:
+	default:
+		problems = append(problems, fmt.Errorf("%s: group_by %q must be none, state or label", kind, budget.GroupBy))
+	}
+	return problems
+}
+
+// splitRepo splits an "owner/repo" string
+func splitRepo(fullName string) (string, string, bool) {
+	owner, repo, found := strings.Cut(fullName, "/")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
 }
 
 // getEnvOrDefault returns an environment variable or the default value
@@ -38,3 +295,14 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// splitList splits a comma-separated environment value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mcp-server/internal/domain"
+)
+
+const testConfigFile = `
+default_profile: work
+profiles:
+  work:
+    token:
+      value: work-token
+    default_owner: acme
+    repo_aliases:
+      backend: acme/api
+    repositories:
+      allow: ["acme/*"]
+  personal:
+    token:
+      env: MCP_TEST_PERSONAL_TOKEN
+    default_owner: alice
+    log_level: debug
+`
+
+// clearEnv unsets the variables applyEnv reads so the host environment
+// does not leak into the tests
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"MCP_CONFIG", "MCP_PROFILE", "MCP_SERVER_NAME", "MCP_SERVER_VERSION", "GITHUB_TOKEN",
+		"LOG_LEVEL", "GITHUB_API_URL", "MCP_DEFAULT_OWNER", "MCP_POLICY_FILE", "MCP_EXPORT_DIR",
+		"MCP_DISABLED_TOOLS", "MCP_READ_ONLY", "MCP_MAX_OUTPUT_TOKENS",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestLoadSelectsProfile(t *testing.T) {
+	clearEnv(t)
+	path := writeConfig(t, testConfigFile)
+	t.Setenv("MCP_TEST_PERSONAL_TOKEN", "personal-token")
+
+	testCases := []struct {
+		name       string
+		profile    string
+		envProfile string
+		expected   string
+		token      string
+		owner      string
+	}{
+		{"default profile", "", "", "work", "work-token", "acme"},
+		{"explicit profile", "personal", "", "personal", "personal-token", "alice"},
+		{"MCP_PROFILE", "", "personal", "personal", "personal-token", "alice"},
+		{"flag wins over MCP_PROFILE", "work", "personal", "work", "work-token", "acme"},
+	}
+
+	for _, tc := range testCases {
+		t.Setenv("MCP_PROFILE", tc.envProfile)
+		cfg, err := Load(path, tc.profile)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if cfg.Profile != tc.expected || cfg.GitHubToken != tc.token || cfg.DefaultOwner != tc.owner {
+			t.Errorf("%s: got profile %q token %q owner %q, want %q %q %q",
+				tc.name, cfg.Profile, cfg.GitHubToken, cfg.DefaultOwner, tc.expected, tc.token, tc.owner)
+		}
+	}
+}
+
+func TestLoadFailsOnUnknownProfile(t *testing.T) {
+	clearEnv(t)
+	_, err := Load(writeConfig(t, testConfigFile), "missing")
+	if err == nil || !strings.Contains(err.Error(), `profile "missing" not found (available: personal, work)`) {
+		t.Fatalf("got %v, want profile not found error", err)
+	}
+}
+
+func TestValidateReportsUnresolvedTokenWithOtherProblems(t *testing.T) {
+	clearEnv(t)
+	// t.Setenv restores the variable when the test ends
+	t.Setenv("MCP_TEST_PERSONAL_TOKEN", "")
+	os.Unsetenv("MCP_TEST_PERSONAL_TOKEN")
+	t.Setenv("MCP_READ_ONLY", "yes")
+	t.Setenv("MCP_MAX_OUTPUT_TOKENS", "lots")
+	t.Setenv("LOG_LEVEL", "trace")
+
+	cfg, err := Load(writeConfig(t, testConfigFile), "personal")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	err = cfg.Validate()
+	for _, problem := range []string{
+		"MCP_TEST_PERSONAL_TOKEN is not set",
+		`MCP_READ_ONLY "yes" must be true or false`,
+		`MCP_MAX_OUTPUT_TOKENS "lots" must be a number`,
+		`log level "trace"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), problem) {
+			t.Errorf("got %v, want it to report %q", err, problem)
+		}
+	}
+	if err != nil && strings.Contains(err.Error(), "GITHUB_TOKEN is required") {
+		t.Errorf("got %v, want the unresolved token reported once", err)
+	}
+}
+
+func TestGitHubTokenOverridesUnresolvedProfileToken(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("MCP_TEST_PERSONAL_TOKEN", "")
+	os.Unsetenv("MCP_TEST_PERSONAL_TOKEN")
+	t.Setenv("GITHUB_TOKEN", "env-token")
+
+	cfg, err := Load(writeConfig(t, testConfigFile), "personal")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := cfg.Validate(); err != nil || cfg.GitHubToken != "env-token" {
+		t.Fatalf("got token %q and %v, want env-token and no error", cfg.GitHubToken, err)
+	}
+}
+
+func TestTokenFileExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "token"), []byte("file-token\n"), 0o600); err != nil {
+		t.Fatalf("writing token: %v", err)
+	}
+
+	token, err := TokenSource{File: "~/token"}.Resolve()
+	if err != nil || token != "file-token" {
+		t.Fatalf("got %q, %v, want file-token", token, err)
+	}
+}
+
+func TestLoadAppliesEnvOverrides(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("MCP_CONFIG", writeConfig(t, testConfigFile))
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("MCP_DEFAULT_OWNER", "globex")
+	t.Setenv("MCP_READ_ONLY", "true")
+	t.Setenv("MCP_MAX_OUTPUT_TOKENS", "1000")
+	t.Setenv("MCP_DISABLED_TOOLS", "search_code, ,get_issue")
+
+	cfg, err := Load("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Profile != "work" || cfg.GitHubToken != "env-token" || cfg.DefaultOwner != "globex" {
+		t.Errorf("got profile %q token %q owner %q", cfg.Profile, cfg.GitHubToken, cfg.DefaultOwner)
+	}
+	if !cfg.ReadOnly || cfg.Output.MaxTokens != 1000 || cfg.Output.MaxBodyTokens != DefaultMaxBodyTokens {
+		t.Errorf("got read only %v output %+v", cfg.ReadOnly, cfg.Output)
+	}
+	if strings.Join(cfg.DisabledTools, ",") != "search_code,get_issue" {
+		t.Errorf("got disabled tools %v", cfg.DisabledTools)
+	}
+	if owner, repo := cfg.ResolveRepository("", "backend"); owner != "acme" || repo != "api" {
+		t.Errorf("alias resolved to %s/%s", owner, repo)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := defaultConfig()
+		cfg.GitHubToken = "token"
+		return cfg
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func(cfg *Config)
+		problems []string
+	}{
+		{"valid", func(cfg *Config) {}, nil},
+		{"missing token", func(cfg *Config) { cfg.GitHubToken = "" }, []string{"GITHUB_TOKEN is required"}},
+		{"relative base url", func(cfg *Config) { cfg.BaseURL = "api.github.com" }, []string{"must be an absolute http(s) URL"}},
+		{"log level", func(cfg *Config) { cfg.LogLevel = "trace" }, []string{`log level "trace"`}},
+		{"alias", func(cfg *Config) { cfg.RepoAliases["a/b"] = "api" }, []string{`repo alias "a/b" must not contain '/'`, `target "api" must be in owner/repo form`}},
+		{"patterns", func(cfg *Config) { cfg.AllowRepos = []string{"acme"}; cfg.DenyRepos = []string{"acme/["} }, []string{`allow pattern "acme"`, `deny pattern "acme/["`}},
+		{"empty tool", func(cfg *Config) { cfg.EnabledTools = []string{" "} }, []string{"enabled tools must not contain empty names"}},
+		{"output", func(cfg *Config) {
+			cfg.ToolOutput["get_issues"] = domain.OutputBudget{MaxTokens: -1, GroupBy: "author"}
+		}, []string{"output for get_issues: token limits", `group_by "author"`}},
+	}
+
+	for _, tc := range testCases {
+		cfg := valid()
+		tc.modify(cfg)
+		err := cfg.Validate()
+		if len(tc.problems) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+			continue
+		}
+		for _, problem := range tc.problems {
+			if !strings.Contains(err.Error(), problem) {
+				t.Errorf("%s: error %q does not report %q", tc.name, err, problem)
+			}
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mcp-server/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File represents the YAML configuration file
+type File struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// Profile holds the settings of a named configuration profile
+type Profile struct {
+	ServerName    string            `yaml:"server_name"`
+	ServerVersion string            `yaml:"server_version"`
+	LogLevel      string            `yaml:"log_level"`
+	Token         TokenSource       `yaml:"token"`
+	BaseURL       string            `yaml:"base_url"`
+	DefaultOwner  string            `yaml:"default_owner"`
+	RepoAliases   map[string]string `yaml:"repo_aliases"`
+	Repositories  struct {
+		Allow []string `yaml:"allow"`
+		Deny  []string `yaml:"deny"`
+	} `yaml:"repositories"`
+	Tools struct {
+		Enabled  []string `yaml:"enabled"`
+		Disabled []string `yaml:"disabled"`
+	} `yaml:"tools"`
+	PolicyFile string        `yaml:"policy_file"`
+	ExportDir  string        `yaml:"export_dir"`
+	ReadOnly   bool          `yaml:"read_only"`
+	Output     OutputProfile `yaml:"output"`
+}
+
+// OutputProfile holds the default output budget and per-tool overrides
+type OutputProfile struct {
+	OutputLimits `yaml:",inline"`
+	Tools        map[string]OutputLimits `yaml:"tools"`
+}
+
+// OutputLimits is the YAML form of an output budget
+type OutputLimits struct {
+	MaxTokens     int    `yaml:"max_tokens"`
+	MaxBodyTokens int    `yaml:"max_body_tokens"`
+	GroupBy       string `yaml:"group_by"`
+}
+
+// budget converts the limits to the domain type
+func (l OutputLimits) budget() domain.OutputBudget {
+	return domain.OutputBudget{
+		MaxTokens:     l.MaxTokens,
+		MaxBodyTokens: l.MaxBodyTokens,
+		GroupBy:       l.GroupBy,
+	}
+}
+
+// TokenSource describes where the GitHub token is read from.
+// Exactly one of Env, File or Value should be set.
+type TokenSource struct {
+	Env   string `yaml:"env"`
+	File  string `yaml:"file"`
+	Value string `yaml:"value"`
+}
+
+// Resolve returns the token referenced by the source
+func (t TokenSource) Resolve() (string, error) {
+	set := 0
+	for _, v := range []string{t.Env, t.File, t.Value} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", fmt.Errorf("token: only one of env, file or value may be set")
+	}
+
+	switch {
+	case t.Env != "":
+		token, ok := os.LookupEnv(t.Env)
+		if !ok {
+			return "", fmt.Errorf("token: environment variable %s is not set", t.Env)
+		}
+		return token, nil
+	case t.File != "":
+		path := t.File
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("token: expanding %s: %w", t.File, err)
+			}
+			path = filepath.Join(home, rest)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("token: reading file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return t.Value, nil
+	}
+}
+
+// readFile reads and decodes the configuration file at path
+func readFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var file File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return &file, nil
+}
+
+// selectProfile returns the requested profile, falling back to the default one
+func (f *File) selectProfile(name string) (string, Profile, error) {
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" && len(f.Profiles) == 1 {
+		for only := range f.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return "", Profile{}, fmt.Errorf("profile %q not found (available: %s)", name, strings.Join(f.profileNames(), ", "))
+	}
+
+	return name, profile, nil
+}
+
+// profileNames returns the sorted profile names
+func (f *File) profileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile copies the non-empty profile values into the configuration.
+// A token that cannot be resolved is recorded and reported by Validate.
+func (c *Config) applyProfile(name string, p Profile) {
+	token, err := p.Token.Resolve()
+	if err != nil {
+		c.tokenErr = fmt.Errorf("profile %q: %w", name, err)
+	}
+
+	c.Profile = name
+	c.GitHubToken = valueOrDefault(token, c.GitHubToken)
+	c.ServerName = valueOrDefault(p.ServerName, c.ServerName)
+	c.ServerVersion = valueOrDefault(p.ServerVersion, c.ServerVersion)
+	c.LogLevel = valueOrDefault(p.LogLevel, c.LogLevel)
+	c.BaseURL = valueOrDefault(p.BaseURL, c.BaseURL)
+	c.DefaultOwner = valueOrDefault(p.DefaultOwner, c.DefaultOwner)
+	c.AllowRepos = p.Repositories.Allow
+	c.DenyRepos = p.Repositories.Deny
+	c.EnabledTools = p.Tools.Enabled
+	c.DisabledTools = p.Tools.Disabled
+	c.PolicyFile = valueOrDefault(p.PolicyFile, c.PolicyFile)
+	c.ExportDir = valueOrDefault(p.ExportDir, c.ExportDir)
+	c.ReadOnly = p.ReadOnly
+	for alias, target := range p.RepoAliases {
+		c.RepoAliases[alias] = target
+	}
+	c.Output = c.Output.Merge(p.Output.budget())
+	for tool, limits := range p.Output.Tools {
+		c.ToolOutput[tool] = limits.budget()
+	}
+}
+
+// valueOrDefault returns value unless it is empty
+func valueOrDefault(value, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+	return defaultValue
+}
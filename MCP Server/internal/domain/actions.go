@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// WorkflowRun represents a GitHub Actions workflow run
+type WorkflowRun struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	WorkflowID int64     `json:"workflow_id"`
+	RunNumber  int       `json:"run_number"`
+	RunAttempt int       `json:"run_attempt"`
+	Event      string    `json:"event"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	HTMLURL    string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Actor      User      `json:"actor"`
+}
+
+// WorkflowJob represents a job of a workflow run
+type WorkflowJob struct {
+	ID          int64          `json:"id"`
+	RunID       int64          `json:"run_id"`
+	Name        string         `json:"name"`
+	Status      string         `json:"status"`
+	Conclusion  string         `json:"conclusion"`
+	HTMLURL     string         `json:"html_url"`
+	StartedAt   *time.Time     `json:"started_at"`
+	CompletedAt *time.Time     `json:"completed_at"`
+	Steps       []WorkflowStep `json:"steps"`
+}
+
+// WorkflowStep represents a step of a workflow job
+type WorkflowStep struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// ListWorkflowRunsRequest defines parameters for listing workflow runs
+type ListWorkflowRunsRequest struct {
+	Owner   string `json:"owner"`
+	Repo    string `json:"repo"`
+	Branch  string `json:"branch,omitempty"`
+	Event   string `json:"event,omitempty"`
+	Status  string `json:"status,omitempty"` // queued, in_progress, completed, success, failure, ...
+	PerPage int    `json:"per_page,omitempty"`
+}
+
+// ListWorkflowRunsResponse contains the workflow runs response
+type ListWorkflowRunsResponse struct {
+	Runs       []WorkflowRun `json:"workflow_runs"`
+	TotalCount int           `json:"total_count"`
+	Count      int           `json:"count"`
+}
+
+// GetWorkflowRunRequest identifies a single workflow run
+type GetWorkflowRunRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	RunID int64  `json:"run_id"`
+}
+
+// GetWorkflowRunResponse contains a workflow run and its jobs
+type GetWorkflowRunResponse struct {
+	Run  WorkflowRun   `json:"run"`
+	Jobs []WorkflowJob `json:"jobs"`
+}
+
+// GetJobLogsRequest defines parameters for downloading job logs
+type GetJobLogsRequest struct {
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	JobID     int64  `json:"job_id"`
+	MaxTokens int    `json:"max_tokens,omitempty"`
+}
+
+// GetJobLogsResponse contains the (possibly tail-truncated) job logs
+type GetJobLogsResponse struct {
+	JobID      int64  `json:"job_id"`
+	Logs       string `json:"logs"`
+	TotalBytes int    `json:"total_bytes"`
+	Truncated  bool   `json:"truncated"`
+}
+
+// RerunFailedJobsRequest identifies the workflow run to re-run
+type RerunFailedJobsRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	RunID int64  `json:"run_id"`
+}
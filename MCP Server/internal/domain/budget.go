@@ -0,0 +1,46 @@
+package domain
+
+// BytesPerToken approximates how many bytes of text make up one model token
+const BytesPerToken = 4
+
+// Ways of grouping list results in tool output
+const (
+	GroupByNone  = "none"
+	GroupByState = "state"
+	GroupByLabel = "label"
+)
+
+// OutputBudget limits the size of a tool result so it fits the client's
+// context window. Zero values mean "no limit" (or no grouping).
+type OutputBudget struct {
+	// MaxTokens caps the whole result
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// MaxBodyTokens caps each issue or comment body
+	MaxBodyTokens int `json:"max_body_tokens,omitempty"`
+	// GroupBy groups list results by state or label
+	GroupBy string `json:"group_by,omitempty"`
+}
+
+// MaxBytes returns the result cap in bytes, 0 if unlimited
+func (b OutputBudget) MaxBytes() int {
+	return b.MaxTokens * BytesPerToken
+}
+
+// MaxBodyBytes returns the body cap in bytes, 0 if unlimited
+func (b OutputBudget) MaxBodyBytes() int {
+	return b.MaxBodyTokens * BytesPerToken
+}
+
+// Merge returns b with the non-zero values of override applied
+func (b OutputBudget) Merge(override OutputBudget) OutputBudget {
+	if override.MaxTokens != 0 {
+		b.MaxTokens = override.MaxTokens
+	}
+	if override.MaxBodyTokens != 0 {
+		b.MaxBodyTokens = override.MaxBodyTokens
+	}
+	if override.GroupBy != "" {
+		b.GroupBy = override.GroupBy
+	}
+	return b
+}
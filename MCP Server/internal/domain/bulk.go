@@ -0,0 +1,93 @@
+package domain
+
+// Bulk issue actions
+const (
+	BulkActionLabel     = "label"     // add and/or remove labels
+	BulkActionClose     = "close"     // close with an optional reason
+	BulkActionReassign  = "reassign"  // replace the assignees
+	BulkActionMilestone = "milestone" // set or clear the milestone
+)
+
+// Per-issue outcomes of a bulk update
+const (
+	BulkStatusPlanned   = "planned"   // dry run: the issue would be changed
+	BulkStatusUnchanged = "unchanged" // the issue already matches the action
+	BulkStatusUpdated   = "updated"
+	BulkStatusFailed    = "failed"
+	BulkStatusSkipped   = "skipped" // not attempted, e.g. after hitting the rate limit
+)
+
+// BulkUpdateIssuesRequest defines a bulk update of the issues of one repository,
+// selected either by number or by a search query
+type BulkUpdateIssuesRequest struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Issues []int  `json:"issues,omitempty"`
+	Query  string `json:"query,omitempty"`
+
+	Action       string   `json:"action"`
+	AddLabels    []string `json:"add_labels,omitempty"`
+	RemoveLabels []string `json:"remove_labels,omitempty"`
+	StateReason  string   `json:"state_reason,omitempty"` // completed, not_planned
+	Assignees    []string `json:"assignees,omitempty"`
+	Milestone    *int     `json:"milestone,omitempty"` // 0 clears the milestone
+
+	DryRun      bool `json:"dry_run"`
+	Concurrency int  `json:"concurrency,omitempty"`
+}
+
+// BulkIssueResult is the planned change and outcome for one issue
+type BulkIssueResult struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Change string `json:"change"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkUpdateIssuesResponse holds the per-issue report of a bulk update
+type BulkUpdateIssuesResponse struct {
+	Repository string            `json:"repository"`
+	Action     string            `json:"action"`
+	DryRun     bool              `json:"dry_run"`
+	Results    []BulkIssueResult `json:"results"`
+	// Warning notes a rate limit too low for the planned updates
+	Warning string `json:"warning,omitempty"`
+}
+
+// Count returns how many results have the given status
+func (r *BulkUpdateIssuesResponse) Count(status string) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// UpdateIssueRequest defines the fields of an issue to change. Empty State and
+// nil slices and pointers are left unchanged; a pointer to 0 clears the milestone.
+type UpdateIssueRequest struct {
+	Owner       string   `json:"owner"`
+	Repo        string   `json:"repo"`
+	Number      int      `json:"number"`
+	State       string   `json:"state,omitempty"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Assignees   []string `json:"assignees,omitempty"`
+	Milestone   *int     `json:"milestone,omitempty"`
+}
+
+// SearchIssuesRequest defines a GitHub issue search
+type SearchIssuesRequest struct {
+	Query   string `json:"query"`
+	PerPage int    `json:"per_page,omitempty"`
+	Page    int    `json:"page,omitempty"`
+}
+
+// SearchIssuesResponse holds a page of the issues matching a search
+type SearchIssuesResponse struct {
+	TotalCount int     `json:"total_count"`
+	Issues     []Issue `json:"items"`
+	NextPage   int     `json:"-"` // 0 on the last page
+}
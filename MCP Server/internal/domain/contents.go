@@ -0,0 +1,93 @@
+package domain
+
+// ContentEntry represents a file or directory in a repository
+type ContentEntry struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Type    string `json:"type"` // file, dir, symlink, submodule
+	Size    int    `json:"size"`
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+}
+
+// GetFileContentsRequest defines parameters for reading a file
+type GetFileContentsRequest struct {
+	Owner    string `json:"owner"`
+	Repo     string `json:"repo"`
+	Path     string `json:"path"`
+	Ref      string `json:"ref,omitempty"`
+	MaxBytes int    `json:"max_bytes,omitempty"`
+}
+
+// FileContents contains a decoded file
+type FileContents struct {
+	Entry     ContentEntry `json:"entry"`
+	Content   string       `json:"content,omitempty"`
+	Binary    bool         `json:"binary"`
+	Truncated bool         `json:"truncated"`
+}
+
+// ListDirectoryRequest defines parameters for listing a directory
+type ListDirectoryRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Path  string `json:"path,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+}
+
+// ListDirectoryResponse contains the entries of a directory
+type ListDirectoryResponse struct {
+	Entries []ContentEntry `json:"entries"`
+	Count   int            `json:"count"`
+}
+
+// TreeEntry represents an entry of a git tree
+type TreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"` // blob, tree, commit
+	Mode string `json:"mode"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// GetRepoTreeRequest defines parameters for fetching a repository tree
+type GetRepoTreeRequest struct {
+	Owner      string `json:"owner"`
+	Repo       string `json:"repo"`
+	Ref        string `json:"ref,omitempty"`
+	Recursive  bool   `json:"recursive,omitempty"`
+	MaxEntries int    `json:"max_entries,omitempty"`
+}
+
+// RepoTree contains the entries of a git tree
+type RepoTree struct {
+	SHA       string      `json:"sha"`
+	Entries   []TreeEntry `json:"tree"`
+	Truncated bool        `json:"truncated"`
+}
+
+// SearchCodeRequest defines parameters for searching code
+type SearchCodeRequest struct {
+	Query   string `json:"query"`
+	Owner   string `json:"owner,omitempty"`
+	Repo    string `json:"repo,omitempty"`
+	PerPage int    `json:"per_page,omitempty"`
+}
+
+// CodeSearchResult represents a single code search hit
+type CodeSearchResult struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	SHA        string   `json:"sha"`
+	HTMLURL    string   `json:"html_url"`
+	Repository string   `json:"repository"` // owner/repo
+	Fragments  []string `json:"fragments,omitempty"`
+}
+
+// SearchCodeResponse contains the code search results
+type SearchCodeResponse struct {
+	Items      []CodeSearchResult `json:"items"`
+	TotalCount int                `json:"total_count"`
+	Incomplete bool               `json:"incomplete_results"`
+	Count      int                `json:"count"`
+}
@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// Kinds of change reported by the issue change feed
+const (
+	ChangeOpened     = "opened"
+	ChangeClosed     = "closed"
+	ChangeReopened   = "reopened"
+	ChangeLabeled    = "labeled"
+	ChangeUnlabeled  = "unlabeled"
+	ChangeAssigned   = "assigned"
+	ChangeUnassigned = "unassigned"
+	ChangeCommented  = "commented"
+)
+
+// ChangeTypes lists every kind of change in the feed
+var ChangeTypes = []string{
+	ChangeOpened, ChangeClosed, ChangeReopened, ChangeLabeled,
+	ChangeUnlabeled, ChangeAssigned, ChangeUnassigned, ChangeCommented,
+}
+
+// IssueEvent represents an entry of the repository issue events API
+type IssueEvent struct {
+	ID        int64     `json:"id"`
+	Event     string    `json:"event"`
+	Actor     User      `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+	Label     *Label    `json:"label,omitempty"`
+	Assignee  *User     `json:"assignee,omitempty"`
+	Issue     *Issue    `json:"issue,omitempty"`
+}
+
+// ListRepoPageRequest defines one page of a repository-wide issue listing
+// (events, comments or issues updated since a time)
+type ListRepoPageRequest struct {
+	Owner   string    `json:"owner"`
+	Repo    string    `json:"repo"`
+	Since   time.Time `json:"since,omitempty"` // comments and issues only
+	PerPage int       `json:"per_page,omitempty"`
+	Page    int       `json:"page,omitempty"`
+}
+
+// ListIssueEventsResponse holds a page of issue events, newest first
+type ListIssueEventsResponse struct {
+	Events   []IssueEvent `json:"events"`
+	NextPage int          `json:"next_page,omitempty"`
+}
+
+// ListIssueCommentsResponse holds a page of issue comments, oldest first
+type ListIssueCommentsResponse struct {
+	Comments []Comment `json:"comments"`
+	NextPage int       `json:"next_page,omitempty"`
+}
+
+// ListUpdatedIssuesResponse holds a page of the issues updated since a time,
+// oldest created first; pull requests are left out
+type ListUpdatedIssuesResponse struct {
+	Issues   []Issue `json:"issues"`
+	NextPage int     `json:"next_page,omitempty"`
+}
+
+// IssueChange is a normalized entry of the change feed
+type IssueChange struct {
+	// ID is unique across kinds, e.g. "event:123" or "comment:456"
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Issue      int       `json:"issue"`
+	IssueTitle string    `json:"issue_title,omitempty"`
+	Actor      string    `json:"actor"`
+	At         time.Time `json:"at"`
+	// Detail is the label, the assignee or the start of the comment
+	Detail string `json:"detail,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// GetRepoEventsRequest defines parameters for reading the change feed
+type GetRepoEventsRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	// Checkpoint is the token returned by the previous call; it wins over Since
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Since is a YYYY-MM-DD date or RFC 3339 time used without a checkpoint
+	Since string   `json:"since,omitempty"`
+	Types []string `json:"types,omitempty"`
+	Limit int      `json:"limit,omitempty"`
+}
+
+// GetRepoEventsResponse holds the changes since the checkpoint, oldest first
+type GetRepoEventsResponse struct {
+	Repository string        `json:"repository"`
+	Since      time.Time     `json:"since"`
+	Changes    []IssueChange `json:"changes"`
+	// Checkpoint resumes the feed after the last returned change
+	Checkpoint string `json:"checkpoint"`
+	// More is set when changes were left out because of the limit
+	More    bool   `json:"more,omitempty"`
+	Warning string `json:"warning,omitempty"`
+}
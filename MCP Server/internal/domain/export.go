@@ -0,0 +1,56 @@
+package domain
+
+import "strings"
+
+// Issue export formats
+const (
+	ExportFormatCSV      = "csv"
+	ExportFormatJSONL    = "jsonl"
+	ExportFormatMarkdown = "markdown"
+)
+
+// Groupings of the Markdown report
+const (
+	ExportGroupByLabel     = "label"
+	ExportGroupByMilestone = "milestone"
+	ExportGroupByNone      = "none"
+)
+
+// MaxExportIssues is the most issues an export returns; the search API
+// does not go further
+const MaxExportIssues = 1000
+
+// ExportIssuesRequest defines the filters and format of an issue export
+type ExportIssuesRequest struct {
+	Owner     string   `json:"owner"`
+	Repo      string   `json:"repo"`
+	State     string   `json:"state,omitempty"` // open, closed, all
+	Labels    []string `json:"labels,omitempty"`
+	Milestone string   `json:"milestone,omitempty"` // title, "none" or "*"
+	Assignee  string   `json:"assignee,omitempty"`  // login or "none"
+	Author    string   `json:"author,omitempty"`
+	Since     string   `json:"since,omitempty"` // YYYY-MM-DD, updated on or after
+	Query     string   `json:"query,omitempty"` // extra search qualifiers
+	Format    string   `json:"format"`
+	GroupBy   string   `json:"group_by,omitempty"` // Markdown only
+	Limit     int      `json:"limit,omitempty"`
+}
+
+// ExportIssuesResult describes a finished export
+type ExportIssuesResult struct {
+	Repository string `json:"repository"`
+	Format     string `json:"format"`
+	Count      int    `json:"count"`
+	// Truncated is set when more issues matched than the limit allowed
+	Truncated bool `json:"truncated,omitempty"`
+	// Path is the file the export was written to, if any
+	Path string `json:"path,omitempty"`
+}
+
+// ExportExtension returns the file extension of an export format
+func ExportExtension(format string) string {
+	if format == ExportFormatMarkdown {
+		return "md"
+	}
+	return strings.ToLower(format)
+}
@@ -0,0 +1,90 @@
+package domain
+
+// ListLabelsRequest defines parameters for listing the labels of a repository
+type ListLabelsRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
+
+// CreateLabelRequest defines parameters for creating a label
+type CreateLabelRequest struct {
+	Owner       string `json:"owner"`
+	Repo        string `json:"repo"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// UpdateLabelRequest defines parameters for updating a label. Empty NewName
+// and Color and a nil Description are left unchanged.
+type UpdateLabelRequest struct {
+	Owner       string  `json:"owner"`
+	Repo        string  `json:"repo"`
+	Name        string  `json:"name"`
+	NewName     string  `json:"new_name,omitempty"`
+	Color       string  `json:"color,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// DeleteLabelRequest defines parameters for deleting a label
+type DeleteLabelRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Name  string `json:"name"`
+}
+
+// LabelSpec is the desired label set applied by sync_labels
+type LabelSpec struct {
+	// Repositories are the "owner/repo" targets, unless the call names its own
+	Repositories []string `yaml:"repositories"`
+	// Labels is the desired set; Aliases are old names renamed to Name
+	Labels []LabelDefinition `yaml:"labels"`
+	// Prune deletes labels that are not in the spec
+	Prune bool `yaml:"prune"`
+}
+
+// LabelDefinition is a label of a LabelSpec
+type LabelDefinition struct {
+	Name        string   `yaml:"name"`
+	Color       string   `yaml:"color"`
+	Description string   `yaml:"description"`
+	Aliases     []string `yaml:"aliases"`
+}
+
+// SyncLabelsRequest defines parameters for applying a label spec
+type SyncLabelsRequest struct {
+	Spec   LabelSpec `json:"spec"`
+	DryRun bool      `json:"dry_run"`
+}
+
+// Label sync actions
+const (
+	LabelActionCreate = "create"
+	LabelActionUpdate = "update"
+	LabelActionRename = "rename"
+	LabelActionDelete = "delete"
+)
+
+// LabelChange is a single difference between a repository and the spec
+type LabelChange struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	// Current is the label in the repository, Desired the label in the spec
+	Current *Label `json:"current,omitempty"`
+	Desired *Label `json:"desired,omitempty"`
+}
+
+// LabelSyncResult is the diff (and, unless dry-run, outcome) for one repository
+type LabelSyncResult struct {
+	Repository string        `json:"repository"`
+	Changes    []LabelChange `json:"changes"`
+	Unchanged  int           `json:"unchanged"`
+	Applied    bool          `json:"applied"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// SyncLabelsResponse holds the per-repository results of a label sync
+type SyncLabelsResponse struct {
+	Results []LabelSyncResult `json:"results"`
+	DryRun  bool              `json:"dry_run"`
+}
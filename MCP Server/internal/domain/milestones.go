@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// Milestone represents a GitHub milestone
+type Milestone struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	DueOn        *time.Time `json:"due_on"`
+	OpenIssues   int        `json:"open_issues"`
+	ClosedIssues int        `json:"closed_issues"`
+	HTMLURL      string     `json:"html_url"`
+}
+
+// ListMilestonesRequest defines parameters for listing milestones
+type ListMilestonesRequest struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	State string `json:"state,omitempty"` // open, closed, all
+}
+
+// CreateMilestoneRequest defines parameters for creating a milestone
+type CreateMilestoneRequest struct {
+	Owner       string `json:"owner"`
+	Repo        string `json:"repo"`
+	Title       string `json:"title"`
+	State       string `json:"state,omitempty"`
+	Description string `json:"description,omitempty"`
+	DueOn       string `json:"due_on,omitempty"` // YYYY-MM-DD
+}
+
+// UpdateMilestoneRequest defines parameters for updating a milestone. Empty
+// Title and State and nil pointers are left unchanged; an empty *DueOn clears
+// the due date.
+type UpdateMilestoneRequest struct {
+	Owner       string  `json:"owner"`
+	Repo        string  `json:"repo"`
+	Number      int     `json:"number"`
+	Title       string  `json:"title,omitempty"`
+	State       string  `json:"state,omitempty"`
+	Description *string `json:"description,omitempty"`
+	DueOn       *string `json:"due_on,omitempty"`
+}
+
+// DeleteMilestoneRequest defines parameters for deleting a milestone
+type DeleteMilestoneRequest struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+}
@@ -4,14 +4,55 @@ import "time"
 
 // Issue represents a GitHub issue
 type Issue struct {
-	Number    int       `json:"number"`
-	Title     string    `json:"title"`
-	State     string    `json:"state"`
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	HTMLURL   string     `json:"html_url"`
+	Body      string     `json:"body,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	User      User       `json:"user"`
+	Labels    []Label    `json:"labels"`
+	Assignees []User     `json:"assignees,omitempty"`
+	Milestone *Milestone `json:"milestone,omitempty"`
+
+	// Only returned by the search API: the API URL of the issue's repository
+	RepositoryURL string `json:"repository_url,omitempty"`
+
+	// Only populated by the GraphQL path when the matching field is requested,
+	// except CommentCount which the REST API also returns
+	CommentCount       int              `json:"comments"`
+	Comments           []Comment        `json:"recent_comments,omitempty"`
+	LinkedPullRequests []PullRequestRef `json:"linked_pull_requests,omitempty"`
+	ProjectItems       []ProjectItem    `json:"project_items,omitempty"`
+}
+
+// Comment represents an issue comment
+type Comment struct {
+	ID        int64     `json:"id,omitempty"`
+	Author    User      `json:"user"`
+	Body      string    `json:"body"`
 	HTMLURL   string    `json:"html_url"`
+	IssueURL  string    `json:"issue_url,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      User      `json:"user"`
-	Labels    []Label   `json:"labels"`
+}
+
+// PullRequestRef represents a pull request linked to an issue
+type PullRequestRef struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ProjectItem represents the membership of an issue in a Projects (v2) board
+type ProjectItem struct {
+	ID            string            `json:"id"`
+	ProjectNumber int               `json:"project_number"`
+	ProjectTitle  string            `json:"project_title"`
+	ProjectURL    string            `json:"project_url"`
+	Fields        map[string]string `json:"fields,omitempty"` // field name -> display value
 }
 
 // User represents a GitHub user
@@ -22,19 +63,65 @@ type User struct {
 
 // Label represents a GitHub label
 type Label struct {
-	Name  string `json:"name"`
-	Color string `json:"color"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description,omitempty"`
+}
+
+// Optional issue fields that can be requested on top of the basic ones
+const (
+	IssueFieldBody      = "body"
+	IssueFieldAssignees = "assignees"
+	IssueFieldComments  = "comments"
+	IssueFieldLinkedPRs = "linked_prs"
+	IssueFieldProjects  = "projects"
+)
+
+// graphQLIssueFields are the fields only the GraphQL API returns in one round trip
+var graphQLIssueFields = map[string]bool{
+	IssueFieldComments:  true,
+	IssueFieldLinkedPRs: true,
+	IssueFieldProjects:  true,
 }
 
 // GetIssuesRequest defines parameters for fetching issues
 type GetIssuesRequest struct {
-	Owner string `json:"owner"`
-	Repo  string `json:"repo"`
-	State string `json:"state,omitempty"` // open, closed, all
+	Owner   string   `json:"owner"`
+	Repo    string   `json:"repo"`
+	State   string   `json:"state,omitempty"` // open, closed, all
+	Fields  []string `json:"fields,omitempty"`
+	PerPage int      `json:"per_page,omitempty"`
+	Cursor  string   `json:"cursor,omitempty"`
+}
+
+// NeedsGraphQL reports whether the request can only be served by the GraphQL API
+func (r *GetIssuesRequest) NeedsGraphQL() bool {
+	return r.Cursor != "" || NeedsGraphQL(r.Fields)
 }
 
 // GetIssuesResponse contains the issues response
 type GetIssuesResponse struct {
-	Issues []Issue `json:"issues"`
-	Count  int     `json:"count"`
+	Issues     []Issue `json:"issues"`
+	Count      int     `json:"count"`
+	TotalCount int     `json:"total_count,omitempty"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	Truncated  bool    `json:"truncated,omitempty"` // results were capped by policy
+}
+
+// GetIssueRequest defines parameters for fetching a single issue
+type GetIssueRequest struct {
+	Owner  string   `json:"owner"`
+	Repo   string   `json:"repo"`
+	Number int      `json:"number"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// NeedsGraphQL reports whether any of the fields requires the GraphQL API
+func NeedsGraphQL(fields []string) bool {
+	for _, field := range fields {
+		if graphQLIssueFields[field] {
+			return true
+		}
+	}
+	return false
 }
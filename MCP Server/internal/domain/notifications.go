@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+// Notification represents a thread in the authenticated user's notifications inbox
+type Notification struct {
+	ID         string              `json:"id"`
+	Unread     bool                `json:"unread"`
+	Reason     string              `json:"reason"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	LastReadAt *time.Time          `json:"last_read_at"`
+	Subject    NotificationSubject `json:"subject"`
+	Repository RepositoryRef       `json:"repository"`
+}
+
+// NotificationSubject is the issue, pull request, release, ... a notification is about
+type NotificationSubject struct {
+	Title            string `json:"title"`
+	Type             string `json:"type"` // Issue, PullRequest, Release, Commit, Discussion, CheckSuite, ...
+	URL              string `json:"url"`
+	LatestCommentURL string `json:"latest_comment_url"`
+}
+
+// RepositoryRef identifies a repository by its full name
+type RepositoryRef struct {
+	FullName string `json:"full_name"`
+}
+
+// ListNotificationsRequest defines parameters for listing notifications
+type ListNotificationsRequest struct {
+	Owner         string   `json:"owner,omitempty"`
+	Repo          string   `json:"repo,omitempty"`
+	Reasons       []string `json:"reasons,omitempty"`
+	Participating bool     `json:"participating,omitempty"`
+	All           bool     `json:"all,omitempty"` // include notifications already read
+	Since         string   `json:"since,omitempty"`
+	PerPage       int      `json:"per_page,omitempty"`
+	Page          int      `json:"page,omitempty"`
+}
+
+// ListNotificationsResponse represents a page of notifications
+type ListNotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	Count         int            `json:"count"`
+	NextPage      int            `json:"next_page,omitempty"`
+}
+
+// GetNotificationThreadRequest defines parameters for fetching a notification thread
+type GetNotificationThreadRequest struct {
+	ThreadID string `json:"thread_id"`
+	// WithSubject also fetches the subject and its latest comment
+	WithSubject bool `json:"with_subject,omitempty"`
+}
+
+// NotificationThread is a notification with the details of its subject
+type NotificationThread struct {
+	Notification  Notification    `json:"notification"`
+	Subject       *SubjectDetails `json:"subject,omitempty"`
+	LatestComment *Comment        `json:"latest_comment,omitempty"`
+}
+
+// SubjectDetails is the state and body of an issue or pull request subject
+type SubjectDetails struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	Body    string `json:"body"`
+	User    User   `json:"user"`
+	Merged  bool   `json:"merged"`
+}
+
+// MarkNotificationReadRequest defines parameters for marking a thread as read
+type MarkNotificationReadRequest struct {
+	ThreadID string `json:"thread_id"`
+}
@@ -0,0 +1,85 @@
+package domain
+
+// Project field data types used by Projects (v2)
+const (
+	ProjectFieldText         = "TEXT"
+	ProjectFieldNumber       = "NUMBER"
+	ProjectFieldDate         = "DATE"
+	ProjectFieldSingleSelect = "SINGLE_SELECT"
+	ProjectFieldIteration    = "ITERATION"
+)
+
+// StatusFieldName is the name of the built-in Projects (v2) status field
+const StatusFieldName = "Status"
+
+// Project represents a Projects (v2) board
+type Project struct {
+	ID     string         `json:"id"`
+	Number int            `json:"number"`
+	Title  string         `json:"title"`
+	URL    string         `json:"url"`
+	Fields []ProjectField `json:"fields,omitempty"`
+}
+
+// ProjectField represents a custom field of a project
+type ProjectField struct {
+	ID       string               `json:"id"`
+	Name     string               `json:"name"`
+	DataType string               `json:"data_type"`
+	Options  []ProjectFieldOption `json:"options,omitempty"` // single select options or iterations
+}
+
+// ProjectFieldOption is a single select option or an iteration
+type ProjectFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProjectFieldValue is the value written to a project field; exactly one
+// member is set, matching the field's data type
+type ProjectFieldValue struct {
+	Text                 string   `json:"text,omitempty"`
+	Number               *float64 `json:"number,omitempty"`
+	Date                 string   `json:"date,omitempty"`
+	SingleSelectOptionID string   `json:"singleSelectOptionId,omitempty"`
+	IterationID          string   `json:"iterationId,omitempty"`
+}
+
+// ProjectBoardItem represents an item on a project board
+type ProjectBoardItem struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"` // ISSUE, PULL_REQUEST, DRAFT_ISSUE
+	Title      string            `json:"title"`
+	Number     int               `json:"number,omitempty"`
+	State      string            `json:"state,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Repository string            `json:"repository,omitempty"` // owner/repo
+	Fields     map[string]string `json:"fields,omitempty"`     // field name -> display value
+}
+
+// ListProjectItemsRequest defines parameters for listing project items
+type ListProjectItemsRequest struct {
+	Owner   string `json:"owner"`
+	Number  int    `json:"number"`
+	Status  string `json:"status,omitempty"` // only items with this Status value
+	PerPage int    `json:"per_page,omitempty"`
+	Cursor  string `json:"cursor,omitempty"`
+}
+
+// ListProjectItemsResponse contains a page of project items
+type ListProjectItemsResponse struct {
+	Project    Project            `json:"project"`
+	Items      []ProjectBoardItem `json:"items"`
+	TotalCount int                `json:"total_count"`
+	Count      int                `json:"count"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// SetProjectFieldRequest defines parameters for setting a field of a project item
+type SetProjectFieldRequest struct {
+	Owner  string `json:"owner"`
+	Number int    `json:"number"`
+	ItemID string `json:"item_id"`
+	Field  string `json:"field"`
+	Value  string `json:"value"` // empty clears the field
+}
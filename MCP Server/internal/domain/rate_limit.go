@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RateLimit represents the GitHub API rate limit status
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+	Resource  string    `json:"resource,omitempty"`
+	Cost      int       `json:"cost,omitempty"` // GraphQL point cost of the last query
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "regexp"
+
+var (
+	// ownerNamePattern matches GitHub user and organization logins; the
+	// underscore appears in enterprise managed user logins
+	ownerNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,99}$`)
+	// repoNamePattern matches the characters GitHub keeps in repository names
+	repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+)
+
+// ValidOwnerName reports whether owner is a well-formed GitHub login
+func ValidOwnerName(owner string) bool {
+	return ownerNamePattern.MatchString(owner)
+}
+
+// ValidRepoName reports whether repo is a well-formed repository name.
+// "." and ".." are rejected because they change the meaning of API paths.
+func ValidRepoName(repo string) bool {
+	return repo != "." && repo != ".." && repoNamePattern.MatchString(repo)
+}
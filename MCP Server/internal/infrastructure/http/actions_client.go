@@ -0,0 +1,141 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// ListWorkflowRuns fetches the workflow runs of a repository
+func (c *GitHubClient) ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error) {
+	query := url.Values{}
+	if req.Branch != "" {
+		query.Set("branch", req.Branch)
+	}
+	if req.Event != "" {
+		query.Set("event", req.Event)
+	}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
+	}
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/actions/runs", req.Owner, req.Repo), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var response domain.ListWorkflowRunsResponse
+		if err := decodeJSON(resp.Body, &response, "workflow runs"); err != nil {
+			return nil, err
+		}
+		response.Count = len(response.Runs)
+		return &response, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// GetWorkflowRun fetches a workflow run
+func (c *GitHubClient) GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.WorkflowRun, error) {
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/actions/runs/%d", req.Owner, req.Repo, req.RunID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var run domain.WorkflowRun
+		if err := decodeJSON(resp.Body, &run, "workflow run"); err != nil {
+			return nil, err
+		}
+		return &run, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("workflow run %d in %s/%s", req.RunID, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// ListWorkflowJobs fetches the jobs of a workflow run
+func (c *GitHubClient) ListWorkflowJobs(req *domain.GetWorkflowRunRequest) ([]domain.WorkflowJob, error) {
+	query := url.Values{"per_page": {"100"}}
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/actions/runs/%d/jobs", req.Owner, req.Repo, req.RunID), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var response struct {
+			Jobs []domain.WorkflowJob `json:"jobs"`
+		}
+		if err := decodeJSON(resp.Body, &response, "workflow jobs"); err != nil {
+			return nil, err
+		}
+		return response.Jobs, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("workflow run %d in %s/%s", req.RunID, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// GetJobLogs downloads the plain text logs of a job and returns their last
+// maxBytes bytes together with the full size. The download is streamed
+// through a ring buffer, so memory stays bounded by maxBytes.
+// GitHub answers with a redirect to a short-lived download URL, which the
+// HTTP client follows without forwarding the Authorization header.
+func (c *GitHubClient) GetJobLogs(req *domain.GetJobLogsRequest, maxBytes int) ([]byte, int, error) {
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/actions/jobs/%d/logs", req.Owner, req.Repo, req.JobID), nil, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		tail := newTailBuffer(maxBytes)
+		if _, err := io.Copy(tail, resp.Body); err != nil {
+			return nil, 0, errors.NewNetworkError(fmt.Sprintf("reading job logs: %v", err))
+		}
+		return tail.Bytes(), tail.Total(), nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, 0, errors.NewNotFoundError(fmt.Sprintf("logs for job %d in %s/%s", req.JobID, req.Owner, req.Repo))
+	default:
+		return nil, 0, c.handleAPIError(resp)
+	}
+}
+
+// RerunFailedJobs re-runs the failed jobs of a workflow run
+func (c *GitHubClient) RerunFailedJobs(req *domain.RerunFailedJobsRequest) error {
+	resp, err := c.do("POST", fmt.Sprintf("/repos/%s/%s/actions/runs/%d/rerun-failed-jobs", req.Owner, req.Repo, req.RunID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return errors.NewNotFoundError(fmt.Sprintf("workflow run %d in %s/%s", req.RunID, req.Owner, req.Repo))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// contentResponse is the raw contents API representation of a file
+type contentResponse struct {
+	domain.ContentEntry
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetFileContents fetches a file and returns its entry and decoded bytes
+func (c *GitHubClient) GetFileContents(req *domain.GetFileContentsRequest) (*domain.ContentEntry, []byte, error) {
+	raw, err := c.getContents(req.Owner, req.Repo, req.Path, req.Ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("%s is a directory, use list_directory", req.Path))
+	}
+
+	var file contentResponse
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, nil, errors.NewJSONDecodingError(fmt.Sprintf("decoding file contents: %v", err))
+	}
+	if file.Type != "file" {
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("%s is a %s, not a file", req.Path, file.Type))
+	}
+
+	// Files over 1 MB come back without inline content
+	if file.Encoding == "none" || (file.Content == "" && file.Size > 0) {
+		data, err := c.getRawContents(req.Owner, req.Repo, req.Path, req.Ref, req.MaxBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &file.ContentEntry, data, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, nil, errors.NewJSONDecodingError(fmt.Sprintf("decoding base64 content: %v", err))
+	}
+
+	return &file.ContentEntry, data, nil
+}
+
+// ListDirectory fetches the entries of a directory
+func (c *GitHubClient) ListDirectory(req *domain.ListDirectoryRequest) ([]domain.ContentEntry, error) {
+	raw, err := c.getContents(req.Owner, req.Repo, req.Path, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		return nil, errors.NewValidationError(fmt.Sprintf("%s is not a directory, use get_file_contents", req.Path))
+	}
+
+	var entries []domain.ContentEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, errors.NewJSONDecodingError(fmt.Sprintf("decoding directory: %v", err))
+	}
+
+	return entries, nil
+}
+
+// GetRepoTree fetches the git tree of a ref
+func (c *GitHubClient) GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error) {
+	query := url.Values{}
+	if req.Recursive {
+		query.Set("recursive", "1")
+	}
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/git/trees/%s", req.Owner, req.Repo, url.PathEscape(req.Ref)), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var tree domain.RepoTree
+		if err := decodeJSON(resp.Body, &tree, "tree"); err != nil {
+			return nil, err
+		}
+		return &tree, nil
+	case http.StatusNotFound, http.StatusConflict:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("tree %s in %s/%s", req.Ref, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// SearchCode searches code using the search API, including text match fragments
+func (c *GitHubClient) SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error) {
+	query := url.Values{"q": {req.Query}}
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
+	}
+
+	resp, err := c.doWithAccept("GET", "/search/code", query, nil, "application/vnd.github.text-match+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var raw struct {
+		TotalCount int  `json:"total_count"`
+		Incomplete bool `json:"incomplete_results"`
+		Items      []struct {
+			Name       string `json:"name"`
+			Path       string `json:"path"`
+			SHA        string `json:"sha"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+			TextMatches []struct {
+				Fragment string `json:"fragment"`
+			} `json:"text_matches"`
+		} `json:"items"`
+	}
+	if err := decodeJSON(resp.Body, &raw, "code search results"); err != nil {
+		return nil, err
+	}
+
+	response := &domain.SearchCodeResponse{
+		TotalCount: raw.TotalCount,
+		Incomplete: raw.Incomplete,
+	}
+	for _, item := range raw.Items {
+		result := domain.CodeSearchResult{
+			Name:       item.Name,
+			Path:       item.Path,
+			SHA:        item.SHA,
+			HTMLURL:    item.HTMLURL,
+			Repository: item.Repository.FullName,
+		}
+		for _, match := range item.TextMatches {
+			result.Fragments = append(result.Fragments, match.Fragment)
+		}
+		response.Items = append(response.Items, result)
+	}
+	response.Count = len(response.Items)
+
+	return response, nil
+}
+
+// getContents calls the contents endpoint and returns the raw JSON body
+func (c *GitHubClient) getContents(owner, repo, path, ref string) ([]byte, error) {
+	query := url.Values{}
+	if ref != "" {
+		query.Set("ref", ref)
+	}
+
+	resp, err := c.do("GET", contentsPath(owner, repo, path), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.NewNetworkError(fmt.Sprintf("reading contents: %v", err))
+		}
+		return raw, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("path %q in %s/%s", path, owner, repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// getRawContents downloads a file using the raw media type. With maxBytes
+// set, only the first maxBytes+1 bytes are read: enough to tell the caller
+// the file is larger without holding all of it in memory.
+func (c *GitHubClient) getRawContents(owner, repo, path, ref string, maxBytes int) ([]byte, error) {
+	query := url.Values{}
+	if ref != "" {
+		query.Set("ref", ref)
+	}
+
+	resp, err := c.doWithAccept("GET", contentsPath(owner, repo, path), query, nil, "application/vnd.github.raw")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var body io.Reader = resp.Body
+	if maxBytes > 0 {
+		body = io.LimitReader(resp.Body, int64(maxBytes)+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, errors.NewNetworkError(fmt.Sprintf("reading raw contents: %v", err))
+	}
+	return data, nil
+}
+
+// contentsPath builds the contents endpoint path, escaping each path segment
+func contentsPath(owner, repo, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, strings.Join(segments, "/"))
+}
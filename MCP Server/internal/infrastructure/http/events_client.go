@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// ListRepoIssueEvents fetches a page of the issue events of a repository, newest first
+func (c *GitHubClient) ListRepoIssueEvents(req *domain.ListRepoPageRequest) (*domain.ListIssueEventsResponse, error) {
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/issues/events", req.Owner, req.Repo), pageQuery(req), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var events []domain.IssueEvent
+		if err := decodeJSON(resp.Body, &events, "issue events"); err != nil {
+			return nil, err
+		}
+		return &domain.ListIssueEventsResponse{Events: events, NextPage: nextPage(resp)}, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// ListRepoIssueComments fetches a page of the issue comments of a repository
+// updated since req.Since, oldest first
+func (c *GitHubClient) ListRepoIssueComments(req *domain.ListRepoPageRequest) (*domain.ListIssueCommentsResponse, error) {
+	query := pageQuery(req)
+	query.Set("sort", "created")
+	query.Set("direction", "asc")
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/issues/comments", req.Owner, req.Repo), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var comments []domain.Comment
+		if err := decodeJSON(resp.Body, &comments, "issue comments"); err != nil {
+			return nil, err
+		}
+		return &domain.ListIssueCommentsResponse{Comments: comments, NextPage: nextPage(resp)}, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// ListUpdatedIssues fetches a page of the issues, in any state, updated since
+// req.Since, oldest created first. The issues endpoint also returns pull
+// requests; they are dropped.
+func (c *GitHubClient) ListUpdatedIssues(req *domain.ListRepoPageRequest) (*domain.ListUpdatedIssuesResponse, error) {
+	query := pageQuery(req)
+	query.Set("state", "all")
+	query.Set("sort", "created")
+	query.Set("direction", "asc")
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/issues", req.Owner, req.Repo), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var items []struct {
+			domain.Issue
+			PullRequest json.RawMessage `json:"pull_request"`
+		}
+		if err := decodeJSON(resp.Body, &items, "issues"); err != nil {
+			return nil, err
+		}
+		issues := make([]domain.Issue, 0, len(items))
+		for _, item := range items {
+			if item.PullRequest == nil {
+				issues = append(issues, item.Issue)
+			}
+		}
+		return &domain.ListUpdatedIssuesResponse{Issues: issues, NextPage: nextPage(resp)}, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// pageQuery builds the paging and since parameters shared by the repository listings
+func pageQuery(req *domain.ListRepoPageRequest) url.Values {
+	query := url.Values{}
+	if !req.Since.IsZero() {
+		query.Set("since", req.Since.UTC().Format(time.RFC3339))
+	}
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
+	}
+	if req.Page > 1 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	return query
+}
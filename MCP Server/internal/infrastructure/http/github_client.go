@@ -1,11 +1,14 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"mcp-server/internal/domain"
 	"mcp-server/pkg/errors"
@@ -13,50 +16,46 @@ import (
 
 // GitHubClient is an HTTP client for the GitHub API
 type GitHubClient struct {
-	client  *http.Client
-	baseURL string
-	token   string
+	client      *http.Client
+	baseURL     string
+	token       string
+	rateLimiter *RateLimiter
 }
 
 // NewGitHubClient creates a new GitHubClient instance
-func NewGitHubClient() *GitHubClient {
+func NewGitHubClient(baseURL, token string) *GitHubClient {
 	return &GitHubClient{
-		client:  http.DefaultClient,
-		baseURL: "https://api.github.com",
-		token:   os.Getenv("GITHUB_TOKEN"),
+		client:      http.DefaultClient,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		token:       token,
+		rateLimiter: NewRateLimiter(),
 	}
 }
 
+// RateLimit returns the last rate limit reported by GitHub
+func (c *GitHubClient) RateLimit() domain.RateLimit {
+	return c.rateLimiter.Snapshot()
+}
+
 // GetIssues fetches issues from a repository
 func (c *GitHubClient) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error) {
-	if c.token == "" {
-		return nil, errors.NewUnauthorizedError()
-	}
-
-	url := fmt.Sprintf("%s/repos/%s/%s/issues", c.baseURL, req.Owner, req.Repo)
+	query := url.Values{}
 	if req.State != "" {
-		url += fmt.Sprintf("?state=%s", req.State)
+		query.Set("state", req.State)
 	}
-
-	httpReq, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, errors.NewNetworkError(fmt.Sprintf("creating request: %v", err))
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
 	}
 
-	httpReq.Header.Set("Authorization", "token "+c.token)
-	httpReq.Header.Set("Accept", "application/vnd.github.v3+json")
-
-	resp, err := c.client.Do(httpReq)
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/issues", req.Owner, req.Repo), query, nil)
 	if err != nil {
-		return nil, errors.NewNetworkError(fmt.Sprintf("executing request: %v", err))
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusOK:
 		return c.parseIssuesResponse(resp.Body)
-	case http.StatusUnauthorized:
-		return nil, errors.NewUnauthorizedError()
 	case http.StatusNotFound:
 		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
 	default:
@@ -64,11 +63,52 @@ func (c *GitHubClient) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssue
 	}
 }
 
+// GetIssue fetches a single issue from a repository
+func (c *GitHubClient) GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error) {
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/issues/%d", req.Owner, req.Repo, req.Number), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var issue domain.Issue
+		if err := decodeJSON(resp.Body, &issue, "issue"); err != nil {
+			return nil, err
+		}
+		return &issue, nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("issue #%d in %s/%s", req.Number, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// GetAuthenticatedUser fetches the user the token belongs to
+func (c *GitHubClient) GetAuthenticatedUser() (*domain.User, error) {
+	resp, err := c.do("GET", "/user", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var user domain.User
+	if err := decodeJSON(resp.Body, &user, "user"); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // parseIssuesResponse parses the issues response
 func (c *GitHubClient) parseIssuesResponse(body io.ReadCloser) (*domain.GetIssuesResponse, error) {
 	var issues []domain.Issue
-	if err := json.NewDecoder(body).Decode(&issues); err != nil {
-		return nil, errors.NewJSONDecodingError(fmt.Sprintf("decoding issues: %v", err))
+	if err := decodeJSON(body, &issues, "issues"); err != nil {
+		return nil, err
 	}
 
 	return &domain.GetIssuesResponse{
@@ -77,6 +117,75 @@ func (c *GitHubClient) parseIssuesResponse(body io.ReadCloser) (*domain.GetIssue
 	}, nil
 }
 
+// do builds an authenticated request against the API, waits out the rate
+// limit if needed and executes it. Unauthorized and rate-limited responses
+// are turned into errors; every other status is left to the caller.
+func (c *GitHubClient) do(method, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	return c.doWithAccept(method, path, query, body, "application/vnd.github.v3+json")
+}
+
+// doWithAccept is like do but requests a specific media type
+func (c *GitHubClient) doWithAccept(method, path string, query url.Values, body io.Reader, accept string) (*http.Response, error) {
+	if c.token == "" {
+		return nil, errors.NewUnauthorizedError()
+	}
+
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	httpReq, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, errors.NewNetworkError(fmt.Sprintf("creating request: %v", err))
+	}
+
+	httpReq.Header.Set("Authorization", "token "+c.token)
+	httpReq.Header.Set("Accept", accept)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if err := c.rateLimiter.Wait(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.NewNetworkError(fmt.Sprintf("executing request: %v", err))
+	}
+
+	c.rateLimiter.Update(resp)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		return nil, errors.NewUnauthorizedError()
+	}
+	if err := c.rateLimiter.CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// encodeJSON encodes a request body
+func encodeJSON(v interface{}) (io.Reader, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("encoding request body: %v", err))
+	}
+	return bytes.NewReader(data), nil
+}
+
+// decodeJSON decodes a response body into v
+func decodeJSON(body io.Reader, v interface{}, what string) error {
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return errors.NewJSONDecodingError(fmt.Sprintf("decoding %s: %v", what, err))
+	}
+	return nil
+}
+
 // handleAPIError handles GitHub API errors
 func (c *GitHubClient) handleAPIError(resp *http.Response) error {
 	var apiErr struct {
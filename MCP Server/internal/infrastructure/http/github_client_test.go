@@ -0,0 +1,222 @@
+package http_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"mcp-server/internal/domain"
+	githubhttp "mcp-server/internal/infrastructure/http"
+	"mcp-server/internal/testutil/fakegithub"
+	"mcp-server/pkg/errors"
+)
+
+// errorCode returns the AppError code of err, or "" for other errors
+func errorCode(err error) string {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestGetIssuesFiltersByStateAndPageSize(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	testCases := []struct {
+		state    string
+		perPage  int
+		expected []int
+	}{
+		{"", 0, []int{5, 3, 2}},
+		{"open", 2, []int{5, 3}},
+		{"closed", 0, []int{4, 1}},
+		{"all", 0, []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		response, err := client.GetIssues(&domain.GetIssuesRequest{Owner: "acme", Repo: "api", State: tc.state, PerPage: tc.perPage})
+		if err != nil {
+			t.Fatalf("GetIssues(state=%q, per_page=%d) error: %v", tc.state, tc.perPage, err)
+		}
+
+		numbers := make([]int, 0, len(response.Issues))
+		for _, issue := range response.Issues {
+			numbers = append(numbers, issue.Number)
+		}
+		if len(numbers) != len(tc.expected) {
+			t.Errorf("GetIssues(state=%q, per_page=%d) = %v, expected %v", tc.state, tc.perPage, numbers, tc.expected)
+			continue
+		}
+		for i := range numbers {
+			if numbers[i] != tc.expected[i] {
+				t.Errorf("GetIssues(state=%q, per_page=%d) = %v, expected %v", tc.state, tc.perPage, numbers, tc.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestGetIssueDecodesFixture(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	issue, err := client.GetIssue(&domain.GetIssueRequest{Owner: "acme", Repo: "api", Number: 2})
+	if err != nil {
+		t.Fatalf("GetIssue error: %v", err)
+	}
+	if issue.Title != "Login fails with expired refresh token" || len(issue.Labels) != 2 || issue.CommentCount != 4 {
+		t.Errorf("GetIssue = %+v, expected issue #2 with 2 labels and 4 comments", issue)
+	}
+}
+
+func TestRateLimitIsTrackedFromHeaders(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	if _, err := client.GetIssues(&domain.GetIssuesRequest{Owner: "acme", Repo: "api"}); err != nil {
+		t.Fatalf("GetIssues error: %v", err)
+	}
+
+	limit := client.RateLimit()
+	if limit.Limit != 5000 || limit.Remaining != 4999 || limit.Resource != "core" {
+		t.Errorf("RateLimit() = %+v, expected 4999 of 5000 core requests left", limit)
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(fake *fakegithub.Server)
+		token    string
+		repo     string
+		expected string
+	}{
+		{"unknown repository", nil, fakegithub.Token, "missing", errors.ErrCodeNotFound},
+		{"bad credentials", nil, "wrong-token", "api", errors.ErrCodeUnauthorized},
+		{"missing token", nil, "", "api", errors.ErrCodeUnauthorized},
+		{
+			"server error",
+			func(fake *fakegithub.Server) {
+				fake.Fail("GET", "/repos/acme/api/issues", http.StatusBadGateway, "Server Error")
+			},
+			fakegithub.Token, "api", errors.ErrCodeGitHubAPI,
+		},
+		{
+			"secondary rate limit",
+			func(fake *fakegithub.Server) {
+				fake.FailWithHeaders("GET", "/repos/acme/api/issues", http.StatusTooManyRequests,
+					"You have exceeded a secondary rate limit", map[string]string{"Retry-After": "30"})
+			},
+			fakegithub.Token, "api", errors.ErrCodeRateLimited,
+		},
+		{
+			"primary rate limit exhausted",
+			func(fake *fakegithub.Server) {
+				fake.SetRateLimit(0, time.Now().Add(time.Hour))
+			},
+			fakegithub.Token, "api", errors.ErrCodeRateLimited,
+		},
+	}
+
+	for _, tc := range testCases {
+		fake := fakegithub.New(t)
+		if tc.setup != nil {
+			tc.setup(fake)
+		}
+		client := githubhttp.NewGitHubClient(fake.URL, tc.token)
+
+		_, err := client.GetIssues(&domain.GetIssuesRequest{Owner: "acme", Repo: tc.repo})
+		if code := errorCode(err); code != tc.expected {
+			t.Errorf("%s: GetIssues error = %v, expected code %s", tc.name, err, tc.expected)
+		}
+	}
+}
+
+func TestExhaustedRateLimitFailsWithoutRequest(t *testing.T) {
+	fake := fakegithub.New(t)
+	fake.SetRateLimit(1, time.Now().Add(time.Hour))
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	if _, err := client.GetIssues(&domain.GetIssuesRequest{Owner: "acme", Repo: "api"}); err != nil {
+		t.Fatalf("first GetIssues error: %v", err)
+	}
+
+	_, err := client.GetIssues(&domain.GetIssuesRequest{Owner: "acme", Repo: "api"})
+	if code := errorCode(err); code != errors.ErrCodeRateLimited {
+		t.Errorf("second GetIssues error = %v, expected code %s", err, errors.ErrCodeRateLimited)
+	}
+	if n := len(fake.Requests()); n != 1 {
+		t.Errorf("fake received %d requests, expected 1", n)
+	}
+}
+
+func TestGetJobLogsKeepsOnlyTheTail(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	logs := strings.Repeat("0123456789", 10000) + "error: build failed\n"
+	fake.Handle("GET", "/repos/acme/api/actions/jobs/7/logs", func(w http.ResponseWriter, r *http.Request) {
+		// Several writes so the ring buffer wraps more than once
+		for i := 0; i < len(logs); i += 4096 {
+			w.Write([]byte(logs[i:min(i+4096, len(logs))]))
+		}
+	})
+
+	for _, maxBytes := range []int{20, 4096, 5000, len(logs), len(logs) + 10} {
+		tail, total, err := client.GetJobLogs(&domain.GetJobLogsRequest{Owner: "acme", Repo: "api", JobID: 7}, maxBytes)
+		if err != nil {
+			t.Fatalf("GetJobLogs(%d) error: %v", maxBytes, err)
+		}
+		expected := logs[max(len(logs)-maxBytes, 0):]
+		if total != len(logs) || string(tail) != expected {
+			t.Errorf("GetJobLogs(%d) = %d bytes of %d, want the last %d of %d", maxBytes, len(tail), total, len(expected), len(logs))
+		}
+	}
+}
+
+func TestGetFileContentsDecodesBase64(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	entry, data, err := client.GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "README.md"})
+	if err != nil {
+		t.Fatalf("GetFileContents error: %v", err)
+	}
+	if entry.Path != "README.md" || string(data) != "# API\n" {
+		t.Errorf("GetFileContents = %+v %q, expected README.md %q", entry, data, "# API\n")
+	}
+
+	fake.Handle("GET", "/repos/acme/api/contents/broken.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type": "file", "encoding": "base64", "size": 4, "path": "broken.txt", "content": "not base64!"}`))
+	})
+	if _, _, err := client.GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "broken.txt"}); errorCode(err) != errors.ErrCodeJSONDecoding {
+		t.Errorf("invalid base64: got %v, expected %s", err, errors.ErrCodeJSONDecoding)
+	}
+}
+
+func TestGetFileContentsReadsLargeFilesUpToMaxBytes(t *testing.T) {
+	fake := fakegithub.New(t)
+	client := githubhttp.NewGitHubClient(fake.URL, fakegithub.Token)
+
+	large := strings.Repeat("0123456789\n", 300000)
+	fake.Handle("GET", "/repos/acme/api/contents/dump.sql", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.github.raw" {
+			fmt.Fprintf(w, `{"type": "file", "encoding": "none", "size": %d, "path": "dump.sql", "content": ""}`, len(large))
+			return
+		}
+		w.Write([]byte(large))
+	})
+
+	entry, data, err := client.GetFileContents(&domain.GetFileContentsRequest{Owner: "acme", Repo: "api", Path: "dump.sql", MaxBytes: 1000})
+	if err != nil {
+		t.Fatalf("GetFileContents error: %v", err)
+	}
+	if entry.Size != len(large) || string(data) != large[:1001] {
+		t.Errorf("GetFileContents = size %d and %d bytes, expected size %d and the first 1001 bytes", entry.Size, len(data), len(large))
+	}
+}
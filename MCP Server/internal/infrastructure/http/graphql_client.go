@@ -0,0 +1,189 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// rateLimitSelection is added to every query so the point budget is tracked
+const rateLimitSelection = "rateLimit { limit cost remaining resetAt }"
+
+// GraphQLClient is a client for the GitHub GraphQL (v4) API. It shares the
+// HTTP client and token of a GitHubClient but keeps its own rate limiter,
+// because GraphQL quota is counted in points instead of requests.
+type GraphQLClient struct {
+	client      *http.Client
+	endpoint    string
+	token       string
+	rateLimiter *RateLimiter
+}
+
+// NewGraphQLClient creates a GraphQLClient next to a REST client
+func NewGraphQLClient(rest *GitHubClient) *GraphQLClient {
+	return &GraphQLClient{
+		client:      rest.client,
+		endpoint:    graphQLEndpoint(rest.baseURL),
+		token:       rest.token,
+		rateLimiter: NewRateLimiter(),
+	}
+}
+
+// RateLimit returns the last point budget reported by GitHub
+func (c *GraphQLClient) RateLimit() domain.RateLimit {
+	return c.rateLimiter.Snapshot()
+}
+
+// graphQLError is an entry of the errors array of a GraphQL response
+type graphQLError struct {
+	Type    string   `json:"type"`
+	Message string   `json:"message"`
+	Path    []string `json:"path"`
+}
+
+// graphQLRateLimit is the rateLimit object selected by every query
+type graphQLRateLimit struct {
+	Limit     int       `json:"limit"`
+	Cost      int       `json:"cost"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// Execute runs a query, waiting until estimatedCost points are available,
+// and decodes the data object into out
+func (c *GraphQLClient) Execute(query string, variables map[string]interface{}, estimatedCost int, out interface{}) error {
+	if c.token == "" {
+		return errors.NewUnauthorizedError()
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("encoding GraphQL query: %v", err))
+	}
+
+	httpReq, err := http.NewRequest("POST", c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("creating request: %v", err))
+	}
+
+	httpReq.Header.Set("Authorization", "bearer "+c.token)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if err := c.rateLimiter.WaitFor(estimatedCost); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return errors.NewNetworkError(fmt.Sprintf("executing request: %v", err))
+	}
+	defer resp.Body.Close()
+
+	c.rateLimiter.Update(resp)
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return errors.NewUnauthorizedError()
+	}
+	if err := c.rateLimiter.CheckResponse(resp); err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewGitHubAPIError(fmt.Sprintf("GraphQL error %d", resp.StatusCode))
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphQLError  `json:"errors"`
+	}
+	if err := decodeJSON(resp.Body, &envelope, "GraphQL response"); err != nil {
+		return err
+	}
+
+	var meta struct {
+		RateLimit *graphQLRateLimit `json:"rateLimit"`
+	}
+	if len(envelope.Data) > 0 && json.Unmarshal(envelope.Data, &meta) == nil && meta.RateLimit != nil {
+		c.rateLimiter.Record(domain.RateLimit{
+			Limit:     meta.RateLimit.Limit,
+			Remaining: meta.RateLimit.Remaining,
+			Used:      meta.RateLimit.Limit - meta.RateLimit.Remaining,
+			Reset:     meta.RateLimit.ResetAt,
+			Resource:  "graphql",
+			Cost:      meta.RateLimit.Cost,
+		})
+	}
+
+	if len(envelope.Errors) > 0 {
+		return graphQLErrorToAppError(envelope.Errors)
+	}
+
+	if err := json.Unmarshal(envelope.Data, out); err != nil {
+		return errors.NewJSONDecodingError(fmt.Sprintf("decoding GraphQL data: %v", err))
+	}
+
+	return nil
+}
+
+// graphQLErrorToAppError maps the first GraphQL error to an AppError
+func graphQLErrorToAppError(errs []graphQLError) error {
+	first := errs[0]
+	switch first.Type {
+	case "NOT_FOUND":
+		return errors.NewNotFoundError(first.Message)
+	case "RATE_LIMITED":
+		return errors.NewRateLimitedError(time.Now())
+	case "FORBIDDEN", "INSUFFICIENT_SCOPES":
+		return errors.NewForbiddenError(first.Message)
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Message)
+	}
+	return errors.NewGitHubAPIError("GraphQL: " + strings.Join(messages, "; "))
+}
+
+// graphQLEndpoint derives the GraphQL endpoint from the REST base URL.
+// GitHub Enterprise serves REST at /api/v3 and GraphQL at /api/graphql.
+func graphQLEndpoint(baseURL string) string {
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// connection describes a paginated connection of a query and the
+// connections selected in each of its nodes, for estimating the point cost
+type connection struct {
+	first  int
+	nested []connection
+}
+
+// requests returns how many requests GitHub counts for the connection: one
+// for the connection itself and, for each nested connection, one per node
+func (c connection) requests() int {
+	requests := 1
+	for _, nested := range c.nested {
+		requests += c.first * nested.requests()
+	}
+	return requests
+}
+
+// estimateCost follows GitHub's point cost formula: the requests needed for
+// every connection of the query divided by 100, rounded up, at least one point
+func estimateCost(connections ...connection) int {
+	requests := 0
+	for _, c := range connections {
+		requests += c.requests()
+	}
+	return max((requests+99)/100, 1)
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"testing"
+
+	"mcp-server/internal/domain"
+)
+
+func TestEstimateCost(t *testing.T) {
+	testCases := []struct {
+		name        string
+		connections []connection
+		expected    int
+	}{
+		// GitHub's rate limit documentation counts 1 + 100 + 100*50 = 5,101
+		// requests for repositories(first: 100) { issues(first: 50) { labels(first: 60) } };
+		// rounded up that is 52 points
+		{"documentation example", []connection{{first: 100, nested: []connection{{first: 50, nested: []connection{{first: 60}}}}}}, 52},
+		{"single connection", []connection{{first: 100}}, 1},
+		{"issue page with projects", []connection{{first: 100, nested: newIssueSelection([]string{domain.IssueFieldProjects}).connections()}}, 14},
+		{"single issue with every field", newIssueSelection([]string{domain.IssueFieldComments, domain.IssueFieldLinkedPRs, domain.IssueFieldProjects}).connections(), 1},
+	}
+
+	for _, tc := range testCases {
+		if cost := estimateCost(tc.connections...); cost != tc.expected {
+			t.Errorf("%s: got %d, want %d", tc.name, cost, tc.expected)
+		}
+	}
+}
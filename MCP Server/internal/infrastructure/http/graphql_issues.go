@@ -0,0 +1,355 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// Page sizes of the connections nested in an issue
+const (
+	labelsPageSize      = 20
+	assigneesPageSize   = 10
+	commentsPageSize    = 20
+	linkedPRsPageSize   = 10
+	projectItemsPerPage = 10
+	fieldValuesPageSize = 20
+)
+
+// fieldValuesSelection selects the display value of every project field of an item
+var fieldValuesSelection = fmt.Sprintf(`fieldValues(first: %d) { nodes {
+      __typename
+      ... on ProjectV2ItemFieldSingleSelectValue { name field { ... on ProjectV2FieldCommon { name } } }
+      ... on ProjectV2ItemFieldTextValue { text field { ... on ProjectV2FieldCommon { name } } }
+      ... on ProjectV2ItemFieldNumberValue { number field { ... on ProjectV2FieldCommon { name } } }
+      ... on ProjectV2ItemFieldDateValue { date field { ... on ProjectV2FieldCommon { name } } }
+      ... on ProjectV2ItemFieldIterationValue { title field { ... on ProjectV2FieldCommon { name } } }
+    } }`, fieldValuesPageSize)
+
+// issueSelection builds the GraphQL selection of an issue for a set of fields
+type issueSelection struct {
+	fields map[string]bool
+}
+
+// newIssueSelection creates an issueSelection from the requested fields
+func newIssueSelection(fields []string) issueSelection {
+	s := issueSelection{fields: map[string]bool{}}
+	for _, field := range fields {
+		s.fields[field] = true
+	}
+	return s
+}
+
+// fragment returns the IssueFields fragment
+func (s issueSelection) fragment() string {
+	var b strings.Builder
+
+	b.WriteString("fragment IssueFields on Issue {\n")
+	b.WriteString("  number title state url createdAt updatedAt\n")
+	b.WriteString("  author { login }\n")
+	fmt.Fprintf(&b, "  labels(first: %d) { nodes { name color } }\n", labelsPageSize)
+	fmt.Fprintf(&b, "  assignees(first: %d) { nodes { login databaseId } }\n", assigneesPageSize)
+
+	if s.fields[domain.IssueFieldBody] {
+		b.WriteString("  body\n")
+	}
+
+	if s.fields[domain.IssueFieldComments] {
+		fmt.Fprintf(&b, "  comments(last: %d) { totalCount nodes { author { login } body url createdAt } }\n", commentsPageSize)
+	} else {
+		b.WriteString("  comments { totalCount }\n")
+	}
+
+	if s.fields[domain.IssueFieldLinkedPRs] {
+		fmt.Fprintf(&b, "  closedByPullRequestsReferences(first: %d, includeClosedPrs: true) { nodes { number title state url } }\n", linkedPRsPageSize)
+	}
+
+	if s.fields[domain.IssueFieldProjects] {
+		fmt.Fprintf(&b, "  projectItems(first: %d) { nodes {\n", projectItemsPerPage)
+		b.WriteString("    id project { number title url }\n")
+		b.WriteString("    " + fieldValuesSelection + "\n")
+		b.WriteString("  } }\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// connections returns the connections the fragment selects in an issue,
+// used to estimate the point cost of a query
+func (s issueSelection) connections() []connection {
+	connections := []connection{{first: labelsPageSize}, {first: assigneesPageSize}}
+	if s.fields[domain.IssueFieldComments] {
+		connections = append(connections, connection{first: commentsPageSize})
+	}
+	if s.fields[domain.IssueFieldLinkedPRs] {
+		connections = append(connections, connection{first: linkedPRsPageSize})
+	}
+	if s.fields[domain.IssueFieldProjects] {
+		connections = append(connections, connection{first: projectItemsPerPage, nested: []connection{{first: fieldValuesPageSize}}})
+	}
+	return connections
+}
+
+// BuildIssueListQuery builds a paginated issue list query for the given fields
+func BuildIssueListQuery(fields []string) string {
+	return `query($owner: String!, $repo: String!, $first: Int!, $after: String, $states: [IssueState!]) {
+  ` + rateLimitSelection + `
+  repository(owner: $owner, name: $repo) {
+    issues(first: $first, after: $after, states: $states, orderBy: {field: CREATED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      nodes { ...IssueFields }
+    }
+  }
+}
+` + newIssueSelection(fields).fragment()
+}
+
+// BuildIssueDetailQuery builds a single issue query for the given fields
+func BuildIssueDetailQuery(fields []string) string {
+	return `query($owner: String!, $repo: String!, $number: Int!) {
+  ` + rateLimitSelection + `
+  repository(owner: $owner, name: $repo) {
+    issue(number: $number) { ...IssueFields }
+  }
+}
+` + newIssueSelection(fields).fragment()
+}
+
+// gqlFieldName is the name of the project field a value belongs to
+type gqlFieldName struct {
+	Name string `json:"name"`
+}
+
+// gqlIssue is the GraphQL representation of an issue
+type gqlIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	URL       string    `json:"url"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Author    *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Labels struct {
+		Nodes []domain.Label `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login      string `json:"login"`
+			DatabaseID int    `json:"databaseId"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Body      string    `json:"body"`
+			URL       string    `json:"url"`
+			CreatedAt time.Time `json:"createdAt"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	ClosedByPullRequestsReferences struct {
+		Nodes []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			State  string `json:"state"`
+			URL    string `json:"url"`
+		} `json:"nodes"`
+	} `json:"closedByPullRequestsReferences"`
+	ProjectItems struct {
+		Nodes []gqlProjectItem `json:"nodes"`
+	} `json:"projectItems"`
+}
+
+// gqlProjectItem is the GraphQL representation of a Projects (v2) item
+type gqlProjectItem struct {
+	ID      string `json:"id"`
+	Project struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		URL    string `json:"url"`
+	} `json:"project"`
+	FieldValues struct {
+		Nodes []gqlFieldValue `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// gqlFieldValue is any of the ProjectV2ItemField*Value types
+type gqlFieldValue struct {
+	Typename string       `json:"__typename"`
+	Field    gqlFieldName `json:"field"`
+	Name     string       `json:"name"`
+	Text     string       `json:"text"`
+	Number   *float64     `json:"number"`
+	Date     string       `json:"date"`
+	Title    string       `json:"title"`
+}
+
+// display returns the human readable value of a project field
+func (v gqlFieldValue) display() string {
+	switch v.Typename {
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return v.Name
+	case "ProjectV2ItemFieldTextValue":
+		return v.Text
+	case "ProjectV2ItemFieldNumberValue":
+		if v.Number != nil {
+			return strconv.FormatFloat(*v.Number, 'f', -1, 64)
+		}
+	case "ProjectV2ItemFieldDateValue":
+		return v.Date
+	case "ProjectV2ItemFieldIterationValue":
+		return v.Title
+	}
+	return ""
+}
+
+// toProjectItem converts a GraphQL project item to the domain model
+func (p gqlProjectItem) toProjectItem() domain.ProjectItem {
+	item := domain.ProjectItem{
+		ID:            p.ID,
+		ProjectNumber: p.Project.Number,
+		ProjectTitle:  p.Project.Title,
+		ProjectURL:    p.Project.URL,
+		Fields:        map[string]string{},
+	}
+	for _, value := range p.FieldValues.Nodes {
+		if value.Field.Name == "" || value.Field.Name == "Title" {
+			continue
+		}
+		if display := value.display(); display != "" {
+			item.Fields[value.Field.Name] = display
+		}
+	}
+	return item
+}
+
+// toIssue converts a GraphQL issue to the domain model
+func (g gqlIssue) toIssue() domain.Issue {
+	issue := domain.Issue{
+		Number:       g.Number,
+		Title:        g.Title,
+		State:        strings.ToLower(g.State),
+		HTMLURL:      g.URL,
+		Body:         g.Body,
+		CreatedAt:    g.CreatedAt,
+		UpdatedAt:    g.UpdatedAt,
+		Labels:       g.Labels.Nodes,
+		CommentCount: g.Comments.TotalCount,
+	}
+	if g.Author != nil {
+		issue.User = domain.User{Login: g.Author.Login}
+	}
+	for _, assignee := range g.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, domain.User{Login: assignee.Login, ID: assignee.DatabaseID})
+	}
+	for _, comment := range g.Comments.Nodes {
+		c := domain.Comment{Body: comment.Body, HTMLURL: comment.URL, CreatedAt: comment.CreatedAt}
+		if comment.Author != nil {
+			c.Author = domain.User{Login: comment.Author.Login}
+		}
+		issue.Comments = append(issue.Comments, c)
+	}
+	for _, pr := range g.ClosedByPullRequestsReferences.Nodes {
+		issue.LinkedPullRequests = append(issue.LinkedPullRequests, domain.PullRequestRef{
+			Number:  pr.Number,
+			Title:   pr.Title,
+			State:   strings.ToLower(pr.State),
+			HTMLURL: pr.URL,
+		})
+	}
+	for _, item := range g.ProjectItems.Nodes {
+		issue.ProjectItems = append(issue.ProjectItems, item.toProjectItem())
+	}
+	return issue
+}
+
+// GetIssues fetches a page of issues with the requested fields in one query
+func (c *GraphQLClient) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error) {
+	variables := map[string]interface{}{
+		"owner": req.Owner,
+		"repo":  req.Repo,
+		"first": req.PerPage,
+	}
+	if req.Cursor != "" {
+		variables["after"] = req.Cursor
+	}
+	switch req.State {
+	case "open":
+		variables["states"] = []string{"OPEN"}
+	case "closed":
+		variables["states"] = []string{"CLOSED"}
+	}
+
+	var data struct {
+		Repository *struct {
+			Issues struct {
+				TotalCount int `json:"totalCount"`
+				PageInfo   struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []gqlIssue `json:"nodes"`
+			} `json:"issues"`
+		} `json:"repository"`
+	}
+
+	cost := estimateCost(connection{first: req.PerPage, nested: newIssueSelection(req.Fields).connections()})
+	if err := c.Execute(BuildIssueListQuery(req.Fields), variables, cost, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	}
+
+	issues := data.Repository.Issues
+	response := &domain.GetIssuesResponse{
+		Issues:     make([]domain.Issue, 0, len(issues.Nodes)),
+		TotalCount: issues.TotalCount,
+	}
+	for _, node := range issues.Nodes {
+		response.Issues = append(response.Issues, node.toIssue())
+	}
+	response.Count = len(response.Issues)
+	if issues.PageInfo.HasNextPage {
+		response.NextCursor = issues.PageInfo.EndCursor
+	}
+
+	return response, nil
+}
+
+// GetIssue fetches a single issue with the requested fields in one query
+func (c *GraphQLClient) GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error) {
+	variables := map[string]interface{}{
+		"owner":  req.Owner,
+		"repo":   req.Repo,
+		"number": req.Number,
+	}
+
+	var data struct {
+		Repository *struct {
+			Issue *gqlIssue `json:"issue"`
+		} `json:"repository"`
+	}
+
+	cost := estimateCost(newIssueSelection(req.Fields).connections()...)
+	if err := c.Execute(BuildIssueDetailQuery(req.Fields), variables, cost, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository == nil || data.Repository.Issue == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("issue #%d in %s/%s", req.Number, req.Owner, req.Repo))
+	}
+
+	issue := data.Repository.Issue.toIssue()
+	return &issue, nil
+}
@@ -0,0 +1,306 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// projectFieldsPageSize is the number of custom fields fetched per project
+const projectFieldsPageSize = 50
+
+// projectQuery selects a project of a user or organization with its fields
+const projectQuery = `query($owner: String!, $number: Int!) {
+  ` + rateLimitSelection + `
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) { ...ProjectFields }
+    }
+  }
+}
+`
+
+// projectFieldsFragment selects a project and the definition of its fields
+var projectFieldsFragment = fmt.Sprintf(`fragment ProjectFields on ProjectV2 {
+  id number title url
+  fields(first: %d) { nodes {
+    ... on ProjectV2FieldCommon { id name dataType }
+    ... on ProjectV2SingleSelectField { options { id name } }
+    ... on ProjectV2IterationField { configuration { iterations { id title } completedIterations { id title } } }
+  } }
+}
+`, projectFieldsPageSize)
+
+// itemContentSelection selects the issue, pull request or draft behind a project item
+const itemContentSelection = `content {
+              __typename
+              ... on Issue { number title state url repository { nameWithOwner } }
+              ... on PullRequest { number title state url repository { nameWithOwner } }
+              ... on DraftIssue { title }
+            }`
+
+// projectItemsQuery selects a page of project items with their field values
+var projectItemsQuery = `query($owner: String!, $number: Int!, $first: Int!, $after: String) {
+  ` + rateLimitSelection + `
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        ...ProjectFields
+        items(first: $first, after: $after) {
+          totalCount
+          pageInfo { hasNextPage endCursor }
+          nodes {
+            id type
+            ` + itemContentSelection + `
+            ` + fieldValuesSelection + `
+          }
+        }
+      }
+    }
+  }
+}
+` + projectFieldsFragment
+
+// projectItemQuery selects a single project item and its content by node ID
+const projectItemQuery = `query($id: ID!) {
+  ` + rateLimitSelection + `
+  node(id: $id) {
+    ... on ProjectV2Item {
+      id type
+      ` + itemContentSelection + `
+    }
+  }
+}`
+
+// updateFieldMutation sets the value of a project item field
+const updateFieldMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $value: ProjectV2FieldValue!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field, value: $value}) {
+    projectV2Item { id }
+  }
+}`
+
+// clearFieldMutation clears the value of a project item field
+const clearFieldMutation = `mutation($project: ID!, $item: ID!, $field: ID!) {
+  clearProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field}) {
+    projectV2Item { id }
+  }
+}`
+
+// gqlProject is the GraphQL representation of a project and its fields
+type gqlProject struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Fields struct {
+		Nodes []struct {
+			ID       string                      `json:"id"`
+			Name     string                      `json:"name"`
+			DataType string                      `json:"dataType"`
+			Options  []domain.ProjectFieldOption `json:"options"`
+			Config   *struct {
+				Iterations          []gqlIteration `json:"iterations"`
+				CompletedIterations []gqlIteration `json:"completedIterations"`
+			} `json:"configuration"`
+		} `json:"nodes"`
+	} `json:"fields"`
+}
+
+// gqlIteration is an iteration of an iteration field
+type gqlIteration struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// toProject converts a GraphQL project to the domain model
+func (g gqlProject) toProject() domain.Project {
+	project := domain.Project{
+		ID:     g.ID,
+		Number: g.Number,
+		Title:  g.Title,
+		URL:    g.URL,
+	}
+	for _, node := range g.Fields.Nodes {
+		if node.ID == "" {
+			continue
+		}
+		field := domain.ProjectField{
+			ID:       node.ID,
+			Name:     node.Name,
+			DataType: node.DataType,
+			Options:  node.Options,
+		}
+		if node.Config != nil {
+			for _, it := range append(node.Config.Iterations, node.Config.CompletedIterations...) {
+				field.Options = append(field.Options, domain.ProjectFieldOption{ID: it.ID, Name: it.Title})
+			}
+		}
+		project.Fields = append(project.Fields, field)
+	}
+	return project
+}
+
+// gqlBoardItem is the GraphQL representation of a project item
+type gqlBoardItem struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Content *struct {
+		Typename   string `json:"__typename"`
+		Number     int    `json:"number"`
+		Title      string `json:"title"`
+		State      string `json:"state"`
+		URL        string `json:"url"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+	} `json:"content"`
+	FieldValues struct {
+		Nodes []gqlFieldValue `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// toBoardItem converts a GraphQL project item to the domain model
+func (g gqlBoardItem) toBoardItem() domain.ProjectBoardItem {
+	item := domain.ProjectBoardItem{
+		ID:     g.ID,
+		Type:   g.Type,
+		Fields: map[string]string{},
+	}
+	if g.Content != nil {
+		item.Title = g.Content.Title
+		item.Number = g.Content.Number
+		item.State = strings.ToLower(g.Content.State)
+		item.URL = g.Content.URL
+		item.Repository = g.Content.Repository.NameWithOwner
+	}
+	for _, value := range g.FieldValues.Nodes {
+		if value.Field.Name == "" || value.Field.Name == "Title" {
+			continue
+		}
+		if display := value.display(); display != "" {
+			item.Fields[value.Field.Name] = display
+		}
+	}
+	return item
+}
+
+// GetProject fetches a project of a user or organization with its field definitions
+func (c *GraphQLClient) GetProject(owner string, number int) (*domain.Project, error) {
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *gqlProject `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+
+	variables := map[string]interface{}{"owner": owner, "number": number}
+	if err := c.Execute(projectQuery+projectFieldsFragment, variables, 1, &data); err != nil {
+		return nil, err
+	}
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("project %d of %s", number, owner))
+	}
+
+	project := data.RepositoryOwner.ProjectV2.toProject()
+	return &project, nil
+}
+
+// ListProjectItems fetches a page of project items with their field values
+func (c *GraphQLClient) ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error) {
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				gqlProject
+				Items struct {
+					TotalCount int `json:"totalCount"`
+					PageInfo   struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []gqlBoardItem `json:"nodes"`
+				} `json:"items"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+
+	variables := map[string]interface{}{
+		"owner":  req.Owner,
+		"number": req.Number,
+		"first":  req.PerPage,
+	}
+	if req.Cursor != "" {
+		variables["after"] = req.Cursor
+	}
+
+	cost := estimateCost(
+		connection{first: projectFieldsPageSize},
+		connection{first: req.PerPage, nested: []connection{{first: fieldValuesPageSize}}},
+	)
+	if err := c.Execute(projectItemsQuery, variables, cost, &data); err != nil {
+		return nil, err
+	}
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("project %d of %s", req.Number, req.Owner))
+	}
+
+	project := data.RepositoryOwner.ProjectV2
+	items := project.Items
+	response := &domain.ListProjectItemsResponse{
+		Project:    project.gqlProject.toProject(),
+		Items:      make([]domain.ProjectBoardItem, 0, len(items.Nodes)),
+		TotalCount: items.TotalCount,
+	}
+	for _, node := range items.Nodes {
+		response.Items = append(response.Items, node.toBoardItem())
+	}
+	response.Count = len(response.Items)
+	if items.PageInfo.HasNextPage {
+		response.NextCursor = items.PageInfo.EndCursor
+	}
+
+	return response, nil
+}
+
+// GetProjectItem fetches a project item by node ID with the repository of its content
+func (c *GraphQLClient) GetProjectItem(itemID string) (*domain.ProjectBoardItem, error) {
+	var data struct {
+		Node *gqlBoardItem `json:"node"`
+	}
+
+	variables := map[string]interface{}{"id": itemID}
+	if err := c.Execute(projectItemQuery, variables, 1, &data); err != nil {
+		return nil, err
+	}
+	if data.Node == nil || data.Node.ID == "" {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("project item %s", itemID))
+	}
+
+	item := data.Node.toBoardItem()
+	return &item, nil
+}
+
+// UpdateProjectItemField sets the value of a project item field
+func (c *GraphQLClient) UpdateProjectItemField(projectID, itemID, fieldID string, value domain.ProjectFieldValue) error {
+	variables := map[string]interface{}{
+		"project": projectID,
+		"item":    itemID,
+		"field":   fieldID,
+		"value":   value,
+	}
+
+	var data struct{}
+	return c.Execute(updateFieldMutation, variables, 1, &data)
+}
+
+// ClearProjectItemField clears the value of a project item field
+func (c *GraphQLClient) ClearProjectItemField(projectID, itemID, fieldID string) error {
+	variables := map[string]interface{}{
+		"project": projectID,
+		"item":    itemID,
+		"field":   fieldID,
+	}
+
+	var data struct{}
+	return c.Execute(clearFieldMutation, variables, 1, &data)
+}
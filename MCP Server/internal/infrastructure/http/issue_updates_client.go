@@ -0,0 +1,129 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// UpdateIssue changes the state, assignees or milestone of an issue
+func (c *GitHubClient) UpdateIssue(req *domain.UpdateIssueRequest) (*domain.Issue, error) {
+	fields := map[string]interface{}{}
+	if req.State != "" {
+		fields["state"] = req.State
+	}
+	if req.StateReason != "" {
+		fields["state_reason"] = req.StateReason
+	}
+	if req.Assignees != nil {
+		fields["assignees"] = req.Assignees
+	}
+	if req.Milestone != nil {
+		if *req.Milestone == 0 {
+			fields["milestone"] = nil
+		} else {
+			fields["milestone"] = *req.Milestone
+		}
+	}
+
+	body, err := encodeJSON(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do("PATCH", fmt.Sprintf("/repos/%s/%s/issues/%d", req.Owner, req.Repo, req.Number), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var issue domain.Issue
+		if err := decodeJSON(resp.Body, &issue, "issue"); err != nil {
+			return nil, err
+		}
+		return &issue, nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("issue #%d in %s/%s", req.Number, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// AddIssueLabels adds labels to an issue, keeping the ones it already has
+func (c *GitHubClient) AddIssueLabels(owner, repo string, number int, labels []string) error {
+	body, err := encodeJSON(map[string][]string{"labels": labels})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do("POST", fmt.Sprintf("/repos/%s/%s/issues/%d/labels", owner, repo, number), nil, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusGone:
+		return errors.NewNotFoundError(fmt.Sprintf("issue #%d in %s/%s", number, owner, repo))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// RemoveIssueLabel removes a label from an issue
+func (c *GitHubClient) RemoveIssueLabel(owner, repo string, number int, label string) error {
+	resp, err := c.do("DELETE", fmt.Sprintf("/repos/%s/%s/issues/%d/labels/%s", owner, repo, number, url.PathEscape(label)), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusGone:
+		return errors.NewNotFoundError(fmt.Sprintf("label %q on issue #%d in %s/%s", label, number, owner, repo))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// SearchIssues runs a GitHub issue search and returns one page of matches
+func (c *GitHubClient) SearchIssues(req *domain.SearchIssuesRequest) (*domain.SearchIssuesResponse, error) {
+	query := url.Values{"q": {req.Query}}
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+
+	resp, err := c.do("GET", "/search/issues", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var result domain.SearchIssuesResponse
+		if err := decodeJSON(resp.Body, &result, "search results"); err != nil {
+			return nil, err
+		}
+		result.NextPage = nextPage(resp)
+		return &result, nil
+	case http.StatusUnprocessableEntity:
+		apiErr := c.handleAPIError(resp)
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid search query: %v", apiErr))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// maxLabelPages bounds how many pages of 100 labels are fetched
+const maxLabelPages = 10
+
+// ListLabels fetches every label of a repository
+func (c *GitHubClient) ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error) {
+	var labels []domain.Label
+
+	for page := 1; page > 0 && page <= maxLabelPages; {
+		query := url.Values{"per_page": {"100"}, "page": {strconv.Itoa(page)}}
+
+		resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/labels", req.Owner, req.Repo), query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var batch []domain.Label
+			err = decodeJSON(resp.Body, &batch, "labels")
+			labels = append(labels, batch...)
+			page = nextPage(resp)
+		case http.StatusNotFound:
+			err = errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+		default:
+			err = c.handleAPIError(resp)
+		}
+		resp.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return labels, nil
+}
+
+// CreateLabel creates a label in a repository
+func (c *GitHubClient) CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error) {
+	body, err := encodeJSON(map[string]string{
+		"name":        req.Name,
+		"color":       req.Color,
+		"description": req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do("POST", fmt.Sprintf("/repos/%s/%s/labels", req.Owner, req.Repo), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var label domain.Label
+		if err := decodeJSON(resp.Body, &label, "label"); err != nil {
+			return nil, err
+		}
+		return &label, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// UpdateLabel changes the name, color or description of a label
+func (c *GitHubClient) UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error) {
+	fields := map[string]string{}
+	if req.NewName != "" {
+		fields["new_name"] = req.NewName
+	}
+	if req.Color != "" {
+		fields["color"] = req.Color
+	}
+	if req.Description != nil {
+		fields["description"] = *req.Description
+	}
+
+	body, err := encodeJSON(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do("PATCH", labelPath(req.Owner, req.Repo, req.Name), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var label domain.Label
+		if err := decodeJSON(resp.Body, &label, "label"); err != nil {
+			return nil, err
+		}
+		return &label, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("label %q in %s/%s", req.Name, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// DeleteLabel deletes a label from a repository
+func (c *GitHubClient) DeleteLabel(req *domain.DeleteLabelRequest) error {
+	resp, err := c.do("DELETE", labelPath(req.Owner, req.Repo, req.Name), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return errors.NewNotFoundError(fmt.Sprintf("label %q in %s/%s", req.Name, req.Owner, req.Repo))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// labelPath builds the API path of a label; names may contain spaces and emoji
+func labelPath(owner, repo, name string) string {
+	return fmt.Sprintf("/repos/%s/%s/labels/%s", owner, repo, url.PathEscape(name))
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// ListMilestones fetches up to 100 milestones of a repository, soonest due first
+func (c *GitHubClient) ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error) {
+	query := url.Values{"per_page": {"100"}, "sort": {"due_on"}, "direction": {"asc"}}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+
+	resp, err := c.do("GET", fmt.Sprintf("/repos/%s/%s/milestones", req.Owner, req.Repo), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var milestones []domain.Milestone
+		if err := decodeJSON(resp.Body, &milestones, "milestones"); err != nil {
+			return nil, err
+		}
+		return milestones, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// CreateMilestone creates a milestone in a repository
+func (c *GitHubClient) CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error) {
+	fields := map[string]string{"title": req.Title}
+	if req.State != "" {
+		fields["state"] = req.State
+	}
+	if req.Description != "" {
+		fields["description"] = req.Description
+	}
+	if req.DueOn != "" {
+		fields["due_on"] = dueOnTimestamp(req.DueOn)
+	}
+
+	body, err := encodeJSON(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do("POST", fmt.Sprintf("/repos/%s/%s/milestones", req.Owner, req.Repo), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var milestone domain.Milestone
+		if err := decodeJSON(resp.Body, &milestone, "milestone"); err != nil {
+			return nil, err
+		}
+		return &milestone, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// UpdateMilestone changes a milestone; a pointer to an empty due date clears it
+func (c *GitHubClient) UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error) {
+	fields := map[string]interface{}{}
+	if req.Title != "" {
+		fields["title"] = req.Title
+	}
+	if req.State != "" {
+		fields["state"] = req.State
+	}
+	if req.Description != nil {
+		fields["description"] = *req.Description
+	}
+	if req.DueOn != nil {
+		if *req.DueOn == "" {
+			fields["due_on"] = nil
+		} else {
+			fields["due_on"] = dueOnTimestamp(*req.DueOn)
+		}
+	}
+
+	body, err := encodeJSON(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do("PATCH", fmt.Sprintf("/repos/%s/%s/milestones/%d", req.Owner, req.Repo, req.Number), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var milestone domain.Milestone
+		if err := decodeJSON(resp.Body, &milestone, "milestone"); err != nil {
+			return nil, err
+		}
+		return &milestone, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("milestone %d in %s/%s", req.Number, req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// DeleteMilestone deletes a milestone
+func (c *GitHubClient) DeleteMilestone(req *domain.DeleteMilestoneRequest) error {
+	resp, err := c.do("DELETE", fmt.Sprintf("/repos/%s/%s/milestones/%d", req.Owner, req.Repo, req.Number), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return errors.NewNotFoundError(fmt.Sprintf("milestone %d in %s/%s", req.Number, req.Owner, req.Repo))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// dueOnTimestamp turns a YYYY-MM-DD due date into the timestamp the API expects
+func dueOnTimestamp(date string) string {
+	return date + "T00:00:00Z"
+}
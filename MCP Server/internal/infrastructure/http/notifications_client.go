@@ -0,0 +1,163 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// nextLinkPattern extracts the page number of the rel="next" Link relation
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ListNotifications fetches a page of the authenticated user's notifications,
+// optionally limited to one repository
+func (c *GitHubClient) ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error) {
+	query := url.Values{}
+	if req.All {
+		query.Set("all", "true")
+	}
+	if req.Participating {
+		query.Set("participating", "true")
+	}
+	if req.Since != "" {
+		query.Set("since", req.Since)
+	}
+	if req.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(req.PerPage))
+	}
+	if req.Page > 1 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+
+	path := "/notifications"
+	if req.Repo != "" {
+		path = fmt.Sprintf("/repos/%s/%s/notifications", req.Owner, req.Repo)
+	}
+
+	resp, err := c.do("GET", path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var notifications []domain.Notification
+		if err := decodeJSON(resp.Body, &notifications, "notifications"); err != nil {
+			return nil, err
+		}
+		return &domain.ListNotificationsResponse{
+			Notifications: notifications,
+			Count:         len(notifications),
+			NextPage:      nextPage(resp),
+		}, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("repository %s/%s", req.Owner, req.Repo))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// GetNotificationThread fetches a single notification thread
+func (c *GitHubClient) GetNotificationThread(threadID string) (*domain.Notification, error) {
+	resp, err := c.do("GET", "/notifications/threads/"+url.PathEscape(threadID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var notification domain.Notification
+		if err := decodeJSON(resp.Body, &notification, "notification thread"); err != nil {
+			return nil, err
+		}
+		return &notification, nil
+	case http.StatusNotFound:
+		return nil, errors.NewNotFoundError(fmt.Sprintf("notification thread %s", threadID))
+	default:
+		return nil, c.handleAPIError(resp)
+	}
+}
+
+// MarkNotificationRead marks a notification thread as read
+func (c *GitHubClient) MarkNotificationRead(threadID string) error {
+	resp, err := c.do("PATCH", "/notifications/threads/"+url.PathEscape(threadID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusResetContent, http.StatusNoContent, http.StatusNotModified:
+		return nil
+	case http.StatusNotFound:
+		return errors.NewNotFoundError(fmt.Sprintf("notification thread %s", threadID))
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// GetNotificationSubject fetches the issue or pull request a notification
+// points to. apiURL is the subject URL returned in the notification.
+func (c *GitHubClient) GetNotificationSubject(apiURL string) (*domain.SubjectDetails, error) {
+	var subject domain.SubjectDetails
+	if err := c.getAPIURL(apiURL, &subject, "notification subject"); err != nil {
+		return nil, err
+	}
+	return &subject, nil
+}
+
+// GetNotificationComment fetches the latest comment of a notification subject
+func (c *GitHubClient) GetNotificationComment(apiURL string) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := c.getAPIURL(apiURL, &comment, "comment"); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// getAPIURL fetches an absolute API URL returned by GitHub and decodes it
+// into v. URLs outside the configured API are refused so the token is never
+// sent elsewhere.
+func (c *GitHubClient) getAPIURL(apiURL string, v interface{}, what string) error {
+	path, ok := strings.CutPrefix(apiURL, c.baseURL)
+	if !ok || !strings.HasPrefix(path, "/") {
+		return errors.NewValidationError(fmt.Sprintf("%s URL %q is not served by %s", what, apiURL, c.baseURL))
+	}
+
+	resp, err := c.do("GET", path, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return decodeJSON(resp.Body, v, what)
+	case http.StatusNotFound, http.StatusGone:
+		return errors.NewNotFoundError(what)
+	default:
+		return c.handleAPIError(resp)
+	}
+}
+
+// nextPage returns the page number of the rel="next" Link relation, 0 if none
+func nextPage(resp *http.Response) int {
+	match := nextLinkPattern.FindStringSubmatch(resp.Header.Get("Link"))
+	if match == nil {
+		return 0
+	}
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return 0
+	}
+	page, _ := strconv.Atoi(next.Query().Get("page"))
+	return page
+}
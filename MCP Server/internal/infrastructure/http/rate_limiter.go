@@ -0,0 +1,123 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"mcp-server/internal/domain"
+	"mcp-server/pkg/errors"
+)
+
+// maxRateLimitWait is the longest a request waits for the rate limit to reset
+const maxRateLimitWait = 60 * time.Second
+
+// RateLimiter tracks the rate limit headers returned by GitHub so requests
+// are held back once the quota is exhausted
+type RateLimiter struct {
+	mu      sync.Mutex
+	limit   domain.RateLimit
+	maxWait time.Duration
+	sleep   func(time.Duration)
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter instance
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		maxWait: maxRateLimitWait,
+		sleep:   time.Sleep,
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent. If the quota resets too far in
+// the future a RATE_LIMITED error is returned instead of waiting.
+func (r *RateLimiter) Wait() error {
+	return r.WaitFor(1)
+}
+
+// WaitFor blocks until cost points of quota are available. GraphQL queries
+// cost a variable number of points; REST requests cost one.
+func (r *RateLimiter) WaitFor(cost int) error {
+	r.mu.Lock()
+	limit := r.limit
+	r.mu.Unlock()
+
+	if limit.Limit == 0 || limit.Remaining >= cost {
+		return nil
+	}
+
+	wait := limit.Reset.Sub(r.now())
+	if wait <= 0 {
+		return nil
+	}
+	if wait > r.maxWait {
+		return errors.NewRateLimitedError(limit.Reset)
+	}
+
+	r.sleep(wait)
+	return nil
+}
+
+// Update records the rate limit headers of a response
+func (r *RateLimiter) Update(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	used, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Used"))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit = domain.RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0),
+		Resource:  resp.Header.Get("X-RateLimit-Resource"),
+	}
+}
+
+// Record stores a rate limit reported in a response body, as the GraphQL
+// rateLimit object does
+func (r *RateLimiter) Record(limit domain.RateLimit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit = limit
+}
+
+// CheckResponse returns a RATE_LIMITED error for primary and secondary
+// rate limit responses
+func (r *RateLimiter) CheckResponse(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err == nil {
+			return errors.NewRateLimitedError(r.now().Add(time.Duration(seconds) * time.Second))
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return errors.NewRateLimitedError(r.Snapshot().Reset)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return errors.NewRateLimitedError(r.now())
+	}
+
+	return nil
+}
+
+// Snapshot returns the last recorded rate limit
+func (r *RateLimiter) Snapshot() domain.RateLimit {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limit
+}
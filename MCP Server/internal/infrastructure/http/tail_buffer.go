@@ -0,0 +1,54 @@
+package http
+
+// tailBuffer is an io.Writer that keeps only the last size bytes written,
+// so a large download can be tailed in constant memory
+type tailBuffer struct {
+	buf   []byte
+	pos   int
+	full  bool
+	total int
+}
+
+// newTailBuffer creates a buffer that keeps the last size bytes
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{buf: make([]byte, size)}
+}
+
+// Write stores p, overwriting the oldest bytes once the buffer is full
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	b.total += n
+
+	size := len(b.buf)
+	if size == 0 {
+		return n, nil
+	}
+	if n >= size {
+		copy(b.buf, p[n-size:])
+		b.pos, b.full = 0, true
+		return n, nil
+	}
+
+	copied := copy(b.buf[b.pos:], p)
+	if copied < n {
+		copy(b.buf, p[copied:])
+	}
+	if b.pos+n >= size {
+		b.full = true
+	}
+	b.pos = (b.pos + n) % size
+	return n, nil
+}
+
+// Bytes returns the kept bytes in the order they were written
+func (b *tailBuffer) Bytes() []byte {
+	if !b.full {
+		return append([]byte(nil), b.buf[:b.pos]...)
+	}
+	return append(append([]byte(nil), b.buf[b.pos:]...), b.buf[:b.pos]...)
+}
+
+// Total returns the number of bytes written, including the discarded ones
+func (b *tailBuffer) Total() int {
+	return b.total
+}
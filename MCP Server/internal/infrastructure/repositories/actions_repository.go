@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// ActionsRepositoryInterface defines the GitHub Actions repository interface
+type ActionsRepositoryInterface interface {
+	ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error)
+	GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.WorkflowRun, error)
+	ListWorkflowJobs(req *domain.GetWorkflowRunRequest) ([]domain.WorkflowJob, error)
+	GetJobLogs(req *domain.GetJobLogsRequest, maxBytes int) ([]byte, int, error)
+	RerunFailedJobs(req *domain.RerunFailedJobsRequest) error
+}
+
+// ActionsRepository implements the Repository pattern for GitHub Actions
+type ActionsRepository struct {
+	client *http.GitHubClient
+}
+
+// NewActionsRepository creates a new ActionsRepository instance
+func NewActionsRepository(client *http.GitHubClient) *ActionsRepository {
+	return &ActionsRepository{
+		client: client,
+	}
+}
+
+// ListWorkflowRuns fetches workflow runs using the HTTP client
+func (r *ActionsRepository) ListWorkflowRuns(req *domain.ListWorkflowRunsRequest) (*domain.ListWorkflowRunsResponse, error) {
+	return r.client.ListWorkflowRuns(req)
+}
+
+// GetWorkflowRun fetches a workflow run using the HTTP client
+func (r *ActionsRepository) GetWorkflowRun(req *domain.GetWorkflowRunRequest) (*domain.WorkflowRun, error) {
+	return r.client.GetWorkflowRun(req)
+}
+
+// ListWorkflowJobs fetches the jobs of a workflow run using the HTTP client
+func (r *ActionsRepository) ListWorkflowJobs(req *domain.GetWorkflowRunRequest) ([]domain.WorkflowJob, error) {
+	return r.client.ListWorkflowJobs(req)
+}
+
+// GetJobLogs downloads the tail of the job logs using the HTTP client
+func (r *ActionsRepository) GetJobLogs(req *domain.GetJobLogsRequest, maxBytes int) ([]byte, int, error) {
+	return r.client.GetJobLogs(req, maxBytes)
+}
+
+// RerunFailedJobs re-runs failed jobs using the HTTP client
+func (r *ActionsRepository) RerunFailedJobs(req *domain.RerunFailedJobsRequest) error {
+	return r.client.RerunFailedJobs(req)
+}
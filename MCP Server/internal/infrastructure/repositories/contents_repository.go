@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// ContentsRepositoryInterface defines the repository contents interface
+type ContentsRepositoryInterface interface {
+	GetFileContents(req *domain.GetFileContentsRequest) (*domain.ContentEntry, []byte, error)
+	ListDirectory(req *domain.ListDirectoryRequest) ([]domain.ContentEntry, error)
+	GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error)
+	SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error)
+}
+
+// ContentsRepository implements the Repository pattern for repository contents
+type ContentsRepository struct {
+	client *http.GitHubClient
+}
+
+// NewContentsRepository creates a new ContentsRepository instance
+func NewContentsRepository(client *http.GitHubClient) *ContentsRepository {
+	return &ContentsRepository{
+		client: client,
+	}
+}
+
+// GetFileContents fetches a file using the HTTP client
+func (r *ContentsRepository) GetFileContents(req *domain.GetFileContentsRequest) (*domain.ContentEntry, []byte, error) {
+	return r.client.GetFileContents(req)
+}
+
+// ListDirectory fetches a directory listing using the HTTP client
+func (r *ContentsRepository) ListDirectory(req *domain.ListDirectoryRequest) ([]domain.ContentEntry, error) {
+	return r.client.ListDirectory(req)
+}
+
+// GetRepoTree fetches a git tree using the HTTP client
+func (r *ContentsRepository) GetRepoTree(req *domain.GetRepoTreeRequest) (*domain.RepoTree, error) {
+	return r.client.GetRepoTree(req)
+}
+
+// SearchCode searches code using the HTTP client
+func (r *ContentsRepository) SearchCode(req *domain.SearchCodeRequest) (*domain.SearchCodeResponse, error) {
+	return r.client.SearchCode(req)
+}
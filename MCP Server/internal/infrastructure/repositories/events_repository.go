@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// EventsRepositoryInterface defines the issue events repository interface
+type EventsRepositoryInterface interface {
+	ListRepoIssueEvents(req *domain.ListRepoPageRequest) (*domain.ListIssueEventsResponse, error)
+	ListRepoIssueComments(req *domain.ListRepoPageRequest) (*domain.ListIssueCommentsResponse, error)
+	ListUpdatedIssues(req *domain.ListRepoPageRequest) (*domain.ListUpdatedIssuesResponse, error)
+}
+
+// EventsRepository implements the Repository pattern for issue events
+type EventsRepository struct {
+	client *http.GitHubClient
+}
+
+// NewEventsRepository creates a new EventsRepository instance
+func NewEventsRepository(client *http.GitHubClient) *EventsRepository {
+	return &EventsRepository{
+		client: client,
+	}
+}
+
+// ListRepoIssueEvents fetches issue events using the HTTP client
+func (r *EventsRepository) ListRepoIssueEvents(req *domain.ListRepoPageRequest) (*domain.ListIssueEventsResponse, error) {
+	return r.client.ListRepoIssueEvents(req)
+}
+
+// ListRepoIssueComments fetches issue comments using the HTTP client
+func (r *EventsRepository) ListRepoIssueComments(req *domain.ListRepoPageRequest) (*domain.ListIssueCommentsResponse, error) {
+	return r.client.ListRepoIssueComments(req)
+}
+
+// ListUpdatedIssues fetches recently updated issues using the HTTP client
+func (r *EventsRepository) ListUpdatedIssues(req *domain.ListRepoPageRequest) (*domain.ListUpdatedIssuesResponse, error) {
+	return r.client.ListUpdatedIssues(req)
+}
@@ -8,21 +8,72 @@ import (
 // GitHubRepositoryInterface defines the repository interface
 type GitHubRepositoryInterface interface {
 	GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error)
+	GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error)
+	GetAuthenticatedUser() (*domain.User, error)
+	SearchIssues(req *domain.SearchIssuesRequest) (*domain.SearchIssuesResponse, error)
+	UpdateIssue(req *domain.UpdateIssueRequest) (*domain.Issue, error)
+	AddIssueLabels(owner, repo string, number int, labels []string) error
+	RemoveIssueLabel(owner, repo string, number int, label string) error
+	RateLimit() domain.RateLimit
 }
 
 // GitHubRepository implements the Repository pattern for GitHub
 type GitHubRepository struct {
-	client *http.GitHubClient
+	client  *http.GitHubClient
+	graphql *http.GraphQLClient
 }
 
 // NewGitHubRepository creates a new GitHubRepository instance
-func NewGitHubRepository(client *http.GitHubClient) *GitHubRepository {
+func NewGitHubRepository(client *http.GitHubClient, graphql *http.GraphQLClient) *GitHubRepository {
 	return &GitHubRepository{
-		client: client,
+		client:  client,
+		graphql: graphql,
 	}
 }
 
-// GetIssues fetches issues using the HTTP client
+// GetIssues fetches issues using the REST client, or the GraphQL client when
+// the requested fields or a cursor need it
 func (r *GitHubRepository) GetIssues(req *domain.GetIssuesRequest) (*domain.GetIssuesResponse, error) {
+	if req.NeedsGraphQL() {
+		return r.graphql.GetIssues(req)
+	}
 	return r.client.GetIssues(req)
 }
+
+// GetIssue fetches a single issue, using GraphQL when the requested fields need it
+func (r *GitHubRepository) GetIssue(req *domain.GetIssueRequest) (*domain.Issue, error) {
+	if domain.NeedsGraphQL(req.Fields) {
+		return r.graphql.GetIssue(req)
+	}
+	return r.client.GetIssue(req)
+}
+
+// GetAuthenticatedUser fetches the user the token belongs to
+func (r *GitHubRepository) GetAuthenticatedUser() (*domain.User, error) {
+	return r.client.GetAuthenticatedUser()
+}
+
+// SearchIssues runs an issue search using the REST client
+func (r *GitHubRepository) SearchIssues(req *domain.SearchIssuesRequest) (*domain.SearchIssuesResponse, error) {
+	return r.client.SearchIssues(req)
+}
+
+// UpdateIssue updates an issue using the REST client
+func (r *GitHubRepository) UpdateIssue(req *domain.UpdateIssueRequest) (*domain.Issue, error) {
+	return r.client.UpdateIssue(req)
+}
+
+// AddIssueLabels adds labels to an issue using the REST client
+func (r *GitHubRepository) AddIssueLabels(owner, repo string, number int, labels []string) error {
+	return r.client.AddIssueLabels(owner, repo, number, labels)
+}
+
+// RemoveIssueLabel removes a label from an issue using the REST client
+func (r *GitHubRepository) RemoveIssueLabel(owner, repo string, number int, label string) error {
+	return r.client.RemoveIssueLabel(owner, repo, number, label)
+}
+
+// RateLimit returns the last REST rate limit status seen by the client
+func (r *GitHubRepository) RateLimit() domain.RateLimit {
+	return r.client.RateLimit()
+}
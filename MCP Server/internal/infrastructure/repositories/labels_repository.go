@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// LabelsRepositoryInterface defines the labels repository interface
+type LabelsRepositoryInterface interface {
+	ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error)
+	CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error)
+	UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error)
+	DeleteLabel(req *domain.DeleteLabelRequest) error
+}
+
+// LabelsRepository implements the Repository pattern for labels
+type LabelsRepository struct {
+	client *http.GitHubClient
+}
+
+// NewLabelsRepository creates a new LabelsRepository instance
+func NewLabelsRepository(client *http.GitHubClient) *LabelsRepository {
+	return &LabelsRepository{
+		client: client,
+	}
+}
+
+// ListLabels fetches labels using the HTTP client
+func (r *LabelsRepository) ListLabels(req *domain.ListLabelsRequest) ([]domain.Label, error) {
+	return r.client.ListLabels(req)
+}
+
+// CreateLabel creates a label using the HTTP client
+func (r *LabelsRepository) CreateLabel(req *domain.CreateLabelRequest) (*domain.Label, error) {
+	return r.client.CreateLabel(req)
+}
+
+// UpdateLabel updates a label using the HTTP client
+func (r *LabelsRepository) UpdateLabel(req *domain.UpdateLabelRequest) (*domain.Label, error) {
+	return r.client.UpdateLabel(req)
+}
+
+// DeleteLabel deletes a label using the HTTP client
+func (r *LabelsRepository) DeleteLabel(req *domain.DeleteLabelRequest) error {
+	return r.client.DeleteLabel(req)
+}
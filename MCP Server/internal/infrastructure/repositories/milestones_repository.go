@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// MilestonesRepositoryInterface defines the milestones repository interface
+type MilestonesRepositoryInterface interface {
+	ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error)
+	CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error)
+	UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error)
+	DeleteMilestone(req *domain.DeleteMilestoneRequest) error
+}
+
+// MilestonesRepository implements the Repository pattern for milestones
+type MilestonesRepository struct {
+	client *http.GitHubClient
+}
+
+// NewMilestonesRepository creates a new MilestonesRepository instance
+func NewMilestonesRepository(client *http.GitHubClient) *MilestonesRepository {
+	return &MilestonesRepository{
+		client: client,
+	}
+}
+
+// ListMilestones fetches milestones using the HTTP client
+func (r *MilestonesRepository) ListMilestones(req *domain.ListMilestonesRequest) ([]domain.Milestone, error) {
+	return r.client.ListMilestones(req)
+}
+
+// CreateMilestone creates a milestone using the HTTP client
+func (r *MilestonesRepository) CreateMilestone(req *domain.CreateMilestoneRequest) (*domain.Milestone, error) {
+	return r.client.CreateMilestone(req)
+}
+
+// UpdateMilestone updates a milestone using the HTTP client
+func (r *MilestonesRepository) UpdateMilestone(req *domain.UpdateMilestoneRequest) (*domain.Milestone, error) {
+	return r.client.UpdateMilestone(req)
+}
+
+// DeleteMilestone deletes a milestone using the HTTP client
+func (r *MilestonesRepository) DeleteMilestone(req *domain.DeleteMilestoneRequest) error {
+	return r.client.DeleteMilestone(req)
+}
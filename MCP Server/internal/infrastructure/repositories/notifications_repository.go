@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// NotificationsRepositoryInterface defines the notifications repository interface
+type NotificationsRepositoryInterface interface {
+	ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error)
+	GetNotificationThread(threadID string) (*domain.Notification, error)
+	GetNotificationSubject(apiURL string) (*domain.SubjectDetails, error)
+	GetNotificationComment(apiURL string) (*domain.Comment, error)
+	MarkNotificationRead(threadID string) error
+}
+
+// NotificationsRepository implements the Repository pattern for notifications
+type NotificationsRepository struct {
+	client *http.GitHubClient
+}
+
+// NewNotificationsRepository creates a new NotificationsRepository instance
+func NewNotificationsRepository(client *http.GitHubClient) *NotificationsRepository {
+	return &NotificationsRepository{
+		client: client,
+	}
+}
+
+// ListNotifications fetches notifications using the HTTP client
+func (r *NotificationsRepository) ListNotifications(req *domain.ListNotificationsRequest) (*domain.ListNotificationsResponse, error) {
+	return r.client.ListNotifications(req)
+}
+
+// GetNotificationThread fetches a notification thread using the HTTP client
+func (r *NotificationsRepository) GetNotificationThread(threadID string) (*domain.Notification, error) {
+	return r.client.GetNotificationThread(threadID)
+}
+
+// GetNotificationSubject fetches a notification subject using the HTTP client
+func (r *NotificationsRepository) GetNotificationSubject(apiURL string) (*domain.SubjectDetails, error) {
+	return r.client.GetNotificationSubject(apiURL)
+}
+
+// GetNotificationComment fetches a comment using the HTTP client
+func (r *NotificationsRepository) GetNotificationComment(apiURL string) (*domain.Comment, error) {
+	return r.client.GetNotificationComment(apiURL)
+}
+
+// MarkNotificationRead marks a thread as read using the HTTP client
+func (r *NotificationsRepository) MarkNotificationRead(threadID string) error {
+	return r.client.MarkNotificationRead(threadID)
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"mcp-server/internal/domain"
+	"mcp-server/internal/infrastructure/http"
+)
+
+// ProjectsRepositoryInterface defines the Projects (v2) repository interface
+type ProjectsRepositoryInterface interface {
+	GetProject(owner string, number int) (*domain.Project, error)
+	ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error)
+	GetProjectItem(itemID string) (*domain.ProjectBoardItem, error)
+	UpdateProjectItemField(projectID, itemID, fieldID string, value domain.ProjectFieldValue) error
+	ClearProjectItemField(projectID, itemID, fieldID string) error
+}
+
+// ProjectsRepository implements the Repository pattern for Projects (v2),
+// which are only available through the GraphQL API
+type ProjectsRepository struct {
+	graphql *http.GraphQLClient
+}
+
+// NewProjectsRepository creates a new ProjectsRepository instance
+func NewProjectsRepository(graphql *http.GraphQLClient) *ProjectsRepository {
+	return &ProjectsRepository{
+		graphql: graphql,
+	}
+}
+
+// GetProject fetches a project and its fields using the GraphQL client
+func (r *ProjectsRepository) GetProject(owner string, number int) (*domain.Project, error) {
+	return r.graphql.GetProject(owner, number)
+}
+
+// ListProjectItems fetches project items using the GraphQL client
+func (r *ProjectsRepository) ListProjectItems(req *domain.ListProjectItemsRequest) (*domain.ListProjectItemsResponse, error) {
+	return r.graphql.ListProjectItems(req)
+}
+
+// GetProjectItem fetches a single project item using the GraphQL client
+func (r *ProjectsRepository) GetProjectItem(itemID string) (*domain.ProjectBoardItem, error) {
+	return r.graphql.GetProjectItem(itemID)
+}
+
+// UpdateProjectItemField sets a field value using the GraphQL client
+func (r *ProjectsRepository) UpdateProjectItemField(projectID, itemID, fieldID string, value domain.ProjectFieldValue) error {
+	return r.graphql.UpdateProjectItemField(projectID, itemID, fieldID, value)
+}
+
+// ClearProjectItemField clears a field value using the GraphQL client
+func (r *ProjectsRepository) ClearProjectItemField(projectID, itemID, fieldID string) error {
+	return r.graphql.ClearProjectItemField(projectID, itemID, fieldID)
+}
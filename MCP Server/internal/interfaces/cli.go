@@ -0,0 +1,75 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-server/internal/domain"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cliClientName identifies command-line calls in the MCP initialization
+const cliClientName = "mcp-server-cli"
+
+// ListTools returns the tools the server registers with the current
+// configuration, as an MCP client would see them
+func (c *Container) ListTools(ctx context.Context) ([]mcp.Tool, error) {
+	mcpClient, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer mcpClient.Close()
+
+	result, err := mcpClient.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// CallTool calls a tool in-process. The call goes through the same argument
+// validation, policy and output budget as calls from MCP clients.
+func (c *Container) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
+	mcpClient, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer mcpClient.Close()
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = arguments
+	return mcpClient.CallTool(ctx, req)
+}
+
+// CheckAuth fetches the user the token belongs to and the rate limit reported with it
+func (c *Container) CheckAuth() (*domain.User, domain.RateLimit, error) {
+	user, err := c.GitHubRepo.GetAuthenticatedUser()
+	if err != nil {
+		return nil, domain.RateLimit{}, err
+	}
+	return user, c.GitHubRepo.RateLimit(), nil
+}
+
+// connect starts an in-process MCP client for a server set up from the container
+func (c *Container) connect(ctx context.Context) (*client.Client, error) {
+	mcpClient, err := client.NewInProcessClient(c.SetupMCPServer(c.Config.ServerName, c.Config.ServerVersion))
+	if err != nil {
+		return nil, err
+	}
+	if err := mcpClient.Start(ctx); err != nil {
+		return nil, fmt.Errorf("starting the in-process client: %w", err)
+	}
+
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: cliClientName, Version: c.Config.ServerVersion}
+	if _, err := mcpClient.Initialize(ctx, initReq); err != nil {
+		mcpClient.Close()
+		return nil, fmt.Errorf("initializing the in-process client: %w", err)
+	}
+
+	return mcpClient, nil
+}
@@ -0,0 +1,1104 @@
+package interfaces_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"mcp-server/internal/application/tools"
+	"mcp-server/internal/config"
+	"mcp-server/internal/interfaces"
+	"mcp-server/internal/testutil/fakegithub"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// testConfig returns a configuration pointing at baseURL with the fake's token
+func testConfig(baseURL string) *config.Config {
+	cfg := config.NewConfig()
+	cfg.GitHubToken = fakegithub.Token
+	cfg.BaseURL = baseURL
+	cfg.DefaultOwner = "acme"
+	cfg.PolicyFile = ""
+	cfg.ReadOnly = false
+	cfg.EnabledTools = nil
+	return cfg
+}
+
+// startMCP runs the MCP server over in-process stdio pipes and returns an
+// initialized client. Everything is shut down when the test ends.
+func startMCP(t *testing.T, cfg *config.Config) *client.Client {
+	t.Helper()
+
+	container, err := interfaces.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("creating container: %v", err)
+	}
+	stdio := server.NewStdioServer(container.SetupMCPServer(cfg.ServerName, cfg.ServerVersion))
+	stdio.SetErrorLogger(log.New(io.Discard, "", 0))
+
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stdio.Listen(ctx, serverIn, serverOut)
+	}()
+
+	mcpClient := client.NewClient(transport.NewIO(clientIn, clientOut, nil))
+	t.Cleanup(func() {
+		mcpClient.Close()
+		cancel()
+		serverOut.Close()
+		<-done
+	})
+
+	if err := mcpClient.Start(ctx); err != nil {
+		t.Fatalf("starting client: %v", err)
+	}
+
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: "e2e-test", Version: "0.0.0"}
+	if _, err := mcpClient.Initialize(ctx, initReq); err != nil {
+		t.Fatalf("initializing: %v", err)
+	}
+
+	return mcpClient
+}
+
+// callTool calls a tool and returns the concatenated text content
+func callTool(t *testing.T, c *client.Client, name string, args map[string]interface{}) (string, bool) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+
+	result, err := c.CallTool(ctx, req)
+	if err != nil {
+		t.Fatalf("calling %s: %v", name, err)
+	}
+
+	var text []string
+	for _, content := range result.Content {
+		if tc, ok := content.(mcp.TextContent); ok {
+			text = append(text, tc.Text)
+		}
+	}
+	return strings.Join(text, "\n"), result.IsError
+}
+
+func TestE2EListTools(t *testing.T) {
+	fake := fakegithub.New(t)
+
+	testCases := []struct {
+		name     string
+		readOnly bool
+		enabled  []string
+		disabled []string
+		expected []string
+		missing  []string
+	}{
+		{"all tools", false, nil, nil, []string{"get_issues", "get_issue", "rerun_failed_jobs", "set_project_field"}, nil},
+		{"read-only", true, nil, nil, []string{"get_issues", "list_project_items", "list_labels", "sync_labels", "list_milestones", "bulk_update_issues"}, []string{"rerun_failed_jobs", "move_project_item", "set_project_field", "mark_notification_read", "create_label", "delete_label", "update_milestone"}},
+		{"enabled list", false, []string{"get_issues"}, nil, []string{"get_issues"}, []string{"get_issue", "search_code"}},
+		{"disabled list", false, nil, []string{"search_code", "sync_labels"}, []string{"get_issues", "get_issue"}, []string{"search_code", "sync_labels"}},
+	}
+
+	for _, tc := range testCases {
+		cfg := testConfig(fake.URL)
+		cfg.ReadOnly = tc.readOnly
+		cfg.EnabledTools = tc.enabled
+		cfg.DisabledTools = tc.disabled
+		c := startMCP(t, cfg)
+
+		result, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+		if err != nil {
+			t.Fatalf("%s: listing tools: %v", tc.name, err)
+		}
+		names := map[string]bool{}
+		for _, tool := range result.Tools {
+			names[tool.Name] = true
+		}
+		for _, name := range tc.expected {
+			if !names[name] {
+				t.Errorf("%s: tool %s is not registered", tc.name, name)
+			}
+		}
+		for _, name := range tc.missing {
+			if names[name] {
+				t.Errorf("%s: tool %s should not be registered", tc.name, name)
+			}
+		}
+	}
+}
+
+func TestE2EGetIssuesOverREST(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "get_issues", map[string]interface{}{
+		"repo":             "api",
+		"state":            "closed",
+		"include_projects": false,
+	})
+	if isError {
+		t.Fatalf("get_issues returned an error: %s", text)
+	}
+
+	for _, expected := range []string{"#4 [closed] Remove the deprecated v1 routes", "#1 [closed] Document the public API", "Found 2 issues in acme/api"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("get_issues output is missing %q:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "[open]") {
+		t.Errorf("get_issues returned open issues for state=closed:\n%s", text)
+	}
+}
+
+func TestE2EGetIssuesOverGraphQL(t *testing.T) {
+	fake := fakegithub.New(t)
+	response, err := os.ReadFile(filepath.Join("testdata", "graphql_issues.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.Handle("POST", "/graphql", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	})
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "get_issues", map[string]interface{}{"repo": "acme/api", "per_page": 1, "include_projects": true})
+	if isError {
+		t.Fatalf("get_issues returned an error: %s", text)
+	}
+
+	for _, expected := range []string{"#5 [open] Health check returns 500", "project #7 Roadmap", "In Progress", `cursor "Y3Vyc29yOjI="`} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("get_issues output is missing %q:\n%s", expected, text)
+		}
+	}
+}
+
+func TestE2EGetIssue(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "get_issue", map[string]interface{}{"repo": "api", "number": 2})
+	if isError {
+		t.Fatalf("get_issue returned an error: %s", text)
+	}
+	for _, expected := range []string{"#2 [open] Login fails with expired refresh token", "opened by bob", "Wait for the token to expire"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("get_issue output is missing %q:\n%s", expected, text)
+		}
+	}
+}
+
+func TestE2EErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setup    func(fake *fakegithub.Server, cfg *config.Config)
+		tool     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{
+			"missing issue", nil,
+			"get_issue", map[string]interface{}{"repo": "api", "number": 99},
+			"NOT_FOUND",
+		},
+		{
+			"denied repository",
+			func(fake *fakegithub.Server, cfg *config.Config) { cfg.DenyRepos = []string{"acme/secret"} },
+			"get_issues", map[string]interface{}{"repo": "secret", "include_projects": false},
+			"FORBIDDEN",
+		},
+		{
+			"invalid token",
+			func(fake *fakegithub.Server, cfg *config.Config) { cfg.GitHubToken = "revoked" },
+			"get_issues", map[string]interface{}{"repo": "api", "include_projects": false},
+			"UNAUTHORIZED",
+		},
+		{
+			"rate limited",
+			func(fake *fakegithub.Server, cfg *config.Config) { fake.SetRateLimit(0, time.Now().Add(time.Hour)) },
+			"get_issues", map[string]interface{}{"repo": "api", "include_projects": false},
+			"RATE_LIMITED",
+		},
+		{
+			"invalid state", nil,
+			"get_issues", map[string]interface{}{"repo": "api", "state": "merged", "include_projects": false},
+			"VALIDATION_ERROR",
+		},
+	}
+
+	for _, tc := range testCases {
+		fake := fakegithub.New(t)
+		cfg := testConfig(fake.URL)
+		if tc.setup != nil {
+			tc.setup(fake, cfg)
+		}
+		c := startMCP(t, cfg)
+
+		text, isError := callTool(t, c, tc.tool, tc.args)
+		if !isError || !strings.Contains(text, tc.expected) {
+			t.Errorf("%s: %s returned (error=%v) %q, expected a %s error", tc.name, tc.tool, isError, text, tc.expected)
+		}
+	}
+}
+
+func TestE2EReplaysCassette(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "get_issues.json")
+	args := map[string]interface{}{"repo": "api", "per_page": 2, "include_projects": false}
+
+	// The cassette is saved when the sub-test ends
+	var recorded string
+	t.Run("record", func(t *testing.T) {
+		fake := fakegithub.New(t)
+		recorder := fakegithub.StartRecorder(t, cassette, fakegithub.Record, fake.URL)
+		recorded, _ = callTool(t, startMCP(t, testConfig(recorder.URL)), "get_issues", args)
+	})
+
+	recorder := fakegithub.StartRecorder(t, cassette, fakegithub.Replay, "")
+	text, isError := callTool(t, startMCP(t, testConfig(recorder.URL)), "get_issues", args)
+	if isError || text != recorded {
+		t.Errorf("replayed get_issues = %q, expected %q", text, recorded)
+	}
+	if !strings.Contains(text, "Found 2 issues in acme/api") {
+		t.Errorf("replayed get_issues output is unexpected:\n%s", text)
+	}
+}
+
+func TestE2EOutputBudget(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	// A small budget shows the first issues, summarizes the rest and returns a cursor
+	args := map[string]interface{}{"repo": "api", "state": "all", "include_projects": false, "max_output_tokens": 160}
+	text, isError := callTool(t, c, "get_issues", args)
+	if isError {
+		t.Fatalf("get_issues returned an error: %s", text)
+	}
+	if !strings.Contains(text, "#5 [open]") || strings.Contains(text, "#1 [closed]") {
+		t.Fatalf("budgeted get_issues did not cut the list:\n%s", text)
+	}
+	if !strings.Contains(text, "more issues not shown") || !strings.Contains(text, "closed 2") {
+		t.Errorf("budgeted get_issues has no overflow summary:\n%s", text)
+	}
+
+	_, cursor, found := strings.Cut(text, `cursor "`)
+	cursor, _, _ = strings.Cut(cursor, `"`)
+	if !found || cursor == "" {
+		t.Fatalf("budgeted get_issues returned no cursor:\n%s", text)
+	}
+
+	// Following the cursors walks through every issue exactly once
+	seen := strings.Count(text, "] ")
+	for i := 0; cursor != "" && i < 10; i++ {
+		args["cursor"] = cursor
+		text, isError = callTool(t, c, "get_issues", args)
+		if isError {
+			t.Fatalf("get_issues with cursor returned an error: %s", text)
+		}
+		seen += strings.Count(text, "] ")
+		cursor = ""
+		if _, rest, ok := strings.Cut(text, `cursor "`); ok {
+			cursor, _, _ = strings.Cut(rest, `"`)
+		}
+	}
+	if seen != 5 {
+		t.Errorf("cursors returned %d issues in total, expected 5", seen)
+	}
+
+	// Budgets smaller than the summary reserve still page one issue at a time
+	for _, tokens := range []int{100, 128} {
+		text, _ = callTool(t, c, "get_issues", map[string]interface{}{"repo": "api", "state": "all", "include_projects": false, "max_output_tokens": tokens})
+		if strings.Count(text, "] ") != 1 || !strings.Contains(text, `cursor "`) {
+			t.Errorf("get_issues with %d tokens did not show one issue and a cursor:\n%s", tokens, text)
+		}
+	}
+
+	// Grouping and body truncation
+	text, _ = callTool(t, c, "get_issues", map[string]interface{}{
+		"repo": "api", "state": "all", "include_projects": false,
+		"fields": "body", "group_by": "state", "max_body_tokens": 5,
+	})
+	for _, expected := range []string{"## state: open (3)", "## state: closed (2)", "[truncated: showing first 20 of"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("grouped get_issues output is missing %q:\n%s", expected, text)
+		}
+	}
+
+	// The safety net caps tools that do not budget their own output
+	text, _ = callTool(t, c, "get_issue", map[string]interface{}{"repo": "api", "number": 2, "max_output_tokens": 10})
+	if !strings.Contains(text, "[output truncated: showing 40 of") {
+		t.Errorf("get_issue output was not capped:\n%s", text)
+	}
+}
+
+func TestE2ENotifications(t *testing.T) {
+	fake := fakegithub.New(t)
+	cfg := testConfig(fake.URL)
+	cfg.DenyRepos = []string{"acme/secret"}
+	c := startMCP(t, cfg)
+
+	testCases := []struct {
+		name     string
+		args     map[string]interface{}
+		expected []string
+		missing  []string
+	}{
+		{"unread inbox", map[string]interface{}{}, []string{"[1001] mention", "[1002] review_requested", "[1003] subscribed"}, []string{"[1004]", "[1005]"}},
+		{"by reason", map[string]interface{}{"reason": "mention,review_requested"}, []string{"[1001]", "[1002]"}, []string{"[1003]"}},
+		{"by repository", map[string]interface{}{"repo": "api", "all": true}, []string{"[1001]", "[1002]", "[1005]"}, []string{"[1003]"}},
+		{"participating", map[string]interface{}{"participating": true}, []string{"[1001]", "[1002]"}, []string{"[1003]"}},
+	}
+
+	for _, tc := range testCases {
+		text, isError := callTool(t, c, "list_notifications", tc.args)
+		if isError {
+			t.Fatalf("%s: list_notifications returned an error: %s", tc.name, text)
+		}
+		for _, expected := range tc.expected {
+			if !strings.Contains(text, expected) {
+				t.Errorf("%s: output is missing %q:\n%s", tc.name, expected, text)
+			}
+		}
+		for _, missing := range tc.missing {
+			if strings.Contains(text, missing) {
+				t.Errorf("%s: output should not contain %q:\n%s", tc.name, missing, text)
+			}
+		}
+	}
+
+	// A thread comes with its subject and latest comment
+	text, _ := callTool(t, c, "get_notification_thread", map[string]interface{}{"thread_id": "1001"})
+	for _, expected := range []string{"Thread 1001 [unread] mention in acme/api", "#2 [open] Login fails", "latest comment by alice", "check whether this also happens on staging"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("get_notification_thread output is missing %q:\n%s", expected, text)
+		}
+	}
+	text, _ = callTool(t, c, "get_notification_thread", map[string]interface{}{"thread_id": 1002})
+	if !strings.Contains(text, "#7 [open] Cache the JWKS keys") || strings.Contains(text, "latest comment") {
+		t.Errorf("get_notification_thread output for a pull request is unexpected:\n%s", text)
+	}
+
+	// Marking a thread as read removes it from the unread inbox
+	if text, isError := callTool(t, c, "mark_notification_read", map[string]interface{}{"thread_id": "1001"}); isError {
+		t.Fatalf("mark_notification_read returned an error: %s", text)
+	}
+	if text, _ := callTool(t, c, "list_notifications", map[string]interface{}{}); strings.Contains(text, "[1001]") {
+		t.Errorf("thread 1001 is still unread:\n%s", text)
+	}
+
+	// Threads of denied repositories cannot be read or marked
+	for _, tool := range []string{"get_notification_thread", "mark_notification_read"} {
+		if text, isError := callTool(t, c, tool, map[string]interface{}{"thread_id": "1004"}); !isError || !strings.Contains(text, "FORBIDDEN") {
+			t.Errorf("%s on a denied repository returned (error=%v) %q", tool, isError, text)
+		}
+	}
+}
+
+func TestE2ELabelsAndMilestones(t *testing.T) {
+	fake := fakegithub.New(t)
+	cfg := testConfig(fake.URL)
+	cfg.DenyRepos = []string{"acme/secret"}
+	c := startMCP(t, cfg)
+
+	text, isError := callTool(t, c, "list_labels", map[string]interface{}{"repo": "api"})
+	if isError || !strings.Contains(text, "bug #d73a4a - Something isn't working") || !strings.Contains(text, "Found 4 labels in acme/api") {
+		t.Errorf("list_labels returned (error=%v):\n%s", isError, text)
+	}
+
+	text, isError = callTool(t, c, "create_label", map[string]interface{}{"repo": "api", "name": "needs triage", "color": "#FBCA04"})
+	if isError || !strings.Contains(text, "Created label needs triage #fbca04") {
+		t.Errorf("create_label returned (error=%v): %s", isError, text)
+	}
+	text, isError = callTool(t, c, "update_label", map[string]interface{}{"repo": "api", "name": "needs triage", "new_name": "triage"})
+	if isError || !strings.Contains(text, "Updated label triage") {
+		t.Errorf("update_label returned (error=%v): %s", isError, text)
+	}
+	if text, isError := callTool(t, c, "delete_label", map[string]interface{}{"repo": "api", "name": "triage"}); isError {
+		t.Errorf("delete_label returned an error: %s", text)
+	}
+	if text, isError := callTool(t, c, "create_label", map[string]interface{}{"repo": "api", "name": "x", "color": "red"}); !isError || !strings.Contains(text, "6-digit hex") {
+		t.Errorf("create_label with an invalid color returned (error=%v) %q", isError, text)
+	}
+
+	text, isError = callTool(t, c, "list_milestones", map[string]interface{}{"repo": "api"})
+	if isError || !strings.Contains(text, "#3 [open] v1.2 (due 2026-11-30, 4 open, 9 closed)") || !strings.Contains(text, "#4 [open] v2.0 (no due date") {
+		t.Errorf("list_milestones returned (error=%v):\n%s", isError, text)
+	}
+	if text, isError := callTool(t, c, "create_milestone", map[string]interface{}{"repo": "api", "title": "v3", "due_on": "next week"}); !isError || !strings.Contains(text, "YYYY-MM-DD") {
+		t.Errorf("create_milestone with an invalid date returned (error=%v) %q", isError, text)
+	}
+
+	spec := `
+repositories: [acme/api, acme/web]
+prune: true
+labels:
+  - name: bug
+    color: "#d73a4a"
+    description: Something isn't working
+  - name: enhancement
+    color: a2eeef
+    description: New feature or request
+  - name: help wanted
+    color: "008672"
+    aliases: [help-wanted]
+`
+
+	// The default dry run reports the diff per repository and changes nothing
+	text, isError = callTool(t, c, "sync_labels", map[string]interface{}{"spec": spec})
+	if isError {
+		t.Fatalf("sync_labels dry run returned an error: %s", text)
+	}
+	for _, expected := range []string{
+		"Dry run", "acme/api: 2 changes, 2 unchanged", "> help-wanted -> help wanted", "- wontfix",
+		"acme/web: 2 changes, 1 unchanged", `~ bug (name "Bug" -> "bug", color #FF0000 -> #d73a4a, description "" -> "Something isn't working")`, "+ help wanted #008672",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("sync_labels dry run is missing %q:\n%s", expected, text)
+		}
+	}
+	if labels := fake.Labels("acme", "api"); len(labels) != 4 || labels[2].Name != "help-wanted" {
+		t.Errorf("dry run changed the labels: %+v", labels)
+	}
+
+	// Applying the spec makes a second run a no-op
+	text, isError = callTool(t, c, "sync_labels", map[string]interface{}{"spec": spec, "dry_run": false})
+	if isError || strings.Contains(text, "failed") || strings.Count(text, "[applied]") != 2 {
+		t.Fatalf("sync_labels returned (error=%v):\n%s", isError, text)
+	}
+	text, _ = callTool(t, c, "sync_labels", map[string]interface{}{"spec": spec, "repos": "api,web"})
+	if !strings.Contains(text, "acme/api: 0 changes, 3 unchanged") || !strings.Contains(text, "acme/web: 0 changes, 3 unchanged") {
+		t.Errorf("sync_labels after applying the spec still reports changes:\n%s", text)
+	}
+
+	// A spec naming a denied repository is rejected before anything runs
+	if text, isError := callTool(t, c, "sync_labels", map[string]interface{}{"spec": spec, "repos": "acme/api,acme/secret"}); !isError || !strings.Contains(text, "FORBIDDEN") {
+		t.Errorf("sync_labels on a denied repository returned (error=%v) %q", isError, text)
+	}
+	if text, isError := callTool(t, c, "sync_labels", map[string]interface{}{"spec": "labels: [{name: bug, colour: red}]"}); !isError || !strings.Contains(text, "invalid label spec") {
+		t.Errorf("sync_labels with an unknown key returned (error=%v) %q", isError, text)
+	}
+}
+
+func TestE2EBulkUpdateIssues(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	// The default dry run previews the change per issue without writing
+	text, isError := callTool(t, c, "bulk_update_issues", map[string]interface{}{
+		"repo": "api", "query": "is:open label:bug", "action": "label", "add_labels": "triage", "remove_labels": "bug",
+	})
+	if isError {
+		t.Fatalf("bulk_update_issues dry run returned an error: %s", text)
+	}
+	for _, expected := range []string{"Dry run of label on acme/api: 2 issues would be changed", "#2 [planned] Login fails with expired refresh token: +triage -bug", "#5 [planned]", "dry_run=false"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("dry run output is missing %q:\n%s", expected, text)
+		}
+	}
+	for _, r := range fake.Requests() {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.Path)
+		}
+	}
+
+	// Executing reports progress for every issue
+	var mu sync.Mutex
+	var progress []map[string]any
+	c.OnNotification(func(n mcp.JSONRPCNotification) {
+		if n.Method == "notifications/progress" {
+			mu.Lock()
+			progress = append(progress, n.Params.AdditionalFields)
+			mu.Unlock()
+		}
+	})
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "bulk_update_issues"
+	req.Params.Meta = &mcp.Meta{ProgressToken: "bulk-1"}
+	req.Params.Arguments = map[string]interface{}{
+		"repo": "api", "query": "is:open label:bug", "action": "label", "add_labels": "triage", "remove_labels": "bug", "dry_run": false, "concurrency": 2,
+	}
+	result, err := c.CallTool(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("bulk_update_issues failed: %v %+v", err, result)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Bulk label on acme/api: 2 updated, 0 failed") {
+		t.Errorf("unexpected report:\n%s", text)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(progress)
+		mu.Unlock()
+		if n == 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	if len(progress) != 2 {
+		t.Fatalf("got %d progress notifications, want 2: %v", len(progress), progress)
+	}
+	for _, p := range progress {
+		if p["progressToken"] != "bulk-1" || p["total"] != float64(2) {
+			t.Errorf("unexpected progress notification %v", p)
+		}
+	}
+	mu.Unlock()
+
+	if text, _ := callTool(t, c, "get_issue", map[string]interface{}{"repo": "api", "number": 2}); !strings.Contains(text, "triage") || strings.Contains(text, "bug") {
+		t.Errorf("issue 2 was not relabeled:\n%s", text)
+	}
+
+	// Issues that already match are unchanged, missing and failing ones are reported
+	fake.Fail(http.MethodPatch, "/repos/acme/api/issues/5", http.StatusInternalServerError, "Server Error")
+	text, _ = callTool(t, c, "bulk_update_issues", map[string]interface{}{
+		"repo": "api", "issues": "1,3,5,99", "action": "close", "state_reason": "not_planned", "dry_run": false,
+	})
+	for _, expected := range []string{"1 updated, 2 failed, 0 skipped, 1 unchanged", "#1 [unchanged]", "#3 [updated] Add rate limiting to the public endpoints: open -> closed as not_planned", "#5 [failed]", "#99 [failed]"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("close report is missing %q:\n%s", expected, text)
+		}
+	}
+
+	text, _ = callTool(t, c, "bulk_update_issues", map[string]interface{}{"repo": "api", "issues": []interface{}{2, 3}, "action": "milestone", "milestone": 3, "dry_run": false})
+	if !strings.Contains(text, "2 updated") || !strings.Contains(text, "milestone: none -> milestone: #3") {
+		t.Errorf("milestone report is unexpected:\n%s", text)
+	}
+	text, _ = callTool(t, c, "bulk_update_issues", map[string]interface{}{"repo": "api", "issues": "2", "action": "milestone", "milestone": "none"})
+	if !strings.Contains(text, "#2 [planned] Login fails with expired refresh token: milestone: #3 v1.2 -> milestone: none") {
+		t.Errorf("milestone preview is unexpected:\n%s", text)
+	}
+
+	// A rate limit below the planned requests is called out in the preview
+	fake.SetRateLimit(1, time.Now().Add(time.Hour))
+	text, _ = callTool(t, c, "bulk_update_issues", map[string]interface{}{"repo": "api", "issues": "2", "action": "reassign", "assignees": "bob,carol"})
+	if !strings.Contains(text, "Warning: the update needs 1 requests but only 0 remain") {
+		t.Errorf("reassign preview has no rate limit warning:\n%s", text)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"repo": "api", "action": "close"},
+		{"repo": "api", "issues": "1", "query": "is:open", "action": "close"},
+		{"repo": "api", "issues": "1", "action": "label"},
+		{"repo": "api", "issues": "1,x", "action": "close"},
+		{"repo": "api", "issues": "1", "action": "lock"},
+		{"repo": "api", "query": "is:open repo:acme/secret", "action": "close"},
+		{"repo": "api", "query": "org:acme label:bug", "action": "close"},
+	} {
+		if text, isError := callTool(t, c, "bulk_update_issues", args); !isError || !strings.Contains(text, "VALIDATION_ERROR") {
+			t.Errorf("bulk_update_issues %v returned (error=%v) %q", args, isError, text)
+		}
+	}
+}
+
+func TestE2EBulkUpdateIssuesStaysInRepository(t *testing.T) {
+	fake := fakegithub.New(t)
+
+	// A search that also returns an issue of another repository
+	var queries []string
+	fake.Handle("GET", "/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"total_count": 2, "items": [
+			{"number": 2, "title": "Login fails with expired refresh token", "state": "open", "repository_url": "%[1]s/repos/acme/api", "labels": []},
+			{"number": 1, "title": "Rotate the deploy keys", "state": "open", "repository_url": "%[1]s/repos/acme/secret", "labels": []}
+		]}`, fake.URL)
+	})
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "bulk_update_issues", map[string]interface{}{"repo": "api", "query": "rotate OR login", "action": "close", "dry_run": false})
+	if isError || !strings.Contains(text, "#2 [updated]") || strings.Contains(text, "Rotate the deploy keys") {
+		t.Errorf("bulk_update_issues returned (error=%v):\n%s", isError, text)
+	}
+	if len(queries) != 1 || queries[0] != "repo:acme/api is:issue rotate OR login" {
+		t.Errorf("search queries = %q", queries)
+	}
+	for _, r := range fake.Requests() {
+		if r.Method != http.MethodGet && r.Path != "/repos/acme/api/issues/2" {
+			t.Errorf("unexpected write %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestE2EToolArguments(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	testCases := []struct {
+		name     string
+		tool     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{"misspelled argument", "get_issues", map[string]interface{}{"repo": "api", "stat": "closed"}, "unknown arguments stat for get_issues"},
+		{"missing required", "get_issue", map[string]interface{}{"number": 2}, "the 'repo' parameter is required"},
+		{"wrong type", "get_issues", map[string]interface{}{"repo": "api", "per_page": "many"}, "the 'per_page' parameter must be a number"},
+	}
+
+	for _, tc := range testCases {
+		text, isError := callTool(t, c, tc.tool, tc.args)
+		if !isError || !strings.Contains(text, "VALIDATION_ERROR") || !strings.Contains(text, tc.expected) {
+			t.Errorf("%s: %s returned (error=%v) %q", tc.name, tc.tool, isError, text)
+		}
+	}
+	if len(fake.Requests()) != 0 {
+		t.Errorf("invalid calls reached GitHub: %+v", fake.Requests())
+	}
+
+	// The output budget argument is accepted by every tool
+	if text, isError := callTool(t, c, "list_labels", map[string]interface{}{"repo": "api", "max_output_tokens": 100}); isError {
+		t.Errorf("list_labels rejected max_output_tokens: %s", text)
+	}
+
+	cfg := testConfig(fake.URL)
+	cfg.DisabledTools = []string{"get_isues"}
+	if _, err := interfaces.NewContainer(cfg); err == nil || !strings.Contains(err.Error(), "unknown tools in configuration: get_isues") {
+		t.Errorf("a misspelled tool name gave %v", err)
+	}
+}
+
+func TestE2EExportIssues(t *testing.T) {
+	fake := fakegithub.New(t)
+	cfg := testConfig(fake.URL)
+	cfg.ExportDir = t.TempDir()
+	c := startMCP(t, cfg)
+
+	text, isError := callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "csv", "state": "all"})
+	if isError {
+		t.Fatalf("csv export returned an error: %s", text)
+	}
+	for _, expected := range []string{
+		"number,title,state,author,assignees,labels,milestone,created_at,updated_at,closed_at,url\n",
+		"2,Login fails with expired refresh token,open,bob,alice,bug;auth,,2025-02-01T08:15:00Z",
+		"5,Health check returns 500 when the cache is down,open,dave,,bug,v2.0,",
+		"1,Document the public API,closed,alice,,docs,,2025-01-10T09:00:00Z,2025-01-20T17:30:00Z,2025-01-20T17:30:00Z,",
+		"Exported 5 issues from acme/api as csv",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("csv export is missing %q:\n%s", expected, text)
+		}
+	}
+
+	// The limit truncates the export and says so
+	text, _ = callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "jsonl", "state": "all", "limit": 3})
+	if lines := strings.Count("\n"+text, "\n{\"number\":"); lines != 3 || !strings.Contains(text, "Exported 3 issues") || !strings.Contains(text, "more issues matched than the limit") {
+		t.Errorf("limited jsonl export has %d issues:\n%s", lines, text)
+	}
+
+	text, _ = callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "jsonl", "labels": "bug", "assignee": "none"})
+	if !strings.Contains(text, `"number":5,`) || strings.Contains(text, `"number":2,`) || !strings.Contains(text, "Exported 1 issues") {
+		t.Errorf("filtered jsonl export is unexpected:\n%s", text)
+	}
+
+	text, _ = callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "markdown", "state": "all", "group_by": "milestone"})
+	for _, expected := range []string{
+		"# Issues in acme/api", "Filters: state all.", "5 issues: 3 open, 2 closed.",
+		"## v2.0 (1)\n\n- [#5](https://github.com/acme/api/issues/5) Health check returns 500 when the cache is down (open, updated 2025-03-04)",
+		"## No milestone (4)", "(open, @alice, updated 2025-02-03)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("markdown report is missing %q:\n%s", expected, text)
+		}
+	}
+	text, _ = callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "markdown"})
+	if !strings.Contains(text, "## bug (2)") || !strings.Contains(text, "## auth (1)") || strings.Index(text, "## auth") > strings.Index(text, "## bug") {
+		t.Errorf("label report is unexpected:\n%s", text)
+	}
+
+	// File exports only appear in the export directory
+	text, isError = callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "markdown", "destination": "file", "filename": "weekly.md"})
+	path := filepath.Join(cfg.ExportDir, "weekly.md")
+	if isError || !strings.Contains(text, "Exported 3 issues from acme/api as markdown to "+path) {
+		t.Fatalf("file export returned (error=%v): %s", isError, text)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "## bug (2)") {
+		t.Errorf("export file %s is unexpected (%v):\n%s", path, err, data)
+	}
+	if text, isError := callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "csv", "destination": "file", "filename": "../escape.csv"}); !isError || !strings.Contains(text, "invalid file name") {
+		t.Errorf("export outside the directory returned (error=%v) %q", isError, text)
+	}
+	if text, isError := callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "xml"}); !isError || !strings.Contains(text, "csv, jsonl or markdown") {
+		t.Errorf("export with an unknown format returned (error=%v) %q", isError, text)
+	}
+	for _, args := range []map[string]interface{}{
+		{"repo": "api", "format": "csv", "query": "repo:acme/secret"},
+		{"repo": "api", "format": "csv", "query": "comments:>1 org:globex", "destination": "file"},
+		{"repo": "api", "format": "csv", "assignee": "alice user:mallory"},
+	} {
+		if text, isError := callTool(t, c, "export_issues", args); !isError || !strings.Contains(text, "VALIDATION_ERROR") {
+			t.Errorf("export_issues %v returned (error=%v) %q", args, isError, text)
+		}
+	}
+	if entries, _ := os.ReadDir(cfg.ExportDir); len(entries) != 1 {
+		t.Errorf("export directory holds %d entries, expected only weekly.md", len(entries))
+	}
+}
+
+func TestE2EExportIssuesStaysInRepository(t *testing.T) {
+	fake := fakegithub.New(t)
+	fake.Handle("GET", "/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"total_count": 2, "items": [
+			{"number": 2, "title": "Login fails with expired refresh token", "state": "open", "repository_url": "%[1]s/repos/acme/api", "labels": []},
+			{"number": 1, "title": "Rotate the deploy keys", "state": "open", "repository_url": "%[1]s/repos/acme/secret", "labels": []}
+		]}`, fake.URL)
+	})
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "export_issues", map[string]interface{}{"repo": "api", "format": "csv"})
+	if isError || !strings.Contains(text, "Login fails") || strings.Contains(text, "Rotate the deploy keys") || !strings.Contains(text, "Exported 1 issues") {
+		t.Errorf("export_issues returned (error=%v):\n%s", isError, text)
+	}
+}
+
+func TestE2ECommandLine(t *testing.T) {
+	fake := fakegithub.New(t)
+	cfg := testConfig(fake.URL)
+	cfg.ReadOnly = true
+	container, err := interfaces.NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("creating container: %v", err)
+	}
+	ctx := context.Background()
+
+	registered, err := container.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("listing tools: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tool := range registered {
+		names[tool.Name] = true
+	}
+	if !names["get_issues"] || !names["export_issues"] || names["create_label"] {
+		t.Errorf("read-only tool list is unexpected: %v", names)
+	}
+
+	def, _ := container.Registry.Lookup("get_issues")
+	arguments, err := tools.ParseCommandLineArguments(def.Tool, []string{"--repo", "api", "--state=all", "--per-page", "2", "--include-projects=false"})
+	if err != nil {
+		t.Fatalf("parsing arguments: %v", err)
+	}
+	result, err := container.CallTool(ctx, "get_issues", arguments)
+	if err != nil || result.IsError {
+		t.Fatalf("calling get_issues: %v %+v", err, result)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "#5 [open] Health check returns 500") {
+		t.Errorf("get_issues returned:\n%s", text)
+	}
+
+	// Calls are validated like calls from MCP clients
+	result, err = container.CallTool(ctx, "get_issues", map[string]interface{}{"repo": "api", "owner ": "acme"})
+	if err != nil || !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "unknown arguments") {
+		t.Errorf("invalid call returned %v %+v", err, result)
+	}
+
+	user, _, err := container.CheckAuth()
+	if err != nil || user.Login != "octo-bot" {
+		t.Errorf("CheckAuth returned %+v, %v", user, err)
+	}
+
+	cfg = testConfig(fake.URL)
+	cfg.GitHubToken = "wrong"
+	if container, err = interfaces.NewContainer(cfg); err != nil {
+		t.Fatalf("creating container: %v", err)
+	}
+	if _, _, err := container.CheckAuth(); err == nil || !strings.Contains(err.Error(), "UNAUTHORIZED") {
+		t.Errorf("CheckAuth with a wrong token returned %v", err)
+	}
+}
+
+func TestE2ERepoEventsSince(t *testing.T) {
+	fake := fakegithub.New(t)
+	c := startMCP(t, testConfig(fake.URL))
+
+	text, isError := callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "since": "2025-02-01", "limit": 8})
+	if isError {
+		t.Fatalf("get_repo_events_since returned an error: %s", text)
+	}
+	for _, expected := range []string{
+		"8 changes in acme/api since 2025-02-01T00:00:00Z",
+		"2025-02-01 08:15 #2 opened by bob (Login fails with expired refresh token)\n2025-02-01 08:16 #2 labeled by bob: bug",
+		"2025-02-02 09:00 #2 assigned by carol: alice",
+		`2025-02-03 10:00 #2 commented by alice: "Reproduced on staging with a token that expired overnight."`,
+		"2025-03-01 09:45 #4 closed by alice",
+		"2025-03-02 07:30 #5 labeled by dave: bug",
+		"More changes are pending",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("change feed is missing %q:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "mentioned") || strings.Contains(text, "#1 ") {
+		t.Errorf("change feed lists events outside the feed or before since:\n%s", text)
+	}
+
+	// The checkpoint resumes after the last change, including changes at the same time
+	checkpoint := extractCheckpoint(t, text)
+	text, _ = callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "checkpoint": checkpoint})
+	if !strings.Contains(text, "2 changes") || !strings.Contains(text, "2025-03-02 07:30 #5 opened by dave") || !strings.Contains(text, "#5 commented by bob") || strings.Contains(text, "More changes") {
+		t.Errorf("resumed change feed is unexpected:\n%s", text)
+	}
+	text, _ = callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "checkpoint": extractCheckpoint(t, text)})
+	if !strings.Contains(text, "0 changes in acme/api since 2025-03-04T16:10:00Z") {
+		t.Errorf("change feed without new changes is unexpected:\n%s", text)
+	}
+
+	text, _ = callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "since": "2025-02-01T00:00:00Z", "types": "closed,commented"})
+	if !strings.Contains(text, "3 changes") || strings.Contains(text, "opened") {
+		t.Errorf("filtered change feed is unexpected:\n%s", text)
+	}
+
+	if text, isError := callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "web", "checkpoint": checkpoint}); !isError || !strings.Contains(text, "the checkpoint belongs to acme/api, not acme/web") {
+		t.Errorf("checkpoint of another repository returned (error=%v) %q", isError, text)
+	}
+	if text, isError := callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "checkpoint": "not-a-checkpoint"}); !isError || !strings.Contains(text, "invalid checkpoint") {
+		t.Errorf("invalid checkpoint returned (error=%v) %q", isError, text)
+	}
+	if text, isError := callTool(t, c, "get_repo_events_since", map[string]interface{}{"repo": "api", "types": "merged"}); !isError || !strings.Contains(text, `unknown change type "merged"`) {
+		t.Errorf("unknown change type returned (error=%v) %q", isError, text)
+	}
+}
+
+// extractCheckpoint returns the checkpoint printed by get_repo_events_since
+func extractCheckpoint(t *testing.T, text string) string {
+	t.Helper()
+
+	_, rest, found := strings.Cut(text, "Checkpoint: ")
+	if !found {
+		t.Fatalf("no checkpoint in:\n%s", text)
+	}
+	checkpoint, _, _ := strings.Cut(rest, "\n")
+	return checkpoint
+}
+
+func TestE2EActions(t *testing.T) {
+	fake := fakegithub.New(t)
+	fake.Handle("GET", "/repos/acme/api/actions/jobs/9102/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		for i := 1; i <= 200; i++ {
+			fmt.Fprintf(w, "2025-03-04T09:05:%02dZ ok  \tacme/api/pkg%03d\t0.01s\n", i%60, i)
+		}
+		io.WriteString(w, "2025-03-04T09:05:55Z --- FAIL: TestRefreshToken (0.02s)\n")
+	})
+	fake.Handle("POST", "/repos/acme/api/actions/runs/4201/rerun-failed-jobs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	cfg := testConfig(fake.URL)
+	cfg.DenyRepos = []string{"acme/secret"}
+	c := startMCP(t, cfg)
+
+	text, isError := callTool(t, c, "list_workflow_runs", map[string]interface{}{"repo": "api"})
+	if isError || !strings.Contains(text, "[4201] CI #88 [completed/failure] fix/token-refresh (pull_request)") || !strings.Contains(text, "Showing 2 of 2 workflow runs in acme/api") {
+		t.Errorf("list_workflow_runs returned (error=%v):\n%s", isError, text)
+	}
+
+	text, isError = callTool(t, c, "get_workflow_run", map[string]interface{}{"repo": "api", "run_id": 4201})
+	for _, expected := range []string{"[4201] CI #88", "job [9101] lint [completed/success]", "job [9102] test [completed/failure]", "failed step 2: go test ./..."} {
+		if isError || !strings.Contains(text, expected) {
+			t.Errorf("get_workflow_run output is missing %q (error=%v):\n%s", expected, isError, text)
+		}
+	}
+
+	// Logs keep their tail, where the failure is
+	text, isError = callTool(t, c, "get_job_logs", map[string]interface{}{"repo": "api", "job_id": 9102, "max_tokens": 100})
+	if isError || !strings.HasPrefix(text, "... [truncated ") || !strings.HasSuffix(text, "--- FAIL: TestRefreshToken (0.02s)\n") || strings.Contains(text, "pkg001") {
+		t.Errorf("get_job_logs returned (error=%v):\n%s", isError, text)
+	}
+
+	text, isError = callTool(t, c, "rerun_failed_jobs", map[string]interface{}{"repo": "api", "run_id": 4201})
+	if isError || !strings.Contains(text, "Re-run of failed jobs requested for run 4201 in acme/api") {
+		t.Errorf("rerun_failed_jobs returned (error=%v): %s", isError, text)
+	}
+
+	// Denied repositories are rejected before GitHub is called
+	for _, call := range []struct {
+		tool string
+		args map[string]interface{}
+	}{
+		{"list_workflow_runs", map[string]interface{}{"repo": "secret"}},
+		{"get_workflow_run", map[string]interface{}{"repo": "secret", "run_id": 5100}},
+		{"get_job_logs", map[string]interface{}{"repo": "secret", "job_id": 9500}},
+		{"rerun_failed_jobs", map[string]interface{}{"repo": "secret", "run_id": 5100}},
+	} {
+		if text, isError := callTool(t, c, call.tool, call.args); !isError || !strings.Contains(text, "FORBIDDEN") {
+			t.Errorf("%s on a denied repository returned (error=%v) %q", call.tool, isError, text)
+		}
+	}
+	for _, r := range fake.Requests() {
+		if strings.HasPrefix(r.Path, "/repos/acme/secret/") {
+			t.Errorf("denied repository was called: %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestE2EContents(t *testing.T) {
+	fake := fakegithub.New(t)
+	cfg := testConfig(fake.URL)
+	cfg.DenyRepos = []string{"acme/secret"}
+	c := startMCP(t, cfg)
+
+	text, isError := callTool(t, c, "get_file_contents", map[string]interface{}{"repo": "api", "path": "README.md"})
+	if isError || text != "# API\n" {
+		t.Errorf("get_file_contents returned (error=%v) %q, expected %q", isError, text, "# API\n")
+	}
+
+	text, isError = callTool(t, c, "list_directory", map[string]interface{}{"repo": "api"})
+	if isError || !strings.HasPrefix(text, "[dir] cmd\n") || !strings.Contains(text, "[file] README.md (6 bytes)") || !strings.Contains(text, "3 entries in acme/api/") {
+		t.Errorf("list_directory returned (error=%v):\n%s", isError, text)
+	}
+
+	text, isError = callTool(t, c, "get_repo_tree", map[string]interface{}{"repo": "api"})
+	for _, expected := range []string{"cmd/\n", "cmd/server/main.go", "4 entries in acme/api@HEAD"} {
+		if isError || !strings.Contains(text, expected) {
+			t.Errorf("get_repo_tree output is missing %q (error=%v):\n%s", expected, isError, text)
+		}
+	}
+
+	// Hits in denied repositories are dropped from an unscoped search
+	text, isError = callTool(t, c, "search_code", map[string]interface{}{"query": "NewServer"})
+	if isError || !strings.Contains(text, "acme/api:cmd/server/main.go") || strings.Contains(text, "acme/secret") {
+		t.Errorf("search_code returned (error=%v):\n%s", isError, text)
+	}
+
+	testCases := []struct {
+		name     string
+		tool     string
+		args     map[string]interface{}
+		expected string
+	}{
+		{"denied file", "get_file_contents", map[string]interface{}{"repo": "secret", "path": "README.md"}, "FORBIDDEN"},
+		{"denied directory", "list_directory", map[string]interface{}{"repo": "secret"}, "FORBIDDEN"},
+		{"denied tree", "get_repo_tree", map[string]interface{}{"repo": "secret"}, "FORBIDDEN"},
+		{"denied search", "search_code", map[string]interface{}{"query": "NewServer", "repo": "secret"}, "FORBIDDEN"},
+		{"path traversal", "get_file_contents", map[string]interface{}{"repo": "api", "path": "../../secret/contents/README.md"}, "VALIDATION_ERROR"},
+		{"directory traversal", "list_directory", map[string]interface{}{"repo": "api", "path": "docs/../../secret"}, "VALIDATION_ERROR"},
+	}
+	for _, tc := range testCases {
+		if text, isError := callTool(t, c, tc.tool, tc.args); !isError || !strings.Contains(text, tc.expected) {
+			t.Errorf("%s: %s returned (error=%v) %q, expected a %s error", tc.name, tc.tool, isError, text, tc.expected)
+		}
+	}
+	for _, r := range fake.Requests() {
+		if strings.Contains(r.Path, "secret") {
+			t.Errorf("denied repository was called: %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+func TestE2EProjects(t *testing.T) {
+	fake := fakegithub.New(t)
+	project, err := os.ReadFile(filepath.Join("testdata", "graphql_project.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Items are looked up by node ID in the project fixture; mutations are recorded
+	var mutations []map[string]interface{}
+	fake.Handle("POST", "/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasPrefix(body.Query, "mutation"):
+			mutations = append(mutations, body.Variables)
+			io.WriteString(w, `{"data": {}}`)
+		case strings.Contains(body.Query, "node(id:"):
+			var data struct {
+				Data struct {
+					RepositoryOwner struct {
+						ProjectV2 struct {
+							Items struct {
+								Nodes []map[string]interface{} `json:"nodes"`
+							} `json:"items"`
+						} `json:"projectV2"`
+					} `json:"repositoryOwner"`
+				} `json:"data"`
+			}
+			json.Unmarshal(project, &data)
+			var node map[string]interface{}
+			for _, item := range data.Data.RepositoryOwner.ProjectV2.Items.Nodes {
+				if item["id"] == body.Variables["id"] {
+					node = item
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"node": node}})
+		default:
+			w.Write(project)
+		}
+	})
+	cfg := testConfig(fake.URL)
+	cfg.DenyRepos = []string{"acme/secret"}
+	c := startMCP(t, cfg)
+
+	// Items from denied repositories are dropped; drafts are kept
+	text, isError := callTool(t, c, "list_project_items", map[string]interface{}{"project": 7})
+	for _, expected := range []string{"[PVTI_2] acme/api#2 Login fails with expired refresh token {Estimate=3, Status=In Progress}", "[PVTI_draft] DRAFT_ISSUE Write the migration guide"} {
+		if isError || !strings.Contains(text, expected) {
+			t.Errorf("list_project_items output is missing %q (error=%v):\n%s", expected, isError, text)
+		}
+	}
+	if strings.Contains(text, "PVTI_9") {
+		t.Errorf("list_project_items returned an item of a denied repository:\n%s", text)
+	}
+
+	text, isError = callTool(t, c, "move_project_item", map[string]interface{}{"project": 7, "item_id": "PVTI_2", "status": "done"})
+	if isError || !strings.Contains(text, `Moved item PVTI_2 to "done"`) {
+		t.Errorf("move_project_item returned (error=%v): %s", isError, text)
+	}
+	text, isError = callTool(t, c, "set_project_field", map[string]interface{}{"project": 7, "item_id": "PVTI_draft", "field": "Estimate", "value": "5"})
+	if isError || !strings.Contains(text, `Set "Estimate" to "5" on item PVTI_draft`) {
+		t.Errorf("set_project_field returned (error=%v): %s", isError, text)
+	}
+	if len(mutations) != 2 || mutations[0]["item"] != "PVTI_2" || mutations[0]["value"].(map[string]interface{})["singleSelectOptionId"] != "opt_done" {
+		t.Fatalf("unexpected mutations: %v", mutations)
+	}
+
+	// Writes to items of denied repositories are rejected before any mutation
+	for _, call := range []struct {
+		tool string
+		args map[string]interface{}
+	}{
+		{"move_project_item", map[string]interface{}{"project": 7, "item_id": "PVTI_9", "status": "Done"}},
+		{"set_project_field", map[string]interface{}{"project": 7, "item_id": "PVTI_9", "field": "Estimate", "value": "1"}},
+	} {
+		if text, isError := callTool(t, c, call.tool, call.args); !isError || !strings.Contains(text, "FORBIDDEN") {
+			t.Errorf("%s on an item of a denied repository returned (error=%v) %q", call.tool, isError, text)
+		}
+	}
+	if text, isError := callTool(t, c, "move_project_item", map[string]interface{}{"project": 7, "item_id": "PVTI_2", "status": "Blocked"}); !isError || !strings.Contains(text, `no option "Blocked"`) {
+		t.Errorf("move_project_item to an unknown status returned (error=%v) %q", isError, text)
+	}
+	if len(mutations) != 2 {
+		t.Errorf("rejected writes sent mutations: %v", mutations[2:])
+	}
+}
@@ -1,6 +1,10 @@
 package interfaces
 
 import (
+	"fmt"
+	"strings"
+
+	"mcp-server/internal/application/policy"
 	"mcp-server/internal/application/services"
 	"mcp-server/internal/application/tools"
 	"mcp-server/internal/config"
@@ -12,48 +16,108 @@ import (
 
 // Container holds all application dependencies
 type Container struct {
-	Config        *config.Config
-	IssueService  services.IssueServiceInterface
-	ToolFactory   *tools.ToolFactory
-	GitHubRepo    repositories.GitHubRepositoryInterface
-	GitHubClient  *http.GitHubClient
+	Config               *config.Config
+	IssueService         services.IssueServiceInterface
+	ToolFactory          *tools.ToolFactory
+	Registry             *tools.Registry
+	GitHubRepo           repositories.GitHubRepositoryInterface
+	ActionsService       services.ActionsServiceInterface
+	ContentsService      services.ContentsServiceInterface
+	ProjectsService      services.ProjectsServiceInterface
+	NotificationsService services.NotificationsServiceInterface
+	LabelsService        services.LabelsServiceInterface
+	MilestonesService    services.MilestonesServiceInterface
+	BulkIssuesService    services.BulkIssuesServiceInterface
+	ExportService        services.ExportServiceInterface
+	EventsService        services.EventsServiceInterface
+	GitHubClient         *http.GitHubClient
+	Enforcer             *policy.Enforcer
 }
 
 // NewContainer creates a new dependency container
-func NewContainer() *Container {
+func NewContainer(cfg *config.Config) (*Container, error) {
 	// Create HTTP client
-	githubClient := http.NewGitHubClient()
-	
-	// Create repository
-	githubRepo := repositories.NewGitHubRepository(githubClient)
-	
-	// Create service
-	issueService := services.NewIssueService(githubRepo)
-	
-	// Create tool factory
-	toolFactory := tools.NewToolFactory(issueService)
+	githubClient := http.NewGitHubClient(cfg.BaseURL, cfg.GitHubToken)
 
-	return &Container{
-		GitHubClient: githubClient,
-		GitHubRepo:   githubRepo,
-		IssueService: issueService,
-		ToolFactory:  toolFactory,
+	// Create repositories
+	graphqlClient := http.NewGraphQLClient(githubClient)
+	githubRepo := repositories.NewGitHubRepository(githubClient, graphqlClient)
+	actionsRepo := repositories.NewActionsRepository(githubClient)
+	contentsRepo := repositories.NewContentsRepository(githubClient)
+	projectsRepo := repositories.NewProjectsRepository(graphqlClient)
+	notificationsRepo := repositories.NewNotificationsRepository(githubClient)
+	labelsRepo := repositories.NewLabelsRepository(githubClient)
+	milestonesRepo := repositories.NewMilestonesRepository(githubClient)
+	eventsRepo := repositories.NewEventsRepository(githubClient)
+
+	// Create policy enforcer; the principal is the user the token belongs to
+	enforcer, err := policy.NewEnforcer(
+		policy.RepositoryRules{Allow: cfg.AllowRepos, Deny: cfg.DenyRepos},
+		cfg.PolicyFile,
+		func() (string, error) {
+			user, err := githubRepo.GetAuthenticatedUser()
+			if err != nil {
+				return "", err
+			}
+			return user.Login, nil
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
+
+	// Create services guarded by the policy
+	issueService := policy.NewIssueService(services.NewIssueService(githubRepo, cfg), enforcer, cfg)
+	actionsService := policy.NewActionsService(services.NewActionsService(actionsRepo, cfg, cfg.ReadOnly), enforcer, cfg)
+	contentsService := policy.NewContentsService(services.NewContentsService(contentsRepo, cfg), enforcer, cfg)
+	projectsService := policy.NewProjectsService(services.NewProjectsService(projectsRepo, cfg, cfg.ReadOnly), enforcer)
+	notificationsService := policy.NewNotificationsService(services.NewNotificationsService(notificationsRepo, cfg, cfg.ReadOnly), enforcer, cfg)
+	labelsService := policy.NewLabelsService(services.NewLabelsService(labelsRepo, cfg, cfg.ReadOnly), enforcer, cfg)
+	milestonesService := policy.NewMilestonesService(services.NewMilestonesService(milestonesRepo, cfg, cfg.ReadOnly), enforcer, cfg)
+	bulkIssuesService := policy.NewBulkIssuesService(services.NewBulkIssuesService(githubRepo, cfg, cfg.ReadOnly), enforcer, cfg)
+	exportService := policy.NewExportService(services.NewExportService(githubRepo, cfg, cfg.ExportDir), enforcer, cfg)
+	eventsService := policy.NewEventsService(services.NewEventsService(eventsRepo, cfg), enforcer, cfg)
+
+	// Create tool factory and registry; unknown tool names in the
+	// configuration are most likely typos
+	toolFactory := tools.NewToolFactory(issueService, actionsService, contentsService, projectsService, notificationsService, labelsService, milestonesService, bulkIssuesService, exportService, eventsService, cfg)
+	registry := tools.NewRegistry(toolFactory.Definitions()...)
+	if unknown := registry.Unknown(append(append([]string(nil), cfg.EnabledTools...), cfg.DisabledTools...)); len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown tools in configuration: %s", strings.Join(unknown, ", "))
+	}
+
+	return &Container{
+		Config:               cfg,
+		GitHubClient:         githubClient,
+		GitHubRepo:           githubRepo,
+		IssueService:         issueService,
+		ActionsService:       actionsService,
+		ContentsService:      contentsService,
+		ProjectsService:      projectsService,
+		NotificationsService: notificationsService,
+		LabelsService:        labelsService,
+		MilestonesService:    milestonesService,
+		BulkIssuesService:    bulkIssuesService,
+		ExportService:        exportService,
+		EventsService:        eventsService,
+		ToolFactory:          toolFactory,
+		Registry:             registry,
+		Enforcer:             enforcer,
+	}, nil
 }
 
-// SetupMCPServer configures the MCP server with all tools
+// SetupMCPServer configures the MCP server with all enabled tools of the
+// registry. Tools that modify GitHub are not registered in read-only mode.
 func (c *Container) SetupMCPServer(name, version string) *server.MCPServer {
 	mcpServer := server.NewMCPServer(
 		name,
 		version,
 		server.WithLogging(),
+		server.WithToolHandlerMiddleware(policy.ToolMiddleware(c.Enforcer)),
+		server.WithToolHandlerMiddleware(tools.OutputBudgetMiddleware(c.Config, c.Registry)),
 	)
 
-	// Create and register get_issues tool
-	getIssuesTool := c.ToolFactory.CreateGetIssuesTool()
-	getIssuesHandler := c.ToolFactory.CreateGetIssuesHandler()
-	
-	mcpServer.AddTool(getIssuesTool, getIssuesHandler)
+	c.Registry.Register(mcpServer, c.Config.ToolEnabled, c.Config.ReadOnly)
 
 	return mcpServer
 }
@@ -0,0 +1,71 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+)
+
+// loadRepoList reads a list fixture of a repository, e.g. issue_events.json
+func (s *Server) loadRepoList(owner, repo, name string) ([]map[string]interface{}, bool) {
+	data, err := fs.ReadFile(s.fixtures, path.Join("repos", owner, repo, name))
+	if err != nil {
+		return nil, false
+	}
+	data = s.rewriteURLs(data)
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		panic(fmt.Sprintf("fakegithub: invalid %s fixture for %s/%s: %v", name, owner, repo, err))
+	}
+	return items, true
+}
+
+// serveIssueEvents lists repos/{owner}/{repo}/issue_events.json newest first,
+// paginated with Link headers. Repositories without the fixture have no events.
+func (s *Server) serveIssueEvents(w http.ResponseWriter, r *http.Request, owner, repo string) {
+	events, _ := s.loadRepoList(owner, repo, "issue_events.json")
+	sort.SliceStable(events, func(i, j int) bool {
+		return fmt.Sprint(events[i]["created_at"]) > fmt.Sprint(events[j]["created_at"])
+	})
+	s.writePage(w, r, events, 30)
+}
+
+// serveIssueComments lists repos/{owner}/{repo}/issue_comments.json oldest
+// first, filtered by the since parameter and paginated with Link headers
+func (s *Server) serveIssueComments(w http.ResponseWriter, r *http.Request, owner, repo string) {
+	comments, _ := s.loadRepoList(owner, repo, "issue_comments.json")
+	since := r.URL.Query().Get("since")
+
+	listed := []map[string]interface{}{}
+	for _, comment := range comments {
+		updated := fmt.Sprint(comment["updated_at"])
+		if comment["updated_at"] == nil {
+			updated = fmt.Sprint(comment["created_at"])
+		}
+		if since == "" || updated >= since {
+			listed = append(listed, comment)
+		}
+	}
+	sort.SliceStable(listed, func(i, j int) bool {
+		return fmt.Sprint(listed[i]["created_at"]) < fmt.Sprint(listed[j]["created_at"])
+	})
+	s.writePage(w, r, listed, 30)
+}
+
+// writePage writes the requested page of items with a Link header
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, items []map[string]interface{}, defaultPerPage int) {
+	perPage := queryInt(r.URL.Query(), "per_page", defaultPerPage)
+	page := queryInt(r.URL.Query(), "page", 1)
+	last := max((len(items)+perPage-1)/perPage, 1)
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	if link := linkHeader(s.URL, r.URL, page, last); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeJSON(w, http.StatusOK, append([]map[string]interface{}{}, items[start:end]...))
+}
@@ -0,0 +1,266 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// issueKey identifies an issue in the edits map
+func issueKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+// findIssue returns an issue, with earlier edits applied, as a generic map
+func (s *Server) findIssue(owner, repo, number string) (map[string]interface{}, int, bool) {
+	issues, _ := s.loadIssues(owner, repo)
+	for _, issue := range issues {
+		if strconv.Itoa(issue.Number) == number {
+			var fields map[string]interface{}
+			json.Unmarshal(issue.raw, &fields)
+			return fields, issue.Number, true
+		}
+	}
+	return nil, 0, false
+}
+
+// saveIssue keeps an edited issue so later reads see the change
+func (s *Server) saveIssue(owner, repo string, number int, fields map[string]interface{}) {
+	raw, _ := json.Marshal(fields)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issueEdits[issueKey(owner, repo, number)] = raw
+}
+
+// editedIssue returns the edited version of an issue, if any
+func (s *Server) editedIssue(owner, repo string, number int) (json.RawMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw, ok := s.issueEdits[issueKey(owner, repo, number)]
+	return raw, ok
+}
+
+// updateIssue applies a PATCH to an issue: state, state_reason, assignees and milestone
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request, owner, repo, number string) {
+	issue, n, ok := s.findIssue(owner, repo, number)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return
+	}
+
+	for field, value := range patch {
+		switch field {
+		case "state", "state_reason":
+			var v string
+			json.Unmarshal(value, &v)
+			issue[field] = v
+		case "assignees":
+			var logins []string
+			json.Unmarshal(value, &logins)
+			assignees := []interface{}{}
+			for _, login := range logins {
+				assignees = append(assignees, map[string]interface{}{"login": login})
+			}
+			issue["assignees"] = assignees
+		case "milestone":
+			var number *int
+			json.Unmarshal(value, &number)
+			if number == nil {
+				issue["milestone"] = nil
+				continue
+			}
+			milestone, found := s.findMilestone(owner, repo, *number)
+			if !found {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+				return
+			}
+			issue["milestone"] = milestone
+		default:
+			writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("the fake does not support updating %q", field))
+			return
+		}
+	}
+
+	s.saveIssue(owner, repo, n, issue)
+	writeJSON(w, http.StatusOK, issue)
+}
+
+// findMilestone looks a milestone up in repos/{owner}/{repo}/milestones.json
+func (s *Server) findMilestone(owner, repo string, number int) (map[string]interface{}, bool) {
+	data, err := fs.ReadFile(s.fixtures, path.Join("repos", owner, repo, "milestones.json"))
+	if err != nil {
+		return nil, false
+	}
+	var milestones []map[string]interface{}
+	json.Unmarshal(data, &milestones)
+	for _, milestone := range milestones {
+		if n, ok := milestone["number"].(float64); ok && int(n) == number {
+			return milestone, true
+		}
+	}
+	return nil, false
+}
+
+// updateIssueLabels adds labels to an issue (POST) or removes one (DELETE)
+func (s *Server) updateIssueLabels(w http.ResponseWriter, r *http.Request, owner, repo, number, name string) {
+	issue, n, ok := s.findIssue(owner, repo, number)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	labels, _ := issue["labels"].([]interface{})
+	has := func(name string) int {
+		for i, label := range labels {
+			if l, ok := label.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(l["name"]), name) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	switch {
+	case r.Method == http.MethodPost && name == "":
+		var body struct {
+			Labels []string `json:"labels"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+		for _, label := range body.Labels {
+			if has(label) < 0 {
+				labels = append(labels, map[string]interface{}{"name": label, "color": "ededed"})
+			}
+		}
+	case r.Method == http.MethodDelete && name != "":
+		i := has(name)
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "Label does not exist")
+			return
+		}
+		labels = append(labels[:i:i], labels[i+1:]...)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	issue["labels"] = labels
+	s.saveIssue(owner, repo, n, issue)
+	writeJSON(w, http.StatusOK, labels)
+}
+
+// searchIssues supports the repo:, is:, state:, no:, label:, milestone:,
+// assignee:, author: and updated:>= qualifiers of the issue search; other
+// terms must appear in the title. Results are paginated with Link headers.
+func (s *Server) searchIssues(w http.ResponseWriter, r *http.Request) {
+	var owner, repo string
+	var filters []func(fixtureIssue, map[string]interface{}) bool
+
+	for _, term := range strings.Fields(r.URL.Query().Get("q")) {
+		qualifier, value, found := strings.Cut(term, ":")
+		value = strings.Trim(value, `"`)
+		switch {
+		case found && qualifier == "repo":
+			owner, repo, _ = strings.Cut(value, "/")
+		case found && (qualifier == "is" || qualifier == "state") && (value == "open" || value == "closed"):
+			state := value
+			filters = append(filters, func(issue fixtureIssue, _ map[string]interface{}) bool { return issue.State == state })
+		case found && qualifier == "is" && value == "issue":
+		case found && qualifier == "no" && (value == "milestone" || value == "assignee" || value == "label"):
+			field := value + "s"
+			if value == "milestone" {
+				field = value
+			}
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				switch v := fields[field].(type) {
+				case nil:
+					return true
+				case []interface{}:
+					return len(v) == 0
+				}
+				return false
+			})
+		case found && (qualifier == "label" || qualifier == "assignee"):
+			field, key, want := qualifier+"s", "name", value
+			if qualifier == "assignee" {
+				key = "login"
+			}
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				items, _ := fields[field].([]interface{})
+				for _, item := range items {
+					if m, ok := item.(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(m[key]), want) {
+						return true
+					}
+				}
+				return false
+			})
+		case found && qualifier == "milestone":
+			title := value
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				milestone, _ := fields["milestone"].(map[string]interface{})
+				return milestone != nil && strings.EqualFold(fmt.Sprint(milestone["title"]), title)
+			})
+		case found && qualifier == "author":
+			login := value
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				user, _ := fields["user"].(map[string]interface{})
+				return user != nil && strings.EqualFold(fmt.Sprint(user["login"]), login)
+			})
+		case found && qualifier == "updated" && strings.HasPrefix(value, ">="):
+			since := strings.TrimPrefix(value, ">=")
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				return fmt.Sprint(fields["updated_at"]) >= since
+			})
+		default:
+			word := strings.ToLower(term)
+			filters = append(filters, func(_ fixtureIssue, fields map[string]interface{}) bool {
+				return strings.Contains(strings.ToLower(fmt.Sprint(fields["title"])), word)
+			})
+		}
+	}
+	if repo == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: the fake needs a repo: qualifier")
+		return
+	}
+
+	issues, _ := s.loadIssues(owner, repo)
+	items := []map[string]interface{}{}
+	for _, issue := range issues {
+		var fields map[string]interface{}
+		json.Unmarshal(issue.raw, &fields)
+		matches := true
+		for _, filter := range filters {
+			matches = matches && filter(issue, fields)
+		}
+		if matches {
+			fields["repository_url"] = fmt.Sprintf("%s/repos/%s/%s", s.URL, owner, repo)
+			items = append(items, fields)
+		}
+	}
+
+	perPage := queryInt(r.URL.Query(), "per_page", 30)
+	page := queryInt(r.URL.Query(), "page", 1)
+	last := max((len(items)+perPage-1)/perPage, 1)
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	if link := linkHeader(s.URL, r.URL, page, last); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count":        len(items),
+		"incomplete_results": false,
+		"items":              items[start:end],
+	})
+}
@@ -0,0 +1,127 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Label is a repository label as the API returns it
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// repoLabels returns the labels of a repository, loading them from
+// repos/{owner}/{repo}/labels.json the first time. Changes made through the
+// API are kept in memory. The caller must hold s.mu.
+func (s *Server) repoLabels(owner, repo string) ([]Label, bool) {
+	key := owner + "/" + repo
+	if labels, ok := s.labels[key]; ok {
+		return labels, true
+	}
+
+	data, err := fs.ReadFile(s.fixtures, path.Join("repos", owner, repo, "labels.json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var labels []Label
+	if err := json.Unmarshal(data, &labels); err != nil {
+		panic(fmt.Sprintf("fakegithub: invalid labels fixture for %s/%s: %v", owner, repo, err))
+	}
+	s.labels[key] = labels
+	return labels, true
+}
+
+// serveLabels lists, creates, updates and deletes labels. Names are matched
+// case-insensitively like on GitHub.
+func (s *Server) serveLabels(w http.ResponseWriter, r *http.Request, owner, repo, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	labels, ok := s.repoLabels(owner, repo)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	index := -1
+	if name != "" {
+		for i, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				index = i
+			}
+		}
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	var fields map[string]*string
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+	}
+	field := func(key, fallback string) string {
+		if value := fields[key]; value != nil {
+			return *value
+		}
+		return fallback
+	}
+	exists := func(candidate string) bool {
+		for i, label := range labels {
+			if i != index && strings.EqualFold(label.Name, candidate) {
+				return true
+			}
+		}
+		return false
+	}
+
+	key := owner + "/" + repo
+	switch {
+	case r.Method == http.MethodGet && name == "":
+		writeJSON(w, http.StatusOK, labels)
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, labels[index])
+	case r.Method == http.MethodPost && name == "":
+		label := Label{Name: field("name", ""), Color: field("color", ""), Description: field("description", "")}
+		if label.Name == "" || exists(label.Name) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+		s.labels[key] = append(labels, label)
+		writeJSON(w, http.StatusCreated, label)
+	case r.Method == http.MethodPatch && name != "":
+		label := labels[index]
+		label.Name = field("new_name", label.Name)
+		label.Color = field("color", label.Color)
+		label.Description = field("description", label.Description)
+		if exists(label.Name) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+		labels[index] = label
+		writeJSON(w, http.StatusOK, label)
+	case r.Method == http.MethodDelete && name != "":
+		s.labels[key] = append(labels[:index:index], labels[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// Labels returns the current labels of a repository
+func (s *Server) Labels(owner, repo string) []Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	labels, _ := s.repoLabels(owner, repo)
+	return append([]Label(nil), labels...)
+}
@@ -0,0 +1,243 @@
+package fakegithub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// Mode selects whether a Recorder talks to GitHub or replays a cassette
+type Mode int
+
+const (
+	// Replay serves the interactions stored in the cassette and nothing else
+	Replay Mode = iota
+	// Record forwards requests upstream and stores the responses in the cassette
+	Record
+)
+
+// RecordEnv is the environment variable that switches recorders to Record mode
+const RecordEnv = "MCP_RECORD"
+
+// recordedHeaders are the response headers kept in a cassette. Everything
+// else, including anything that could identify the token, is dropped.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Location",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"X-RateLimit-Resource",
+	"X-RateLimit-Used",
+}
+
+// forwardedHeaders are the request headers passed upstream while recording
+var forwardedHeaders = []string{"Authorization", "Accept", "Content-Type"}
+
+// Interaction is a recorded request and the response it got
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request; the Authorization header is never stored
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"` // path and query
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the part of a response replayed to the client
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+// Cassette is the on-disk list of interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.Handler that either proxies to GitHub and records the
+// traffic, or replays it. Point the client's base URL at a server running it.
+type Recorder struct {
+	mode     Mode
+	path     string
+	upstream string
+	client   *http.Client
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// ModeFromEnv returns Record when MCP_RECORD is set to a non-empty value
+func ModeFromEnv() Mode {
+	if os.Getenv(RecordEnv) != "" {
+		return Record
+	}
+	return Replay
+}
+
+// NewRecorder creates a recorder for the cassette at path. In Replay mode the
+// cassette must exist; upstream is only used in Record mode.
+func NewRecorder(path string, mode Mode, upstream string) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		upstream: upstream,
+		client:   &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }},
+	}
+	if mode == Record {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// StartRecorder starts a server running a recorder for the cassette. In
+// Record mode the cassette is written when the test ends.
+func StartRecorder(t testing.TB, path string, mode Mode, upstream string) *httptest.Server {
+	r, err := NewRecorder(path, mode, upstream)
+	if err != nil {
+		t.Fatalf("creating recorder: %v", err)
+	}
+
+	server := httptest.NewServer(r)
+	t.Cleanup(func() {
+		server.Close()
+		if err := r.Save(); err != nil {
+			t.Errorf("saving cassette: %v", err)
+		}
+	})
+	return server
+}
+
+// ServeHTTP records or replays a single request
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recorded := RecordedRequest{Method: req.Method, URL: req.URL.RequestURI(), Body: string(body)}
+
+	var response RecordedResponse
+	if r.mode == Record {
+		response, err = r.forward(req, recorded)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	} else {
+		var ok bool
+		if response, ok = r.next(recorded); !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no recorded interaction for %s %s", req.Method, recorded.URL))
+			return
+		}
+	}
+
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	w.WriteHeader(response.Status)
+	io.WriteString(w, response.Body)
+}
+
+// forward sends the request upstream and appends the interaction to the cassette
+func (r *Recorder) forward(req *http.Request, recorded RecordedRequest) (RecordedResponse, error) {
+	upstreamReq, err := http.NewRequest(req.Method, r.upstream+recorded.URL, bytes.NewReader([]byte(recorded.Body)))
+	if err != nil {
+		return RecordedResponse{}, err
+	}
+	for _, name := range forwardedHeaders {
+		if value := req.Header.Get(name); value != "" {
+			upstreamReq.Header.Set(name, value)
+		}
+	}
+
+	resp, err := r.client.Do(upstreamReq)
+	if err != nil {
+		return RecordedResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return RecordedResponse{}, err
+	}
+
+	response := RecordedResponse{Status: resp.StatusCode, Headers: map[string]string{}, Body: string(body)}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			response.Headers[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+
+	return response, nil
+}
+
+// next returns the first unused interaction matching the request. Repeated
+// requests are replayed in the order they were recorded; the last match is
+// reused once all of them have been served.
+func (r *Recorder) next(recorded RecordedRequest) (RecordedResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction.Response, true
+		}
+		last = i
+	}
+	if last >= 0 {
+		return r.cassette.Interactions[last].Response, true
+	}
+	return RecordedResponse{}, false
+}
+
+// Save writes the cassette in Record mode; it does nothing when replaying
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
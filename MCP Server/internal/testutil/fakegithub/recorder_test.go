@@ -0,0 +1,119 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// get performs an authenticated GET and returns the status and body
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "token "+Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "issues.json")
+	upstream := New(t)
+
+	// Record against the fake, then replay without it
+	var recorded string
+	t.Run("record", func(t *testing.T) {
+		recorder := StartRecorder(t, cassette, Record, upstream.URL)
+		status, body := get(t, recorder.URL+"/repos/acme/api/issues?per_page=2")
+		if status != http.StatusOK {
+			t.Fatalf("recorded status = %d, expected 200", status)
+		}
+		recorded = body
+	})
+	upstream.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("cassette was not written: %v", err)
+	}
+	if strings.Contains(string(data), Token) {
+		t.Error("cassette contains the token")
+	}
+
+	var saved Cassette
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("decoding cassette: %v", err)
+	}
+	if len(saved.Interactions) != 1 || saved.Interactions[0].Response.Headers["Link"] == "" {
+		t.Errorf("cassette = %+v, expected one interaction with a Link header", saved)
+	}
+
+	recorder := StartRecorder(t, cassette, Replay, "")
+	status, body := get(t, recorder.URL+"/repos/acme/api/issues?per_page=2")
+	if status != http.StatusOK || body != recorded {
+		t.Errorf("replayed %d %q, expected 200 %q", status, body, recorded)
+	}
+
+	status, _ = get(t, recorder.URL+"/repos/acme/api/issues?per_page=3")
+	if status != http.StatusNotFound {
+		t.Errorf("unrecorded request status = %d, expected 404", status)
+	}
+}
+
+func TestIssuesArePaginatedWithLinkHeaders(t *testing.T) {
+	fake := New(t)
+
+	testCases := []struct {
+		query    string
+		count    int
+		relation []string
+	}{
+		{"state=all&per_page=2", 2, []string{`rel="next"`, `rel="last"`}},
+		{"state=all&per_page=2&page=2", 2, []string{`rel="next"`, `rel="prev"`}},
+		{"state=all&per_page=2&page=3", 1, []string{`rel="first"`, `rel="prev"`}},
+		{"state=all", 5, nil},
+	}
+
+	for _, tc := range testCases {
+		req, _ := http.NewRequest("GET", fake.URL+"/repos/acme/api/issues?"+tc.query, nil)
+		req.Header.Set("Authorization", "token "+Token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var issues []json.RawMessage
+		json.NewDecoder(resp.Body).Decode(&issues)
+		resp.Body.Close()
+
+		if len(issues) != tc.count {
+			t.Errorf("%s: got %d issues, expected %d", tc.query, len(issues), tc.count)
+		}
+		link := resp.Header.Get("Link")
+		for _, rel := range tc.relation {
+			if !strings.Contains(link, rel) {
+				t.Errorf("%s: Link %q is missing %s", tc.query, link, rel)
+			}
+		}
+		if tc.relation == nil && link != "" {
+			t.Errorf("%s: unexpected Link %q", tc.query, link)
+		}
+	}
+}
@@ -0,0 +1,481 @@
+// Package fakegithub provides an in-process fake of the GitHub REST API for
+// tests. It serves issues, notifications, labels, users and arbitrary GET
+// resources from fixture files, keeps issue and label changes in memory,
+// answers simple issue searches, paginates lists the way GitHub does, returns
+// rate limit headers on every response and can be told to fail specific
+// requests.
+package fakegithub
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Token is the only token the fake accepts; other tokens get a 401
+const Token = "fake-github-token"
+
+// defaultRateLimit is the request budget the fake starts with
+const defaultRateLimit = 5000
+
+// fixtureBaseURL is replaced by the fake's own URL in served fixtures, so API
+// URLs embedded in responses point back at the fake
+const fixtureBaseURL = "https://api.github.com"
+
+//go:embed fixtures
+var defaultFixtures embed.FS
+
+// Request is a request received by the fake
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// failure is a canned error response for a method and path
+type failure struct {
+	status  int
+	message string
+	headers map[string]string
+}
+
+// Server is a fake GitHub API backed by fixtures
+type Server struct {
+	*httptest.Server
+
+	fixtures fs.FS
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	failures map[string]failure
+	requests []Request
+	read     map[string]bool
+	labels   map[string][]Label
+	// issueEdits holds issues changed through the API, keyed by owner/repo#number
+	issueEdits map[string]json.RawMessage
+	limit      int
+	remaining  int
+	reset      time.Time
+}
+
+// New starts a fake serving the built-in fixtures. It is closed when the test ends.
+func New(t testing.TB) *Server {
+	fixtures, err := fs.Sub(defaultFixtures, "fixtures")
+	if err != nil {
+		t.Fatalf("loading fixtures: %v", err)
+	}
+	return NewWithFixtures(t, fixtures)
+}
+
+// NewWithFixtures starts a fake serving the given fixtures. The layout mirrors
+// the API: repos/{owner}/{repo}/issues.json holds every issue of a repository,
+// repos/{owner}/{repo}/labels.json its labels, notifications.json the whole
+// inbox, user.json the authenticated user and {path}.json any other GET resource.
+func NewWithFixtures(t testing.TB, fixtures fs.FS) *Server {
+	s := &Server{
+		fixtures:   fixtures,
+		handlers:   map[string]http.HandlerFunc{},
+		failures:   map[string]failure{},
+		read:       map[string]bool{},
+		labels:     map[string][]Label{},
+		issueEdits: map[string]json.RawMessage{},
+		limit:      defaultRateLimit,
+		remaining:  defaultRateLimit,
+		reset:      time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Handle registers a handler for a method and path, taking precedence over
+// the fixtures. It is used for endpoints the fake does not model, like GraphQL.
+func (s *Server) Handle(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// Fail makes every request to method and path answer with status and a
+// GitHub-style error message
+func (s *Server) Fail(method, path string, status int, message string) {
+	s.FailWithHeaders(method, path, status, message, nil)
+}
+
+// FailWithHeaders is like Fail and also sets response headers, e.g. Retry-After
+func (s *Server) FailWithHeaders(method, path string, status int, message string, headers map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method+" "+path] = failure{status: status, message: message, headers: headers}
+}
+
+// SetRateLimit sets the remaining request budget and when it resets. Once the
+// budget is exhausted every request gets a 403 like on GitHub.
+func (s *Server) SetRateLimit(remaining int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining = remaining
+	s.reset = reset.Truncate(time.Second)
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// serveHTTP authenticates the request, applies the rate limit and dispatches it
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()})
+	handler := s.handlers[key]
+	fail, failing := s.failures[key]
+	exhausted := s.remaining <= 0
+	if !exhausted {
+		s.remaining--
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(max(s.remaining, 0)))
+	w.Header().Set("X-RateLimit-Used", strconv.Itoa(s.limit-max(s.remaining, 0)))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	w.Header().Set("X-RateLimit-Resource", "core")
+	s.mu.Unlock()
+
+	if !authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Bad credentials")
+		return
+	}
+	if exhausted {
+		writeError(w, http.StatusForbidden, "API rate limit exceeded")
+		return
+	}
+	if failing {
+		for name, value := range fail.headers {
+			w.Header().Set(name, value)
+		}
+		writeError(w, fail.status, fail.message)
+		return
+	}
+	if handler != nil {
+		handler(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if r.Method == http.MethodPatch && len(parts) == 3 && parts[0] == "notifications" && parts[1] == "threads" {
+		s.markRead(w, parts[2])
+		return
+	}
+	if len(parts) >= 4 && len(parts) <= 5 && parts[0] == "repos" && parts[3] == "labels" {
+		name := ""
+		if len(parts) == 5 {
+			name = parts[4]
+		}
+		s.serveLabels(w, r, parts[1], parts[2], name)
+		return
+	}
+	if r.Method == http.MethodPatch && len(parts) == 5 && parts[0] == "repos" && parts[3] == "issues" {
+		s.updateIssue(w, r, parts[1], parts[2], parts[4])
+		return
+	}
+	if len(parts) >= 6 && len(parts) <= 7 && parts[0] == "repos" && parts[3] == "issues" && parts[5] == "labels" {
+		name := ""
+		if len(parts) == 7 {
+			name = parts[6]
+		}
+		s.updateIssueLabels(w, r, parts[1], parts[2], parts[4], name)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && parts[0] == "search" && parts[1] == "issues":
+		s.searchIssues(w, r)
+	case len(parts) == 1 && parts[0] == "notifications":
+		s.serveNotifications(w, r, "")
+	case len(parts) == 4 && parts[0] == "repos" && parts[3] == "notifications":
+		s.serveNotifications(w, r, parts[1]+"/"+parts[2])
+	case len(parts) == 3 && parts[0] == "notifications" && parts[1] == "threads":
+		s.serveThread(w, parts[2])
+	case len(parts) == 4 && parts[0] == "repos" && parts[3] == "issues":
+		s.serveIssues(w, r, parts[1], parts[2])
+	case len(parts) == 5 && parts[0] == "repos" && parts[3] == "issues" && parts[4] == "events":
+		s.serveIssueEvents(w, r, parts[1], parts[2])
+	case len(parts) == 5 && parts[0] == "repos" && parts[3] == "issues" && parts[4] == "comments":
+		s.serveIssueComments(w, r, parts[1], parts[2])
+	case len(parts) == 5 && parts[0] == "repos" && parts[3] == "issues":
+		s.serveIssue(w, parts[1], parts[2], parts[4])
+	default:
+		s.serveFixture(w, r.URL.Path)
+	}
+}
+
+// authorized accepts the REST ("token") and GraphQL ("bearer") header forms
+func authorized(r *http.Request) bool {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return (strings.EqualFold(scheme, "token") || strings.EqualFold(scheme, "bearer")) && token == Token
+}
+
+// fixtureIssue keeps the raw fixture next to the fields the fake filters on
+type fixtureIssue struct {
+	Number    int    `json:"number"`
+	State     string `json:"state"`
+	UpdatedAt string `json:"updated_at"`
+	raw       json.RawMessage
+}
+
+// loadIssues reads the issues of a repository, newest first
+func (s *Server) loadIssues(owner, repo string) ([]fixtureIssue, bool) {
+	data, err := fs.ReadFile(s.fixtures, path.Join("repos", owner, repo, "issues.json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		panic(fmt.Sprintf("fakegithub: invalid issues fixture for %s/%s: %v", owner, repo, err))
+	}
+
+	issues := make([]fixtureIssue, 0, len(raws))
+	for _, raw := range raws {
+		var issue fixtureIssue
+		if err := json.Unmarshal(raw, &issue); err != nil {
+			panic(fmt.Sprintf("fakegithub: invalid issue in %s/%s: %v", owner, repo, err))
+		}
+		if edited, ok := s.editedIssue(owner, repo, issue.Number); ok {
+			json.Unmarshal(edited, &issue)
+			raw = edited
+		}
+		issue.raw = raw
+		issues = append(issues, issue)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number > issues[j].Number })
+	return issues, true
+}
+
+// serveIssues lists issues filtered by state and since, paginated with Link headers
+func (s *Server) serveIssues(w http.ResponseWriter, r *http.Request, owner, repo string) {
+	issues, ok := s.loadIssues(owner, repo)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	query := r.URL.Query()
+	state := query.Get("state")
+	if state == "" {
+		state = "open"
+	}
+	since := query.Get("since")
+	filtered := make([]json.RawMessage, 0, len(issues))
+	for _, issue := range issues {
+		if (state == "all" || issue.State == state) && issue.UpdatedAt >= since {
+			filtered = append(filtered, issue.raw)
+		}
+	}
+
+	perPage := queryInt(query, "per_page", 30)
+	page := queryInt(query, "page", 1)
+	last := max((len(filtered)+perPage-1)/perPage, 1)
+
+	start := min((page-1)*perPage, len(filtered))
+	end := min(start+perPage, len(filtered))
+
+	if link := linkHeader(s.URL, r.URL, page, last); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeJSON(w, http.StatusOK, filtered[start:end])
+}
+
+// serveIssue returns a single issue by number
+func (s *Server) serveIssue(w http.ResponseWriter, owner, repo, number string) {
+	issues, _ := s.loadIssues(owner, repo)
+	for _, issue := range issues {
+		if strconv.Itoa(issue.Number) == number {
+			writeJSON(w, http.StatusOK, issue.raw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// fixtureNotification keeps the raw fixture next to the fields the fake filters on
+type fixtureNotification struct {
+	ID         string `json:"id"`
+	Reason     string `json:"reason"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	raw map[string]interface{}
+}
+
+// loadNotifications reads the inbox and applies the threads marked as read
+func (s *Server) loadNotifications() []fixtureNotification {
+	data, err := fs.ReadFile(s.fixtures, "notifications.json")
+	if err != nil {
+		return nil
+	}
+	data = s.rewriteURLs(data)
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		panic(fmt.Sprintf("fakegithub: invalid notifications fixture: %v", err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notifications := make([]fixtureNotification, 0, len(raws))
+	for _, raw := range raws {
+		var n fixtureNotification
+		if err := json.Unmarshal(raw, &n); err != nil {
+			panic(fmt.Sprintf("fakegithub: invalid notification: %v", err))
+		}
+		json.Unmarshal(raw, &n.raw)
+		if s.read[n.ID] {
+			n.raw["unread"] = false
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications
+}
+
+// participatingReasons approximate GitHub's "participating" filter
+var participatingReasons = map[string]bool{
+	"assign": true, "author": true, "comment": true, "mention": true,
+	"review_requested": true, "state_change": true, "team_mention": true,
+}
+
+// serveNotifications lists the inbox, optionally for one repository. Read
+// threads are only listed with all=true.
+func (s *Server) serveNotifications(w http.ResponseWriter, r *http.Request, repository string) {
+	query := r.URL.Query()
+	all := query.Get("all") == "true"
+	participating := query.Get("participating") == "true"
+
+	var listed []map[string]interface{}
+	for _, n := range s.loadNotifications() {
+		if repository != "" && n.Repository.FullName != repository {
+			continue
+		}
+		if !all && n.raw["unread"] == false {
+			continue
+		}
+		if participating && !participatingReasons[n.Reason] {
+			continue
+		}
+		listed = append(listed, n.raw)
+	}
+
+	perPage := queryInt(query, "per_page", 50)
+	page := queryInt(query, "page", 1)
+	last := max((len(listed)+perPage-1)/perPage, 1)
+	start := min((page-1)*perPage, len(listed))
+	end := min(start+perPage, len(listed))
+
+	if link := linkHeader(s.URL, r.URL, page, last); link != "" {
+		w.Header().Set("Link", link)
+	}
+	writeJSON(w, http.StatusOK, append([]map[string]interface{}{}, listed[start:end]...))
+}
+
+// serveThread returns a single notification thread
+func (s *Server) serveThread(w http.ResponseWriter, id string) {
+	for _, n := range s.loadNotifications() {
+		if n.ID == id {
+			writeJSON(w, http.StatusOK, n.raw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// markRead marks a notification thread as read
+func (s *Server) markRead(w http.ResponseWriter, id string) {
+	for _, n := range s.loadNotifications() {
+		if n.ID == id {
+			s.mu.Lock()
+			s.read[id] = true
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusResetContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// serveFixture returns {path}.json with API URLs pointing at the fake
+func (s *Server) serveFixture(w http.ResponseWriter, urlPath string) {
+	data, err := fs.ReadFile(s.fixtures, strings.Trim(urlPath, "/")+".json")
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.rewriteURLs(data))
+}
+
+// rewriteURLs points the API URLs of a fixture at the fake
+func (s *Server) rewriteURLs(data []byte) []byte {
+	return []byte(strings.ReplaceAll(string(data), fixtureBaseURL, s.URL))
+}
+
+// linkHeader builds the next/prev/first/last relations GitHub returns on lists
+func linkHeader(base string, u *url.URL, page, last int) string {
+	link := func(p int, rel string) string {
+		query := u.Query()
+		query.Set("page", strconv.Itoa(p))
+		return fmt.Sprintf(`<%s%s?%s>; rel="%s"`, base, u.Path, query.Encode(), rel)
+	}
+
+	var links []string
+	if page < last {
+		links = append(links, link(page+1, "next"), link(last, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(page-1, "prev"))
+	}
+	return strings.Join(links, ", ")
+}
+
+// queryInt reads a positive integer query parameter
+func queryInt(query url.Values, name string, fallback int) int {
+	n, err := strconv.Atoi(query.Get(name))
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a GitHub-style error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"time"
 )
 
 // AppError represents an application error
@@ -35,6 +36,9 @@ const (
 	ErrCodeJSONDecoding = "JSON_DECODING_ERROR"
 	ErrCodeUnauthorized = "UNAUTHORIZED"
 	ErrCodeNotFound     = "NOT_FOUND"
+	ErrCodeForbidden    = "FORBIDDEN"
+	ErrCodeRateLimited  = "RATE_LIMITED"
+	ErrCodeExport       = "EXPORT_ERROR"
 )
 
 // NewValidationError creates a validation AppError
@@ -61,3 +65,15 @@ func NewUnauthorizedError() *AppError {
 func NewNotFoundError(resource string) *AppError {
 	return NewAppError(ErrCodeNotFound, "Resource not found", resource)
 }
+
+func NewForbiddenError(details string) *AppError {
+	return NewAppError(ErrCodeForbidden, "Forbidden", details)
+}
+
+func NewRateLimitedError(resetAt time.Time) *AppError {
+	return NewAppError(ErrCodeRateLimited, "GitHub rate limit exceeded", fmt.Sprintf("resets at %s", resetAt.Format(time.RFC3339)))
+}
+
+func NewExportError(details string) *AppError {
+	return NewAppError(ErrCodeExport, "Export failed", details)
+}